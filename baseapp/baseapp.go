@@ -3,6 +3,7 @@ package baseapp
 import (
 	"fmt"
 	"io"
+	"os"
 	"reflect"
 	"runtime/debug"
 	"strings"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/ColorPlatform/color-sdk/codec"
 	"github.com/ColorPlatform/color-sdk/store"
+	"github.com/ColorPlatform/color-sdk/store/listenkv"
+	"github.com/ColorPlatform/color-sdk/store/snapshots"
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	"github.com/ColorPlatform/color-sdk/version"
 )
@@ -81,6 +84,26 @@ type BaseApp struct {
 
 	// flag for sealing options and parameters to a BaseApp
 	sealed bool
+
+	// halt stops the node when a BeginBlocker requests an upgrade the
+	// running binary cannot apply. Defaults to exiting the process.
+	halt func(err error)
+
+	// snapshots of the state taken every snapshotInterval blocks, keeping the
+	// snapshotKeepRecent most recent ones
+	snapshotStore      *snapshots.Store
+	snapshotInterval   uint64
+	snapshotKeepRecent uint32
+
+	// upgrades of the state applied on load, reporting the applied upgrade
+	// without committing it in a dry run
+	upgrades      []*upgrade
+	upgradeDryRun bool
+	upgradeReport *sdk.UpgradeReport
+
+	// listeners of the writes of each delivered tx, recorded by txWrites
+	listeners []sdk.StateListener
+	txWrites  *listenkv.Recorder
 }
 
 var _ abci.Application = (*BaseApp)(nil)
@@ -103,6 +126,7 @@ func NewBaseApp(
 		queryRouter:    NewQueryRouter(),
 		txDecoder:      txDecoder,
 		fauxMerkleMode: false,
+		halt:           haltProcess,
 	}
 	for _, option := range options {
 		option(app)
@@ -160,10 +184,19 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
-// LoadLatestVersion loads the latest application version. It will panic if
-// called more than once on a running BaseApp.
+// LoadLatestVersion loads the latest application version, and applies the
+// registered upgrade due at the next height, if any. It will panic if called
+// more than once on a running BaseApp.
 func (app *BaseApp) LoadLatestVersion(baseKey *sdk.KVStoreKey) error {
-	err := app.cms.LoadLatestVersion()
+	upgrade, err := app.dueUpgrade()
+	if err != nil {
+		return err
+	}
+	if upgrade != nil {
+		err = app.loadAndUpgrade(upgrade)
+	} else {
+		err = app.cms.LoadLatestVersion()
+	}
 	if err != nil {
 		return err
 	}
@@ -407,6 +440,9 @@ func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 	case "custom":
 		return handleQueryCustom(app, path, req)
+
+	case "snapshot":
+		return handleQuerySnapshot(app, path, req)
 	}
 
 	msg := "unknown query path"
@@ -572,7 +608,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(gasMeter)
 
 	if app.beginBlocker != nil {
-		res = app.beginBlocker(app.deliverState.ctx, req)
+		res = app.runBeginBlocker(req)
 	}
 
 	// set the signed validators for addition to context in deliverTx
@@ -580,6 +616,31 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	return
 }
 
+// runBeginBlocker calls the application BeginBlocker. If it reports that a
+// software upgrade is needed at this height, the node is halted before any
+// state of the block is committed.
+func (app *BaseApp) runBeginBlocker(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(sdk.UpgradeNeededError)
+			if !ok {
+				panic(r)
+			}
+			app.logger.Error(err.Error())
+			app.halt(err)
+		}
+	}()
+
+	return app.beginBlocker(app.deliverState.ctx, req)
+}
+
+// haltProcess exits the process. The deliver state of the current block is
+// never written, so the last committed height stays consistent on disk.
+func haltProcess(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
+
 // CheckTx implements the ABCI interface. It runs the "basic checks" to see
 // whether or not a transaction can possibly be executed, first decoding, then
 // the ante handler (which checks signatures/fees/ValidateBasic), then finally
@@ -616,6 +677,7 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 	} else {
 		result = app.runTx(runTxModeDeliver, txBytes, tx)
 	}
+	app.listenDeliverTx(txBytes)
 
 	return abci.ResponseDeliverTx{
 		Code:      uint32(result.Code),
@@ -731,12 +793,17 @@ func (app *BaseApp) getState(mode runTxMode) *state {
 
 // cacheTxContext returns a new context based off of the provided context with
 // a cache wrapped multi-store.
-func (app *BaseApp) cacheTxContext(ctx sdk.Context, txBytes []byte) (
+func (app *BaseApp) cacheTxContext(ctx sdk.Context, txBytes []byte, mode runTxMode) (
 	sdk.Context, sdk.CacheMultiStore) {
 
 	ms := ctx.MultiStore()
 	// TODO: https://github.com/ColorPlatform/color-sdk/issues/2824
-	msCache := ms.CacheMultiStore()
+	var msCache sdk.CacheMultiStore
+	if lms, ok := ms.(sdk.ListeningMultiStore); ok && mode == runTxModeDeliver && app.txWrites != nil {
+		msCache = lms.CacheMultiStoreWithListener(app.txWrites)
+	} else {
+		msCache = ms.CacheMultiStore()
+	}
 	if msCache.TracingEnabled() {
 		msCache = msCache.SetTracingContext(
 			sdk.TraceContext(
@@ -827,7 +894,7 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		// NOTE: Alternatively, we could require that anteHandler ensures that
 		// writes do not happen if aborted/failed.  This may have some
 		// performance benefits, but it'll be more difficult to get right.
-		anteCtx, msCache = app.cacheTxContext(ctx, txBytes)
+		anteCtx, msCache = app.cacheTxContext(ctx, txBytes, mode)
 
 		newCtx, result, abort := app.anteHandler(anteCtx, tx, mode == runTxModeSimulate)
 		if !newCtx.IsZero() {
@@ -856,7 +923,7 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 	// Create a new context based off of the existing context with a cache wrapped
 	// multi-store in case message processing fails.
-	runMsgCtx, msCache := app.cacheTxContext(ctx, txBytes)
+	runMsgCtx, msCache := app.cacheTxContext(ctx, txBytes, mode)
 	result = app.runMsgs(runMsgCtx, msgs, mode)
 	result.GasWanted = gasWanted
 
@@ -888,6 +955,9 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 // Commit implements the ABCI interface.
 func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	header := app.deliverState.ctx.BlockHeader()
+	if app.upgradeReport != nil && app.upgradeReport.DryRun {
+		panic("cannot commit the state of an upgrade dry run")
+	}
 
 	// write the Deliver state and commit the MultiStore
 	app.deliverState.ms.Write()
@@ -903,6 +973,15 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// empty/reset the deliver state
 	app.deliverState = nil
 
+	// The version is pinned before the next commit can prune it, and unpinned
+	// once the snapshot is taken.
+	if app.snapshotInterval > 0 && uint64(commitID.Version)%app.snapshotInterval == 0 {
+		if snapshotter, ok := app.cms.(sdk.Snapshotter); ok {
+			snapshotter.PinVersion(commitID.Version)
+			go app.snapshot(snapshotter, commitID.Version)
+		}
+	}
+
 	return abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
@@ -1253,3 +1253,38 @@ func TestGetMaximumBlockGas(t *testing.T) {
 	app.setConsensusParams(&abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: -5000000}})
 	require.Panics(t, func() { app.getMaximumBlockGas() })
 }
+
+func TestBeginBlockHaltsOnUpgradeNeeded(t *testing.T) {
+	upgradeHeight := int64(2)
+	beginBlocker := func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		if ctx.BlockHeight() == upgradeHeight {
+			panic(sdk.UpgradeNeededError{Name: "v2", Height: upgradeHeight, Info: "new binary"})
+		}
+		return abci.ResponseBeginBlock{}
+	}
+	app := setupBaseApp(t, func(bapp *BaseApp) { bapp.SetBeginBlocker(beginBlocker) })
+
+	var halted error
+	app.halt = func(err error) { halted = err }
+
+	app.InitChain(abci.RequestInitChain{})
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	require.Nil(t, halted)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	header = abci.Header{Height: upgradeHeight}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	require.Equal(t, sdk.UpgradeNeededError{Name: "v2", Height: upgradeHeight, Info: "new binary"}, halted)
+	require.Contains(t, halted.Error(), `UPGRADE "v2" NEEDED`)
+
+	// any other panic is propagated
+	app = setupBaseApp(t, func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			panic("boom")
+		})
+	})
+	app.InitChain(abci.RequestInitChain{})
+	require.Panics(t, func() { app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}}) })
+}
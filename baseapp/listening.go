@@ -0,0 +1,21 @@
+package baseapp
+
+import (
+	"fmt"
+)
+
+// listenDeliverTx passes the writes of the tx just delivered to the
+// listeners. It panics if a listener fails, halting the node before the
+// block is committed.
+func (app *BaseApp) listenDeliverTx(txBytes []byte) {
+	if app.txWrites == nil {
+		return
+	}
+	height := app.deliverState.ctx.BlockHeight()
+	writes := app.txWrites.PopWrites()
+	for _, listener := range app.listeners {
+		if err := listener.ListenDeliverTx(height, txBytes, writes); err != nil {
+			panic(fmt.Sprintf("failed to stream the writes of a tx at height %d: %v", height, err))
+		}
+	}
+}
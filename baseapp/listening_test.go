@@ -0,0 +1,93 @@
+package baseapp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+type txWrites struct {
+	height int64
+	tx     []byte
+	writes []sdk.StoreKVPair
+}
+
+type testListener struct {
+	txs     []txWrites
+	commits map[int64][]sdk.StoreKVPair
+}
+
+func (l *testListener) ListenDeliverTx(height int64, tx []byte, writes []sdk.StoreKVPair) error {
+	l.txs = append(l.txs, txWrites{height, tx, writes})
+	return nil
+}
+
+func (l *testListener) ListenCommit(version int64, writes []sdk.StoreKVPair) error {
+	l.commits[version] = writes
+	return nil
+}
+
+func TestStateListeners(t *testing.T) {
+	anteKey := []byte("ante-key")
+	deliverKey := []byte("deliver-key")
+	listener := &testListener{commits: make(map[int64][]sdk.StoreKVPair)}
+	app := setupBaseApp(t,
+		func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) },
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey2, deliverKey))
+		},
+		SetStateListeners(listener),
+	)
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	tx := newTxCounter(0, 0)
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+	require.True(t, app.DeliverTx(txBytes).IsOK())
+
+	// the writes of a failed handler are dropped, but not those of the ante
+	failing := newTxCounter(1, 1)
+	failing.setFailOnHandler(true)
+	failingBytes, err := codec.MarshalBinaryLengthPrefixed(failing)
+	require.NoError(t, err)
+	require.False(t, app.DeliverTx(failingBytes).IsOK())
+
+	// txs which can't be decoded are streamed too
+	require.False(t, app.DeliverTx([]byte("invalid")).IsOK())
+
+	// writes of the block itself are only part of the commit
+	app.deliverState.ctx.KVStore(capKey1).Set([]byte("block-key"), []byte("value"))
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	anteWrite := func(counter int64) sdk.StoreKVPair {
+		return sdk.StoreKVPair{StoreKey: capKey1.Name(), Key: anteKey, Value: varint(counter)}
+	}
+	deliverWrite := sdk.StoreKVPair{StoreKey: capKey2.Name(), Key: deliverKey, Value: varint(1)}
+	require.Equal(t, []txWrites{
+		{1, txBytes, []sdk.StoreKVPair{anteWrite(1), deliverWrite}},
+		{1, failingBytes, []sdk.StoreKVPair{anteWrite(2)}},
+		{1, []byte("invalid"), nil},
+	}, listener.txs)
+
+	// the commit holds the final value of each key
+	require.Equal(t, []sdk.StoreKVPair{
+		{StoreKey: capKey1.Name(), Key: anteKey, Value: varint(2)},
+		{StoreKey: capKey1.Name(), Key: []byte("block-key"), Value: []byte("value")},
+		deliverWrite,
+	}, listener.commits[1])
+}
+
+func varint(i int64) []byte {
+	bz := make([]byte, 8)
+	return bz[:binary.PutVarint(bz, i)]
+}
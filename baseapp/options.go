@@ -7,6 +7,8 @@ import (
 	dbm "github.com/ColorPlatform/prism/libs/db"
 
 	"github.com/ColorPlatform/color-sdk/store"
+	"github.com/ColorPlatform/color-sdk/store/listenkv"
+	"github.com/ColorPlatform/color-sdk/store/snapshots"
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
@@ -18,6 +20,43 @@ func SetPruning(opts sdk.PruningOptions) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.cms.SetPruning(opts) }
 }
 
+// SetSnapshotStore sets the store of the state snapshots of the app
+func SetSnapshotStore(snapshotStore *snapshots.Store) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.snapshotStore = snapshotStore }
+}
+
+// SetSnapshotInterval sets the number of blocks between the state snapshots
+// taken by the app. Zero disables them.
+func SetSnapshotInterval(interval uint64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.snapshotInterval = interval }
+}
+
+// SetSnapshotKeepRecent sets the number of recent snapshots the app keeps.
+// Zero keeps all of them.
+func SetSnapshotKeepRecent(keepRecent uint32) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.snapshotKeepRecent = keepRecent }
+}
+
+// SetUpgradeDryRun sets whether the app discards the upgrade it applies on
+// load, after reporting it. Such an app must not process blocks.
+func SetUpgradeDryRun(dryRun bool) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.upgradeDryRun = dryRun }
+}
+
+// SetStateListeners adds listeners of the writes to the state made by each
+// delivered tx and committed with each block.
+func SetStateListeners(listeners ...sdk.StateListener) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if bap.txWrites == nil {
+			bap.txWrites = listenkv.NewRecorder()
+		}
+		for _, listener := range listeners {
+			bap.listeners = append(bap.listeners, listener)
+			bap.cms.AddListener(listener)
+		}
+	}
+}
+
 // SetMinGasPrices returns an option that sets the minimum gas prices on the app.
 func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
 	gasPrices, err := sdk.ParseDecCoins(gasPricesStr)
@@ -0,0 +1,18 @@
+package baseapp
+
+import (
+	"errors"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// PruneStores deletes the versions of the loaded state that the pruning
+// options of the app don't keep, and returns the number of versions deleted in
+// each store.
+func (app *BaseApp) PruneStores() (map[string]int, error) {
+	pruner, ok := app.cms.(sdk.Pruner)
+	if !ok {
+		return nil, errors.New("multistore doesn't support pruning")
+	}
+	return pruner.Prune()
+}
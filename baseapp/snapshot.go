@@ -0,0 +1,127 @@
+package baseapp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/store/snapshots"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// CreateSnapshot takes a snapshot of the committed state at the given height
+// and saves it to the snapshot store of the app.
+func (app *BaseApp) CreateSnapshot(height int64) (*snapshots.Snapshot, error) {
+	if app.snapshotStore == nil {
+		return nil, errors.New("no snapshot store")
+	}
+	snapshotter, ok := app.cms.(sdk.Snapshotter)
+	if !ok {
+		return nil, errors.New("multistore doesn't support snapshots")
+	}
+	snapshotter.PinVersion(height)
+	defer snapshotter.UnpinVersion(height)
+	return app.snapshotStore.Save(height, snapshots.DefaultChunkSize, func(w io.Writer) ([]byte, error) {
+		return snapshotter.Snapshot(height, w)
+	})
+}
+
+// RestoreSnapshot restores the state of a fresh app from the snapshot at the
+// given height in its snapshot store, and loads it. The tendermint block and
+// state stores are not restored.
+func (app *BaseApp) RestoreSnapshot(height int64) error {
+	if app.snapshotStore == nil {
+		return errors.New("no snapshot store")
+	}
+	snapshotter, ok := app.cms.(sdk.Snapshotter)
+	if !ok {
+		return errors.New("multistore doesn't support snapshots")
+	}
+
+	snapshot, state, err := app.snapshotStore.Load(height)
+	if err != nil {
+		return err
+	}
+	defer state.Close()
+	err = snapshotter.Restore(snapshot.Height, snapshot.AppHash, state)
+	if err != nil {
+		return err
+	}
+
+	// the app was initialized from the empty state it had before the restore
+	baseKey := app.baseKey
+	app.baseKey = nil
+	return app.initFromMainStore(baseKey)
+}
+
+// snapshot takes a snapshot of the state committed at the given height in the
+// background, and prunes the old snapshots. The height must have been pinned
+// by the caller, it is unpinned here.
+func (app *BaseApp) snapshot(snapshotter sdk.Snapshotter, height int64) {
+	defer snapshotter.UnpinVersion(height)
+	snapshot, err := app.CreateSnapshot(height)
+	if err != nil {
+		app.logger.Error("failed to take state snapshot", "height", height, "err", err)
+		return
+	}
+	app.logger.Info("took state snapshot", "height", height, "hash", fmt.Sprintf("%X", snapshot.Hash))
+
+	if app.snapshotKeepRecent == 0 {
+		return
+	}
+	pruned, err := app.snapshotStore.Prune(app.snapshotKeepRecent)
+	if err != nil {
+		app.logger.Error("failed to prune state snapshots", "err", err)
+		return
+	}
+	if pruned > 0 {
+		app.logger.Debug("pruned state snapshots", "pruned", pruned)
+	}
+}
+
+func handleQuerySnapshot(app *BaseApp, path []string, _ abci.RequestQuery) (res abci.ResponseQuery) {
+	// "/snapshot" prefix for snapshot queries
+	if app.snapshotStore == nil {
+		return sdk.ErrUnknownRequest("node doesn't serve snapshots").QueryResult()
+	}
+
+	switch {
+	case len(path) == 2 && path[1] == "list":
+		list, err := app.snapshotStore.List()
+		if err != nil {
+			return sdk.ErrInternal(err.Error()).QueryResult()
+		}
+		return abci.ResponseQuery{
+			Code:      uint32(sdk.CodeOK),
+			Codespace: string(sdk.CodespaceRoot),
+			Value:     codec.Cdc.MustMarshalJSON(list),
+		}
+
+	case len(path) == 4 && path[1] == "chunk":
+		height, err := strconv.ParseInt(path[2], 10, 64)
+		if err != nil {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("invalid snapshot height %s", path[2])).QueryResult()
+		}
+		index, err := strconv.ParseUint(path[3], 10, 32)
+		if err != nil {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("invalid chunk index %s", path[3])).QueryResult()
+		}
+		chunk, err := app.snapshotStore.LoadChunk(height, uint32(index))
+		if err != nil {
+			return sdk.ErrUnknownRequest(err.Error()).QueryResult()
+		}
+		return abci.ResponseQuery{
+			Code:      uint32(sdk.CodeOK),
+			Codespace: string(sdk.CodespaceRoot),
+			Height:    height,
+			Value:     chunk,
+		}
+	}
+
+	msg := "Expected path is snapshot list or snapshot chunk <height> <index>"
+	return sdk.ErrUnknownRequest(msg).QueryResult()
+}
@@ -0,0 +1,69 @@
+package baseapp
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/store"
+	"github.com/ColorPlatform/color-sdk/store/snapshots"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+func newSnapshotApp(t *testing.T, db dbm.DB, snapshotStore *snapshots.Store) *BaseApp {
+	app := NewBaseApp(t.Name(), defaultLogger(), db, nil,
+		SetPruning(store.PruneSyncable), SetSnapshotStore(snapshotStore))
+	app.MountStores(capKey1, capKey2)
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	return app
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapshotStore, err := snapshots.NewStore(dir)
+	require.NoError(t, err)
+
+	app := newSnapshotApp(t, dbm.NewMemDB(), snapshotStore)
+	var commitID sdk.CommitID
+	for height := int64(1); height <= 3; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey2).Set([]byte{byte(height)}, []byte("value"))
+		app.Commit()
+		commitID = app.LastCommitID()
+	}
+
+	snapshot, err := app.CreateSnapshot(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), snapshot.Height)
+	require.Equal(t, commitID.Hash, snapshot.AppHash)
+
+	// the snapshot is served by queries
+	res := app.Query(abci.RequestQuery{Path: "/snapshot/list"})
+	require.True(t, res.IsOK(), res.Log)
+	var list []*snapshots.Snapshot
+	require.NoError(t, codec.Cdc.UnmarshalJSON(res.Value, &list))
+	require.Equal(t, []*snapshots.Snapshot{snapshot}, list)
+	res = app.Query(abci.RequestQuery{Path: "/snapshot/chunk/3/0"})
+	require.True(t, res.IsOK(), res.Log)
+	require.NotEmpty(t, res.Value)
+	res = app.Query(abci.RequestQuery{Path: "/snapshot/chunk/3/1"})
+	require.False(t, res.IsOK())
+
+	// a fresh app is restored from the snapshot
+	restored := newSnapshotApp(t, dbm.NewMemDB(), snapshotStore)
+	require.NoError(t, restored.RestoreSnapshot(3))
+	require.Equal(t, int64(3), restored.LastBlockHeight())
+	require.Equal(t, commitID, restored.LastCommitID())
+	ctx := restored.NewContext(true, abci.Header{})
+	require.Equal(t, []byte("value"), ctx.KVStore(capKey2).Get([]byte{2}))
+
+	// but not an app with a state
+	require.Error(t, app.RestoreSnapshot(3))
+}
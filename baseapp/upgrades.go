@@ -0,0 +1,135 @@
+package baseapp
+
+import (
+	"fmt"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// MigrationHandler migrates the state of a module when an upgrade is applied,
+// and returns the number of keys it migrated.
+type MigrationHandler func(ctx sdk.Context) (migrated int, err error)
+
+// upgrade is an upgrade of the state registered with the app, applied when
+// the app loads the state committed just before its height.
+type upgrade struct {
+	name          string
+	height        int64
+	storeUpgrades *sdk.StoreUpgrades
+	migrations    []migration // in order of registration
+}
+
+type migration struct {
+	module  string
+	handler MigrationHandler
+}
+
+// RegisterUpgrade registers an upgrade of the state with the given name, which
+// the app applies when loading the latest version if it was committed just
+// before the given height, i.e. when the previous binary halted for the
+// upgrade. The mounted stores must be the stores after the upgrade, with the
+// given store upgrades applied, and a handler for the upgrade plan must be set
+// in the upgrade keeper so that the upgraded binary doesn't halt again.
+func (app *BaseApp) RegisterUpgrade(name string, height int64, storeUpgrades *sdk.StoreUpgrades) {
+	if app.sealed {
+		panic("RegisterUpgrade() on sealed BaseApp")
+	}
+	if height <= 1 {
+		panic(fmt.Sprintf("upgrade %s must be after the first block, got height %d", name, height))
+	}
+	for _, u := range app.upgrades {
+		if u.name == name || u.height == height {
+			panic(fmt.Sprintf("upgrade %s at height %d conflicts with upgrade %s at height %d",
+				name, height, u.name, u.height))
+		}
+	}
+	app.upgrades = append(app.upgrades, &upgrade{
+		name:          name,
+		height:        height,
+		storeUpgrades: storeUpgrades,
+	})
+}
+
+// RegisterMigration registers the handler migrating the state of a module
+// when the upgrade with the given name is applied. Migrations run in the
+// order they are registered in, after the store upgrades.
+func (app *BaseApp) RegisterMigration(upgradeName, module string, handler MigrationHandler) {
+	if app.sealed {
+		panic("RegisterMigration() on sealed BaseApp")
+	}
+	for _, u := range app.upgrades {
+		if u.name != upgradeName {
+			continue
+		}
+		for _, m := range u.migrations {
+			if m.module == module {
+				panic(fmt.Sprintf("module %s already has a migration for upgrade %s", module, upgradeName))
+			}
+		}
+		u.migrations = append(u.migrations, migration{module: module, handler: handler})
+		return
+	}
+	panic(fmt.Sprintf("unknown upgrade %s", upgradeName))
+}
+
+// UpgradeReport returns the report of the upgrade applied when the app was
+// loaded, if any.
+func (app *BaseApp) UpgradeReport() *sdk.UpgradeReport {
+	return app.upgradeReport
+}
+
+// dueUpgrade returns the upgrade to apply when loading the latest version.
+func (app *BaseApp) dueUpgrade() (*upgrade, error) {
+	if len(app.upgrades) == 0 {
+		return nil, nil
+	}
+	upgrader, ok := app.cms.(sdk.StoreUpgrader)
+	if !ok {
+		return nil, fmt.Errorf("multistore doesn't support upgrades")
+	}
+	latest := upgrader.GetLatestVersion()
+	for _, u := range app.upgrades {
+		if u.height == latest+1 {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+// loadAndUpgrade loads the latest version, upgrading its stores, and runs the
+// migrations of the upgrade. In a dry run, the changes of the migrations are
+// discarded, and the upgraded stores cannot be committed.
+func (app *BaseApp) loadAndUpgrade(u *upgrade) error {
+	upgrader := app.cms.(sdk.StoreUpgrader)
+	stores, err := upgrader.LoadVersionAndUpgrade(u.height-1, u.storeUpgrades)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade the stores for %s: %v", u.name, err)
+	}
+	report := &sdk.UpgradeReport{
+		Name:    u.name,
+		Height:  u.height,
+		DryRun:  app.upgradeDryRun,
+		Stores:  stores,
+		Modules: make(map[string]int),
+	}
+
+	ms := app.cms.CacheMultiStore()
+	ctx := sdk.NewContext(ms, abci.Header{Height: u.height}, false, app.logger)
+	for _, m := range u.migrations {
+		migrated, err := m.handler(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to migrate module %s for %s: %v", m.module, u.name, err)
+		}
+		report.Modules[m.module] = migrated
+	}
+	app.upgradeReport = report
+	if app.upgradeDryRun {
+		app.logger.Info("dry run of upgrade", "report", report.String())
+		return nil
+	}
+	ms.Write()
+	app.logger.Info("applied upgrade", "report", report.String())
+	return nil
+}
@@ -0,0 +1,95 @@
+package baseapp
+
+import (
+	"testing"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+var capKey3 = sdk.NewKVStoreKey("key3")
+
+// newUpgradedApp returns an app renaming the store key2 to key3 at height 3,
+// and migrating the values of key3.
+func newUpgradedApp(t *testing.T, db dbm.DB, options ...func(*BaseApp)) *BaseApp {
+	app := NewBaseApp(t.Name(), defaultLogger(), db, nil, options...)
+	app.MountStores(capKey1, capKey3)
+	app.RegisterUpgrade("v2", 3, &sdk.StoreUpgrades{
+		Renamed: []sdk.StoreRename{{OldKey: capKey2.Name(), NewKey: capKey3.Name()}},
+	})
+	app.RegisterMigration("v2", "module", func(ctx sdk.Context) (int, error) {
+		store := ctx.KVStore(capKey3)
+		iter := store.Iterator(nil, nil)
+		defer iter.Close()
+		migrated := 0
+		for ; iter.Valid(); iter.Next() {
+			store.Set(iter.Key(), append(iter.Value(), []byte("-v2")...))
+			migrated++
+		}
+		return migrated, nil
+	})
+	return app
+}
+
+func TestUpgradeOnLoad(t *testing.T) {
+	db := dbm.NewMemDB()
+	app := NewBaseApp(t.Name(), defaultLogger(), db, nil)
+	app.MountStores(capKey1, capKey2)
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey2).Set([]byte{byte(height)}, []byte("value"))
+		app.Commit()
+	}
+
+	// a dry run reports the upgrade without applying it
+	app = newUpgradedApp(t, db, SetUpgradeDryRun(true))
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	expected := &sdk.UpgradeReport{
+		Name:    "v2",
+		Height:  3,
+		DryRun:  true,
+		Stores:  map[string]int{capKey3.Name(): 2},
+		Modules: map[string]int{"module": 2},
+	}
+	require.Equal(t, expected, app.UpgradeReport())
+	require.Equal(t, []byte("value"), app.cms.GetKVStore(capKey3).Get([]byte{1}))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	require.Panics(t, func() { app.Commit() })
+
+	// the upgrade is applied when loading the state before its height
+	app = newUpgradedApp(t, db)
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	expected.DryRun = false
+	require.Equal(t, expected, app.UpgradeReport())
+	require.Equal(t, []byte("value-v2"), app.cms.GetKVStore(capKey3).Get([]byte{1}))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	app.Commit()
+
+	// and only then
+	app = newUpgradedApp(t, db)
+	require.NoError(t, app.LoadLatestVersion(capKey1))
+	require.Nil(t, app.UpgradeReport())
+	require.Equal(t, int64(3), app.LastBlockHeight())
+	require.Equal(t, []byte("value-v2"), app.cms.GetKVStore(capKey3).Get([]byte{2}))
+}
+
+func TestRegisterUpgrade(t *testing.T) {
+	app := newBaseApp(t.Name())
+	app.RegisterUpgrade("v2", 10, nil)
+	require.Panics(t, func() { app.RegisterUpgrade("v2", 20, nil) })
+	require.Panics(t, func() { app.RegisterUpgrade("v3", 10, nil) })
+	require.Panics(t, func() { app.RegisterUpgrade("v3", 1, nil) })
+
+	handler := func(ctx sdk.Context) (int, error) { return 0, nil }
+	app.RegisterMigration("v2", "module", handler)
+	require.Panics(t, func() { app.RegisterMigration("v2", "module", handler) })
+	require.Panics(t, func() { app.RegisterMigration("v3", "module", handler) })
+
+	app.Seal()
+	require.Panics(t, func() { app.RegisterUpgrade("v3", 20, nil) })
+	require.Panics(t, func() { app.RegisterMigration("v2", "other", handler) })
+}
@@ -47,6 +47,8 @@ import (
 	slashingrest "github.com/ColorPlatform/color-sdk/x/slashing/client/rest"
 	"github.com/ColorPlatform/color-sdk/x/staking"
 	stakingrest "github.com/ColorPlatform/color-sdk/x/staking/client/rest"
+	supplyrest "github.com/ColorPlatform/color-sdk/x/supply/client/rest"
+	upgraderest "github.com/ColorPlatform/color-sdk/x/upgrade/client/rest"
 
 	abci "github.com/ColorPlatform/prism/abci/types"
 	tmcfg "github.com/ColorPlatform/prism/config"
@@ -416,6 +418,8 @@ func registerRoutes(rs *RestServer) {
 	slashingrest.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc, rs.KeyBase)
 	govrest.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 	mintrest.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
+	upgraderest.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
+	supplyrest.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 }
 
 // Request makes a test LCD test request. It returns a response object and a
@@ -23,10 +23,16 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/params"
 	"github.com/ColorPlatform/color-sdk/x/slashing"
 	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 const (
 	appName = "GaiaApp"
+
+	// upgrade burning the dropped gov deposits instead of sending them to an address
+	upgradeDepositBurn = "deposit-burn"
+
 	// DefaultKeyPass contains the default key password for genesis transactions
 	DefaultKeyPass = "12345678"
 )
@@ -54,6 +60,8 @@ type GaiaApp struct {
 	keyDistr         *sdk.KVStoreKey
 	tkeyDistr        *sdk.TransientStoreKey
 	keyGov           *sdk.KVStoreKey
+	keyUpgrade       *sdk.KVStoreKey
+	keySupply        *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
 	keyParams        *sdk.KVStoreKey
 	tkeyParams       *sdk.TransientStoreKey
@@ -67,6 +75,8 @@ type GaiaApp struct {
 	mintKeeper          mint.Keeper
 	distrKeeper         distr.Keeper
 	govKeeper           gov.Keeper
+	upgradeKeeper       upgrade.Keeper
+	supplyKeeper        supply.Keeper
 	crisisKeeper        crisis.Keeper
 	paramsKeeper        params.Keeper
 }
@@ -93,6 +103,8 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		tkeyDistr:        sdk.NewTransientStoreKey(distr.TStoreKey),
 		keySlashing:      sdk.NewKVStoreKey(slashing.StoreKey),
 		keyGov:           sdk.NewKVStoreKey(gov.StoreKey),
+		keyUpgrade:       sdk.NewKVStoreKey(upgrade.StoreKey),
+		keySupply:        sdk.NewKVStoreKey(supply.StoreKey),
 		keyFeeCollection: sdk.NewKVStoreKey(auth.FeeStoreKey),
 		keyParams:        sdk.NewKVStoreKey(params.StoreKey),
 		tkeyParams:       sdk.NewTransientStoreKey(params.TStoreKey),
@@ -124,10 +136,6 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		app.bankKeeper, app.paramsKeeper.Subspace(staking.DefaultParamspace),
 		staking.DefaultCodespace,
 	)
-	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint,
-		app.paramsKeeper.Subspace(mint.DefaultParamspace),
-		&stakingKeeper, app.feeCollectionKeeper,
-	)
 	app.distrKeeper = distr.NewKeeper(
 		app.cdc,
 		app.keyDistr,
@@ -135,16 +143,32 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		app.bankKeeper, &stakingKeeper, app.feeCollectionKeeper,
 		distr.DefaultCodespace,
 	)
+	app.supplyKeeper = supply.NewKeeper(
+		app.cdc,
+		app.keySupply,
+		app.accountKeeper, &stakingKeeper, app.distrKeeper, app.feeCollectionKeeper,
+	)
+	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint,
+		app.paramsKeeper.Subspace(mint.DefaultParamspace),
+		&stakingKeeper, app.feeCollectionKeeper, app.supplyKeeper,
+	)
 	app.slashingKeeper = slashing.NewKeeper(
 		app.cdc,
 		app.keySlashing,
 		&stakingKeeper, app.paramsKeeper.Subspace(slashing.DefaultParamspace),
 		slashing.DefaultCodespace,
 	)
-	app.govKeeper = gov.NewKeeper(
+	app.upgradeKeeper = upgrade.NewKeeper(
+		app.cdc,
+		app.keyUpgrade,
+		upgrade.DefaultCodespace,
+	)
+	govKeeper := gov.NewKeeper(
 		app.cdc,
 		app.distrKeeper,
 		app.mintKeeper,
+		app.supplyKeeper,
+		app.upgradeKeeper,
 		app.keyGov,
 		app.paramsKeeper, app.paramsKeeper.Subspace(gov.DefaultParamspace), app.bankKeeper, &app.stakingKeeper, &stakingKeeper,
 		gov.DefaultCodespace,
@@ -161,12 +185,25 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	// modified like below:
 	app.stakingKeeper = *stakingKeeper.SetHooks(
 		NewStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()),
+	).SetSupplyKeeper(app.supplyKeeper)
+
+	// register the handlers of the upgrades this binary can apply
+	app.upgradeKeeper.SetUpgradeHandler(upgradeDepositBurn, func(ctx sdk.Context, plan upgrade.Plan) {
+		app.govKeeper.MigrateBurnedDeposits(ctx)
+	})
+
+	// register the governance hooks, modules following the proposal lifecycle
+	// add their hooks here
+	app.govKeeper = *govKeeper.SetHooks(
+		gov.NewMultiGovHooks(),
 	)
 
 	// register the crisis routes
 	bank.RegisterInvariants(&app.crisisKeeper, app.accountKeeper)
 	distr.RegisterInvariants(&app.crisisKeeper, app.distrKeeper, app.stakingKeeper)
 	staking.RegisterInvariants(&app.crisisKeeper, app.stakingKeeper, app.feeCollectionKeeper, app.distrKeeper, app.accountKeeper)
+	supply.RegisterInvariants(&app.crisisKeeper, app.supplyKeeper)
+	gov.RegisterInvariants(&app.crisisKeeper, app.govKeeper)
 
 	// register message routes
 	app.Router().
@@ -183,16 +220,19 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		AddRoute(gov.QuerierRoute, gov.NewQuerier(app.govKeeper)).
 		AddRoute(slashing.QuerierRoute, slashing.NewQuerier(app.slashingKeeper, app.cdc)).
 		AddRoute(staking.QuerierRoute, staking.NewQuerier(app.stakingKeeper, app.cdc)).
-		AddRoute(mint.QuerierRoute, mint.NewQuerier(app.mintKeeper))
+		AddRoute(mint.QuerierRoute, mint.NewQuerier(app.mintKeeper)).
+		AddRoute(upgrade.QuerierRoute, upgrade.NewQuerier(app.upgradeKeeper)).
+		AddRoute(supply.QuerierRoute, supply.NewQuerier(app.supplyKeeper))
 
 	// initialize BaseApp
 	app.MountStores(app.keyMain, app.keyAccount, app.keyStaking, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams,
+		app.keySlashing, app.keyGov, app.keyUpgrade, app.keySupply, app.keyFeeCollection, app.keyParams,
 		app.tkeyParams, app.tkeyStaking, app.tkeyDistr,
 	)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.feeCollectionKeeper))
+	app.SetAnteHandler(crisis.NewAnteHandler(app.crisisKeeper,
+		auth.NewAnteHandler(app.accountKeeper, app.feeCollectionKeeper), gov.RouterKey))
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -221,6 +261,9 @@ func MakeCodec() *codec.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// apply a scheduled software upgrade, or halt if this binary does not support it
+	upgrade.BeginBlocker(ctx, app.upgradeKeeper)
+
 	// mint new tokens for the previous block
 	mint.BeginBlocker(ctx, app.mintKeeper)
 
@@ -245,9 +288,11 @@ func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.R
 	tags := gov.EndBlocker(ctx, app.govKeeper)
 	validatorUpdates, endBlockerTags := staking.EndBlocker(ctx, app.stakingKeeper)
 	tags = append(tags, endBlockerTags...)
+	tags = append(tags, crisis.EndBlocker(ctx, app.crisisKeeper)...)
 
+	// node-local checks, they do not affect the consensus
 	if app.invCheckPeriod != 0 && ctx.BlockHeight()%int64(app.invCheckPeriod) == 0 {
-		app.assertRuntimeInvariants()
+		crisis.AssertInvariants(ctx, app.crisisKeeper)
 	}
 
 	return abci.ResponseEndBlock{
@@ -283,6 +328,10 @@ func (app *GaiaApp) initFromGenesisState(ctx sdk.Context, genesisState GenesisSt
 	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
 	crisis.InitGenesis(ctx, app.crisisKeeper, genesisState.CrisisData)
 	mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
+	upgrade.InitGenesis(ctx, app.upgradeKeeper, genesisState.UpgradeData)
+
+	// the supply starts from the coins held by the modules initialized above
+	supply.InitGenesis(ctx, app.supplyKeeper, genesisState.SupplyData)
 
 	// validate genesis state
 	if err := GaiaValidateGenesisState(genesisState); err != nil {
@@ -338,7 +387,7 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	}
 
 	// assert runtime invariants
-	app.assertRuntimeInvariants()
+	crisis.AssertInvariants(ctx, app.crisisKeeper)
 
 	return abci.ResponseInitChain{
 		Validators: validators,
@@ -7,9 +7,9 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/bank"
 	"github.com/ColorPlatform/color-sdk/x/crisis"
 
-	"github.com/stretchr/testify/require"
 	"github.com/ColorPlatform/prism/libs/db"
 	"github.com/ColorPlatform/prism/libs/log"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ColorPlatform/color-sdk/codec"
 	"github.com/ColorPlatform/color-sdk/x/auth"
@@ -18,6 +18,8 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/mint"
 	"github.com/ColorPlatform/color-sdk/x/slashing"
 	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 
 	abci "github.com/ColorPlatform/prism/abci/types"
 )
@@ -38,6 +40,8 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 		gov.DefaultGenesisState(),
 		crisis.DefaultGenesisState(),
 		slashing.DefaultGenesisState(),
+		supply.DefaultGenesisState(),
+		upgrade.DefaultGenesisState(),
 	)
 
 	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -17,6 +17,8 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/mint"
 	"github.com/ColorPlatform/color-sdk/x/slashing"
 	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 // export the state of gaia for a genesis file
@@ -49,6 +51,8 @@ func (app *GaiaApp) ExportAppStateAndValidators(forZeroHeight bool, jailWhiteLis
 		gov.ExportGenesis(ctx, app.govKeeper),
 		crisis.ExportGenesis(ctx, app.crisisKeeper),
 		slashing.ExportGenesis(ctx, app.slashingKeeper),
+		supply.ExportGenesis(ctx, app.supplyKeeper),
+		upgrade.ExportGenesis(ctx, app.upgradeKeeper),
 	)
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -78,7 +82,7 @@ func (app *GaiaApp) prepForZeroHeightGenesis(ctx sdk.Context, jailWhiteList []st
 	}
 
 	/* Just to be safe, assert the invariants on current state. */
-	app.assertRuntimeInvariantsOnContext(ctx)
+	crisis.AssertInvariants(ctx, app.crisisKeeper)
 
 	/* Handle fee distribution state. */
 
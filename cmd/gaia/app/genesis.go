@@ -23,6 +23,8 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/mint"
 	"github.com/ColorPlatform/color-sdk/x/slashing"
 	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 var (
@@ -42,6 +44,8 @@ type GenesisState struct {
 	GovData      gov.GenesisState      `json:"gov"`
 	CrisisData   crisis.GenesisState   `json:"crisis"`
 	SlashingData slashing.GenesisState `json:"slashing"`
+	SupplyData   supply.GenesisState   `json:"supply"`
+	UpgradeData  upgrade.GenesisState  `json:"upgrade"`
 	GenTxs       []json.RawMessage     `json:"gentxs"`
 }
 
@@ -49,7 +53,8 @@ func NewGenesisState(accounts []GenesisAccount, authData auth.GenesisState,
 	bankData bank.GenesisState,
 	stakingData staking.GenesisState, mintData mint.GenesisState,
 	distrData distr.GenesisState, govData gov.GenesisState, crisisData crisis.GenesisState,
-	slashingData slashing.GenesisState) GenesisState {
+	slashingData slashing.GenesisState, supplyData supply.GenesisState,
+	upgradeData upgrade.GenesisState) GenesisState {
 
 	return GenesisState{
 		Accounts:     accounts,
@@ -61,6 +66,8 @@ func NewGenesisState(accounts []GenesisAccount, authData auth.GenesisState,
 		GovData:      govData,
 		CrisisData:   crisisData,
 		SlashingData: slashingData,
+		SupplyData:   supplyData,
+		UpgradeData:  upgradeData,
 	}
 }
 
@@ -214,6 +221,8 @@ func NewDefaultGenesisState() GenesisState {
 		GovData:      gov.DefaultGenesisState(),
 		CrisisData:   crisis.DefaultGenesisState(),
 		SlashingData: slashing.DefaultGenesisState(),
+		SupplyData:   supply.DefaultGenesisState(),
+		UpgradeData:  upgrade.DefaultGenesisState(),
 		GenTxs:       nil,
 	}
 }
@@ -253,6 +262,12 @@ func GaiaValidateGenesisState(genesisState GenesisState) error {
 	if err := crisis.ValidateGenesis(genesisState.CrisisData); err != nil {
 		return err
 	}
+	if err := supply.ValidateGenesis(genesisState.SupplyData); err != nil {
+		return err
+	}
+	if err := upgrade.ValidateGenesis(genesisState.UpgradeData); err != nil {
+		return err
+	}
 
 	return slashing.ValidateGenesis(genesisState.SlashingData)
 }
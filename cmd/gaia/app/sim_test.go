@@ -168,8 +168,9 @@ func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account, genesisTimest
 	govGenesis := gov.GenesisState{
 		StartingProposalID: uint64(r.Intn(100)),
 		DepositParams: gov.DepositParams{
-			MinDeposit:       sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(r.Intn(1e3)))},
+			MinDeposit:       sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(r.Intn(1e3)+1))},
 			MaxDepositPeriod: vp,
+			DroppedDeposits:  []gov.DroppedDepositPolicy{gov.DroppedDepositsBurn, gov.DroppedDepositsCommunityPool}[r.Intn(2)],
 		},
 		VotingParams: gov.VotingParams{
 			VotingPeriod: vp,
@@ -179,6 +180,15 @@ func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account, genesisTimest
 			Threshold: sdk.NewDecWithPrec(5, 1),
 			// Veto:      sdk.NewDecWithPrec(334, 3),
 		},
+		FundingParams: gov.FundingParams{
+			CycleDuration: time.Duration(simulation.RandIntBetween(r, 60*60, 60*60*24*28)) * time.Second,
+			FreezeWindow:  time.Duration(r.Intn(60*60)) * time.Second,
+			MaxCycleCount: uint64(simulation.RandIntBetween(r, 1, 6)),
+			TreasuryShare: sdk.NewDecWithPrec(int64(simulation.RandIntBetween(r, 1, 10)), 1),
+			Policy: []gov.FundingPolicy{
+				gov.FundingPolicySkip, gov.FundingPolicyPartial, gov.FundingPolicyCarryOver,
+			}[r.Intn(3)],
+		},
 	}
 	fmt.Printf("Selected randomly generated governance parameters:\n\t%+v\n", govGenesis)
 
@@ -188,6 +198,8 @@ func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account, genesisTimest
 			UnbondingTime: time.Duration(simulation.RandIntBetween(r, 60, 60*60*24*3*2)) * time.Second,
 			MaxValidators: uint16(r.Intn(250) + 1),
 			BondDenom:     sdk.DefaultBondDenom,
+			// the council is made of the largest delegators
+			CouncilMemberMinCoin: sdk.NewDec(amount).Mul(sdk.NewDecWithPrec(int64(simulation.RandIntBetween(r, 1, 20)), 1)),
 		},
 	}
 	fmt.Printf("Selected randomly generated staking parameters:\n\t%+v\n", stakingGenesis)
@@ -204,12 +216,17 @@ func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account, genesisTimest
 	}
 	fmt.Printf("Selected randomly generated slashing parameters:\n\t%+v\n", slashingGenesis)
 
+	mintParams := mint.NewParams(
+		sdk.DefaultBondDenom,
+		uint64(60*60*8766/5),
+		sdk.NewDec(362880000000),
+		sdk.NewDec(600000),
+		sdk.NewDecWithPrec(int64(r.Intn(99)), 2),
+		time.Duration(simulation.RandIntBetween(r, 60*60*24, 60*60*24*7*52))*time.Second,
+		time.Duration(simulation.RandIntBetween(r, 60, 60*60))*time.Second)
 	mintGenesis := mint.GenesisState{
-		Minter: mint.InitialMinter(
-			sdk.NewDecWithPrec(int64(r.Intn(99)), 2)),
-		Params: mint.NewParams(
-			sdk.DefaultBondDenom,
-			uint64(60*60*8766/5)),
+		Minter: mint.InitialMinter(mintParams),
+		Params: mintParams,
 	}
 	fmt.Printf("Selected randomly generated minting parameters:\n\t%+v\n", mintGenesis)
 
@@ -280,7 +297,8 @@ func testAndRunTxs(app *GaiaApp) []simulation.WeightedOperation {
 		{50, distrsim.SimulateMsgWithdrawValidatorCommission(app.accountKeeper, app.distrKeeper)},
 		{5, govsim.SimulateSubmittingVotingAndSlashingForProposal(app.govKeeper)},
 		{100, govsim.SimulateMsgDeposit(app.govKeeper)},
-		{10, govsim.SimulateFundingCycle(app.govKeeper)},
+		{10, govsim.SimulateFundingCycle(app.accountKeeper, app.govKeeper)},
+		{20, stakingsim.SimulateMsgDelegateCouncilMember(app.accountKeeper, app.stakingKeeper)},
 		{100, stakingsim.SimulateMsgCreateValidator(app.accountKeeper, app.stakingKeeper)},
 		{5, stakingsim.SimulateMsgEditValidator(app.stakingKeeper)},
 		{100, stakingsim.SimulateMsgDelegate(app.accountKeeper, app.stakingKeeper)},
@@ -296,6 +314,7 @@ func invariants(app *GaiaApp) []sdk.Invariant {
 		simulation.PeriodicInvariant(distr.AllInvariants(app.distrKeeper, app.stakingKeeper), period, 0),
 		simulation.PeriodicInvariant(staking.AllInvariants(app.stakingKeeper, app.feeCollectionKeeper,
 			app.distrKeeper, app.accountKeeper), period, 0),
+		simulation.PeriodicInvariant(gov.AllInvariants(app.govKeeper), period, 0),
 	}
 }
 
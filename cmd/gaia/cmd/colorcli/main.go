@@ -12,8 +12,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	amino "github.com/tendermint/go-amino"
 	"github.com/ColorPlatform/prism/libs/cli"
+	amino "github.com/tendermint/go-amino"
 
 	"github.com/ColorPlatform/color-sdk/client"
 	"github.com/ColorPlatform/color-sdk/client/keys"
@@ -35,6 +35,10 @@ import (
 	slashing "github.com/ColorPlatform/color-sdk/x/slashing/client/rest"
 	st "github.com/ColorPlatform/color-sdk/x/staking"
 	staking "github.com/ColorPlatform/color-sdk/x/staking/client/rest"
+	sp "github.com/ColorPlatform/color-sdk/x/supply"
+	supply "github.com/ColorPlatform/color-sdk/x/supply/client/rest"
+	up "github.com/ColorPlatform/color-sdk/x/upgrade"
+	upgrade "github.com/ColorPlatform/color-sdk/x/upgrade/client/rest"
 
 	authcmd "github.com/ColorPlatform/color-sdk/x/auth/client/cli"
 	bankcmd "github.com/ColorPlatform/color-sdk/x/bank/client/cli"
@@ -45,6 +49,8 @@ import (
 	mintclient "github.com/ColorPlatform/color-sdk/x/mint/client"
 	slashingclient "github.com/ColorPlatform/color-sdk/x/slashing/client"
 	stakingclient "github.com/ColorPlatform/color-sdk/x/staking/client"
+	supplyclient "github.com/ColorPlatform/color-sdk/x/supply/client"
+	upgradeclient "github.com/ColorPlatform/color-sdk/x/upgrade/client"
 
 	_ "github.com/ColorPlatform/color-sdk/client/lcd/statik"
 )
@@ -76,6 +82,8 @@ func main() {
 		mintclient.NewModuleClient(mint.StoreKey, cdc),
 		slashingclient.NewModuleClient(sl.StoreKey, cdc),
 		crisisclient.NewModuleClient(sl.StoreKey, cdc),
+		upgradeclient.NewModuleClient(up.StoreKey, cdc),
+		supplyclient.NewModuleClient(sp.StoreKey, cdc),
 	}
 
 	rootCmd := &cobra.Command{
@@ -177,6 +185,8 @@ func registerRoutes(rs *lcd.RestServer) {
 	slashing.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc, rs.KeyBase)
 	gov.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 	mintrest.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
+	upgrade.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
+	supply.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 }
 
 func registerSwaggerUI(rs *lcd.RestServer) {
@@ -18,7 +18,6 @@ import (
 	"github.com/ColorPlatform/color-sdk/cmd/gaia/app"
 	gaiaInit "github.com/ColorPlatform/color-sdk/cmd/gaia/init"
 	"github.com/ColorPlatform/color-sdk/server"
-	"github.com/ColorPlatform/color-sdk/store"
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
@@ -57,7 +56,7 @@ func main() {
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
 	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
-		0, "Assert registered invariants every N blocks")
+		0, "Assert registered invariants every N blocks on this node, without affecting the consensus")
 	err := executor.Execute()
 	if err != nil {
 		// handle with #870
@@ -66,11 +65,30 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(
-		logger, db, traceStore, true, invCheckPeriod,
-		baseapp.SetPruning(store.NewPruningOptionsFromString(viper.GetString("pruning"))),
+	pruning, err := server.GetPruningOptions()
+	if err != nil {
+		panic(err)
+	}
+	snapshotStore, err := server.OpenSnapshotStore(viper.GetString(cli.HomeFlag))
+	if err != nil {
+		panic(err)
+	}
+	options := []func(*baseapp.BaseApp){
+		baseapp.SetPruning(pruning),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
-	)
+		baseapp.SetSnapshotStore(snapshotStore),
+		baseapp.SetSnapshotInterval(uint64(viper.GetInt64(server.FlagSnapshotInterval))),
+		baseapp.SetSnapshotKeepRecent(uint32(viper.GetInt(server.FlagSnapshotKeepRecent))),
+		baseapp.SetUpgradeDryRun(viper.GetBool(server.FlagUpgradeDryRun)),
+	}
+	if dir := viper.GetString(server.FlagStreamingFileDir); dir != "" {
+		sink, err := server.OpenStreamingFileSink(viper.GetString(cli.HomeFlag), dir)
+		if err != nil {
+			panic(err)
+		}
+		options = append(options, baseapp.SetStateListeners(sink))
+	}
+	return app.NewGaiaApp(logger, db, traceStore, true, invCheckPeriod, options...)
 }
 
 func exportAppStateAndTMValidators(
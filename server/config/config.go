@@ -9,6 +9,17 @@ import (
 
 const (
 	defaultMinGasPrices = ""
+
+	// DefaultPruning is the default pruning strategy, and DefaultPruningKeepRecent
+	// and DefaultPruningKeepEvery the default numbers of the custom strategy,
+	// which are the ones of the syncable strategy
+	DefaultPruning           = "syncable"
+	DefaultPruningKeepRecent = 100
+	DefaultPruningKeepEvery  = 10000
+
+	// DefaultSnapshotKeepRecent is the default number of recent state
+	// snapshots kept by a node
+	DefaultSnapshotKeepRecent = 2
 )
 
 // BaseConfig defines the server's basic configuration
@@ -17,6 +28,23 @@ type BaseConfig struct {
 	// transaction. A transaction's fees must meet the minimum of any denomination
 	// specified in this config (e.g. 0.25token1;0.0001token2).
 	MinGasPrices string `mapstructure:"minimum-gas-prices"`
+
+	// The pruning strategy of the state: syncable, nothing, everything or
+	// custom. The custom strategy keeps the PruningKeepRecent most recent
+	// states, and every PruningKeepEvery state.
+	Pruning           string `mapstructure:"pruning"`
+	PruningKeepRecent int64  `mapstructure:"pruning-keep-recent"`
+	PruningKeepEvery  int64  `mapstructure:"pruning-keep-every"`
+
+	// The number of blocks between the state snapshots taken by the node, zero
+	// to take none, and the number of recent snapshots to keep, zero to keep
+	// all of them.
+	SnapshotInterval   uint64 `mapstructure:"snapshot-interval"`
+	SnapshotKeepRecent uint32 `mapstructure:"snapshot-keep-recent"`
+
+	// The directory the writes to the state of each block are streamed to,
+	// empty to stream none of them.
+	StreamingFileDir string `mapstructure:"streaming-file-dir"`
 }
 
 // Config defines the server's top level configuration
@@ -55,7 +83,11 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig{
-			MinGasPrices: defaultMinGasPrices,
+			MinGasPrices:       defaultMinGasPrices,
+			Pruning:            DefaultPruning,
+			PruningKeepRecent:  DefaultPruningKeepRecent,
+			PruningKeepEvery:   DefaultPruningKeepEvery,
+			SnapshotKeepRecent: DefaultSnapshotKeepRecent,
 		},
 	}
 }
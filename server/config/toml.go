@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"text/template"
 
-	"github.com/spf13/viper"
 	cmn "github.com/ColorPlatform/prism/libs/common"
+	"github.com/spf13/viper"
 )
 
 const defaultConfigTemplate = `# This is a TOML config file.
@@ -17,6 +17,33 @@ const defaultConfigTemplate = `# This is a TOML config file.
 # transaction. A transaction's fees must meet the minimum of any denomination
 # specified in this config (e.g. 0.25token1;0.0001token2).
 minimum-gas-prices = "{{ .BaseConfig.MinGasPrices }}"
+
+##### pruning options #####
+
+# The pruning strategy of the state: syncable (keep the last 100 states and
+# every 10000th), nothing (keep all the states), everything (keep only the
+# latest state) or custom (keep the last pruning-keep-recent states and every
+# pruning-keep-every state, 0 to keep none of them).
+pruning = "{{ .BaseConfig.Pruning }}"
+pruning-keep-recent = {{ .BaseConfig.PruningKeepRecent }}
+pruning-keep-every = {{ .BaseConfig.PruningKeepEvery }}
+
+##### state snapshot options #####
+
+# The number of blocks between the state snapshots taken by the node, which
+# are saved in data/snapshots. 0 disables the snapshots. Taking them at heights
+# kept by the pruning strategy gives them time to complete.
+snapshot-interval = {{ .BaseConfig.SnapshotInterval }}
+
+# The number of recent snapshots to keep. 0 keeps all of them.
+snapshot-keep-recent = {{ .BaseConfig.SnapshotKeepRecent }}
+
+##### state streaming options #####
+
+# The directory the writes to the state made by each tx and block are streamed
+# to, in a file per block. A relative path is resolved against the home
+# directory. Empty disables the streaming.
+streaming-file-dir = "{{ .BaseConfig.StreamingFileDir }}"
 `
 
 var configTemplate *template.Template
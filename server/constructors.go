@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ColorPlatform/color-sdk/store/snapshots"
+	"github.com/ColorPlatform/color-sdk/store/streaming"
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	abci "github.com/ColorPlatform/prism/abci/types"
 	dbm "github.com/ColorPlatform/prism/libs/db"
@@ -29,6 +31,21 @@ func openDB(rootDir string) (dbm.DB, error) {
 	return db, err
 }
 
+// OpenSnapshotStore opens the store of the state snapshots of the node with
+// the given root directory.
+func OpenSnapshotStore(rootDir string) (*snapshots.Store, error) {
+	return snapshots.NewStore(filepath.Join(rootDir, "data", "snapshots"))
+}
+
+// OpenStreamingFileSink opens the sink the writes to the state are streamed
+// to, in the given directory relative to the root directory of the node.
+func OpenStreamingFileSink(rootDir, dir string) (*streaming.FileSink, error) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(rootDir, dir)
+	}
+	return streaming.NewFileSink(dir)
+}
+
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
 	if traceWriterFile != "" {
 		w, err = os.OpenFile(
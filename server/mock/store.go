@@ -38,6 +38,14 @@ func (ms multiStore) SetTracer(w io.Writer) sdk.MultiStore {
 	panic("not implemented")
 }
 
+func (ms multiStore) AddListener(listener sdk.StateListener) {
+	panic("not implemented")
+}
+
+func (ms multiStore) ListeningEnabled() bool {
+	panic("not implemented")
+}
+
 func (ms multiStore) Commit() sdk.CommitID {
 	panic("not implemented")
 }
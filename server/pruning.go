@@ -0,0 +1,90 @@
+package server
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ColorPlatform/prism/libs/cli"
+
+	"github.com/ColorPlatform/color-sdk/server/config"
+	"github.com/ColorPlatform/color-sdk/store"
+)
+
+// pruning flags
+const (
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+)
+
+// pruneApp is an application which prunes its state offline
+type pruneApp interface {
+	PruneStores() (map[string]int, error)
+}
+
+// GetPruningOptions returns the pruning options set by the pruning flags or
+// config entries.
+func GetPruningOptions() (store.PruningOptions, error) {
+	return store.NewPruningOptionsFromConfig(
+		viper.GetString(flagPruning),
+		viper.GetInt64(flagPruningKeepRecent),
+		viper.GetInt64(flagPruningKeepEvery),
+	)
+}
+
+// addPruningFlags adds the flags setting the pruning strategy to a command.
+func addPruningFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagPruning, config.DefaultPruning, "Pruning strategy: syncable, nothing, everything, custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, config.DefaultPruningKeepRecent,
+		"Number of recent states to keep with the custom pruning strategy")
+	cmd.Flags().Int64(flagPruningKeepEvery, config.DefaultPruningKeepEvery,
+		"Interval of the states to keep with the custom pruning strategy, 0 to keep none")
+}
+
+// PruneCmd prunes the state of a stopped node down to its pruning strategy.
+func PruneCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune the state of the stopped node down to the pruning strategy",
+		Long: `Delete the old states of the stopped node that the pruning strategy, given by
+the flags or the config file, doesn't keep. This applies a new pruning strategy
+to the states kept by the previous one, without running the node.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := GetPruningOptions(); err != nil {
+				return err
+			}
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			db, err := openDB(config.RootDir)
+			if err != nil {
+				return err
+			}
+			app, ok := appCreator(ctx.Logger, db, nil).(pruneApp)
+			if !ok {
+				return fmt.Errorf("application doesn't support pruning")
+			}
+
+			pruned, err := app.PruneStores()
+			if err != nil {
+				return err
+			}
+			names := make([]string, 0, len(pruned))
+			for name := range pruned {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf("store %s: pruned %d versions\n", name, pruned[name])
+			}
+			return nil
+		},
+	}
+	addPruningFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/store"
+	storetypes "github.com/ColorPlatform/color-sdk/store/types"
+)
+
+func TestGetPruningOptions(t *testing.T) {
+	defer viper.Reset()
+
+	tests := []struct {
+		strategy   string
+		keepRecent int64
+		keepEvery  int64
+		expected   store.PruningOptions
+		expectPass bool
+	}{
+		{"syncable", 0, 0, store.PruneSyncable, true},
+		{"nothing", 0, 0, store.PruneNothing, true},
+		{"everything", 7, 3, store.PruneEverything, true},
+		{"custom", 7, 3, storetypes.NewPruningOptions(7, 3), true},
+		{"custom", 0, 0, store.PruneEverything, true},
+		{"custom", -1, 3, store.PruningOptions{}, false},
+		{"custom", 7, -1, store.PruningOptions{}, false},
+		{"other", 7, 3, store.PruningOptions{}, false},
+	}
+	for i, tc := range tests {
+		viper.Set(flagPruning, tc.strategy)
+		viper.Set(flagPruningKeepRecent, tc.keepRecent)
+		viper.Set(flagPruningKeepEvery, tc.keepEvery)
+
+		opt, err := GetPruningOptions()
+		if tc.expectPass {
+			require.NoError(t, err, "test %d", i)
+			require.Equal(t, tc.expected, opt, "test %d", i)
+		} else {
+			require.Error(t, err, "test %d", i)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package server
+
+// DONTCOVER
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	"github.com/ColorPlatform/prism/libs/cli"
+
+	"github.com/ColorPlatform/color-sdk/store/snapshots"
+)
+
+const flagTrustedAppHash = "trusted-app-hash"
+
+// snapshotApp is an application which takes and restores state snapshots
+type snapshotApp interface {
+	CreateSnapshot(height int64) (*snapshots.Snapshot, error)
+	RestoreSnapshot(height int64) error
+}
+
+// SnapshotCmd returns the commands managing the state snapshots of the node.
+func SnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage state snapshots",
+	}
+	cmd.AddCommand(
+		createSnapshotCmd(ctx, appCreator),
+		listSnapshotsCmd(ctx),
+		restoreSnapshotCmd(ctx, appCreator),
+	)
+	return cmd
+}
+
+func createSnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [height]",
+		Short: "Take a snapshot of the state at a height, the latest one by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := openSnapshotApp(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+
+			height := app.(abci.Application).Info(abci.RequestInfo{}).LastBlockHeight
+			if len(args) == 1 {
+				height, err = strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid height %s: %v", args[0], err)
+				}
+			}
+			if height <= 0 {
+				return fmt.Errorf("no state to snapshot")
+			}
+
+			snapshot, err := app.CreateSnapshot(height)
+			if err != nil {
+				return err
+			}
+			fmt.Println(snapshot.String())
+			return nil
+		},
+	}
+}
+
+func listSnapshotsCmd(ctx *Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the state snapshots of the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.Config.SetRoot(viper.GetString(cli.HomeFlag))
+			store, err := OpenSnapshotStore(ctx.Config.RootDir)
+			if err != nil {
+				return err
+			}
+			list, err := store.List()
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range list {
+				fmt.Printf("height: %d format: %d chunks: %d hash: %X\n",
+					snapshot.Height, snapshot.Format, len(snapshot.ChunkHashes), snapshot.Hash)
+			}
+			return nil
+		},
+	}
+}
+
+func restoreSnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <height>",
+		Short: "Restore the empty state of the node from a snapshot",
+		Long: `Restore the empty state of the node from the snapshot at the given height,
+which was copied into data/snapshots. The restored state is checked against
+the app hash of the snapshot, which should be checked in turn against a
+trusted app hash with --trusted-app-hash.
+
+Only the application state is restored. The block store and the state store
+of tendermint (data/blockstore.db and data/state.db) are left empty, and
+tendermint refuses to start with an application state ahead of its blocks.
+Before starting the node, they must be bootstrapped at the same height, e.g.
+by copying them from a trusted node stopped at the snapshot height. The node
+then syncs the blocks after the height to catch up.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %s: %v", args[0], err)
+			}
+
+			ctx.Config.SetRoot(viper.GetString(cli.HomeFlag))
+			store, err := OpenSnapshotStore(ctx.Config.RootDir)
+			if err != nil {
+				return err
+			}
+			snapshot, err := store.Get(height)
+			if err != nil {
+				return err
+			}
+			if trusted := viper.GetString(flagTrustedAppHash); trusted != "" {
+				appHash, err := hex.DecodeString(trusted)
+				if err != nil {
+					return fmt.Errorf("invalid trusted app hash: %v", err)
+				}
+				if !bytes.Equal(appHash, snapshot.AppHash) {
+					return fmt.Errorf("snapshot has app hash %X instead of trusted app hash %X",
+						snapshot.AppHash, appHash)
+				}
+			}
+
+			app, err := openSnapshotApp(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			if err := app.RestoreSnapshot(height); err != nil {
+				return err
+			}
+			fmt.Printf("restored state at height %d with app hash %X\n", height, snapshot.AppHash)
+			fmt.Println("the tendermint block and state stores must be bootstrapped at this height before starting the node")
+			return nil
+		},
+	}
+	cmd.Flags().String(flagTrustedAppHash, "", "Hex encoded app hash the snapshot must match")
+	return cmd
+}
+
+func openSnapshotApp(ctx *Context, appCreator AppCreator) (snapshotApp, error) {
+	config := ctx.Config
+	config.SetRoot(viper.GetString(cli.HomeFlag))
+
+	db, err := openDB(config.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	app, ok := appCreator(ctx.Logger, db, nil).(snapshotApp)
+	if !ok {
+		return nil, fmt.Errorf("application doesn't support state snapshots")
+	}
+	return app, nil
+}
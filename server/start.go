@@ -15,6 +15,8 @@ import (
 	"github.com/ColorPlatform/prism/p2p"
 	pvm "github.com/ColorPlatform/prism/privval"
 	"github.com/ColorPlatform/prism/proxy"
+
+	"github.com/ColorPlatform/color-sdk/server/config"
 )
 
 // Tendermint full-node start flags
@@ -24,6 +26,10 @@ const (
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
 	FlagMinGasPrices   = "minimum-gas-prices"
+
+	FlagSnapshotInterval   = "snapshot-interval"
+	FlagSnapshotKeepRecent = "snapshot-keep-recent"
+	FlagStreamingFileDir   = "streaming-file-dir"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -33,6 +39,10 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 		Use:   "start",
 		Short: "Run the full node",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := GetPruningOptions(); err != nil {
+				return err
+			}
+
 			if !viper.GetBool(flagWithTendermint) {
 				ctx.Logger.Info("Starting ABCI without Tendermint")
 				return startStandAlone(ctx, appCreator)
@@ -49,11 +59,14 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Bool(flagWithTendermint, true, "Run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	addPruningFlags(cmd)
 	cmd.Flags().String(
 		FlagMinGasPrices, "",
 		"Minimum gas prices to accept for transactions; Any fee in a tx must meet this minimum (e.g. 0.01photino;0.0001stake)",
 	)
+	cmd.Flags().Uint64(FlagSnapshotInterval, 0, "Take a state snapshot every N blocks, 0 to disable")
+	cmd.Flags().Uint32(FlagSnapshotKeepRecent, config.DefaultSnapshotKeepRecent, "Number of recent state snapshots to keep, 0 to keep all")
+	cmd.Flags().String(FlagStreamingFileDir, "", "Stream the writes to the state of each block to files in this directory, empty to disable")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
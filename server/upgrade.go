@@ -0,0 +1,58 @@
+package server
+
+// DONTCOVER
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ColorPlatform/prism/libs/cli"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// FlagUpgradeDryRun makes the app created by the app creator discard the
+// upgrade it applies on load
+const FlagUpgradeDryRun = "upgrade-dry-run"
+
+// upgradeApp is an application which applies upgrades of the state on load
+type upgradeApp interface {
+	UpgradeReport() *sdk.UpgradeReport
+}
+
+// UpgradeDryRunCmd returns the command reporting the changes of the upgrade
+// due at the next height, without applying it.
+func UpgradeDryRunCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "upgrade-dry-run",
+		Short: "Report the keys the upgrade due at the next height would migrate, without applying it",
+		Long: `Load the state where the node halted for an upgrade, apply the store upgrades
+and migrations of the upgrade registered by this binary, report the number of
+keys they change, and discard the changes.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			viper.Set(FlagUpgradeDryRun, true)
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			db, err := openDB(config.RootDir)
+			if err != nil {
+				return err
+			}
+			app, ok := appCreator(ctx.Logger, db, nil).(upgradeApp)
+			if !ok {
+				return fmt.Errorf("application doesn't support upgrades")
+			}
+
+			report := app.UpgradeReport()
+			if report == nil {
+				fmt.Println("no upgrade is due at the next height")
+				return nil
+			}
+			fmt.Println(report.String())
+			return nil
+		},
+	}
+}
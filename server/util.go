@@ -151,6 +151,9 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		SnapshotCmd(ctx, appCreator),
+		UpgradeDryRunCmd(ctx, appCreator),
+		PruneCmd(ctx, appCreator),
 		client.LineBreak,
 		version.VersionCmd,
 	)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ColorPlatform/color-sdk/store/cachekv"
 	"github.com/ColorPlatform/color-sdk/store/dbadapter"
+	"github.com/ColorPlatform/color-sdk/store/listenkv"
 	"github.com/ColorPlatform/color-sdk/store/types"
 )
 
@@ -27,6 +28,7 @@ type Store struct {
 }
 
 var _ types.CacheMultiStore = Store{}
+var _ types.ListeningMultiStore = Store{}
 
 func NewFromKVStore(
 	store types.KVStore,
@@ -68,6 +70,15 @@ func newCacheMultiStoreFromCMS(cms Store) Store {
 	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
 }
 
+// CacheMultiStoreWithListener implements ListeningMultiStore.
+func (cms Store) CacheMultiStoreWithListener(listener types.WriteListener) types.CacheMultiStore {
+	stores := make(map[types.StoreKey]types.CacheWrapper)
+	for k, v := range cms.stores {
+		stores[k] = listenkv.Wrap(k, v, listener)
+	}
+	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
+}
+
 // SetTracer sets the tracer for the MultiStore that the underlying
 // stores will utilize to trace operations. A MultiStore is returned.
 func (cms Store) SetTracer(w io.Writer) types.MultiStore {
@@ -0,0 +1,190 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/ColorPlatform/prism/crypto/tmhash"
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/RNSSolution/iavl"
+)
+
+// Keys of the nodes and roots of an IAVL tree in its database, as laid out by
+// the iavl package.
+var (
+	nodeKeyFormat = iavl.NewKeyFormat('n', tmhash.Size) // n<hash>
+	rootKeyFormat = iavl.NewKeyFormat('r', 8)           // r<version>
+)
+
+// Export walks the nodes of the tree stored in db at the given version in
+// pre-order, and calls fn with the encoding of each node. It returns the root
+// hash of the tree, which is empty for an empty tree.
+func Export(db dbm.DB, version int64, fn func(node []byte) error) ([]byte, error) {
+	rootHash := db.Get(rootKeyFormat.Key(version))
+	if rootHash == nil {
+		return nil, fmt.Errorf("version %d does not exist", version)
+	}
+	if len(rootHash) == 0 {
+		return rootHash, nil
+	}
+
+	stack := [][]byte{rootHash}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		bz := db.Get(nodeKeyFormat.Key(hash))
+		if bz == nil {
+			return nil, fmt.Errorf("node %X of version %d is missing", hash, version)
+		}
+		node, err := decodeNode(bz)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(bz); err != nil {
+			return nil, err
+		}
+		if !node.isLeaf() {
+			stack = append(stack, node.rightHash, node.leftHash)
+		}
+	}
+	return rootHash, nil
+}
+
+// Importer writes a tree exported by Export to an empty database, checking
+// the hash of every node against the root hash of the tree.
+type Importer struct {
+	batch    dbm.Batch
+	version  int64
+	rootHash []byte
+	hashes   [][]byte // hashes of the nodes still expected, in reverse order
+}
+
+// NewImporter returns an Importer of the tree of the given version and root
+// hash into db.
+func NewImporter(db dbm.DB, version int64, rootHash []byte) *Importer {
+	if rootHash == nil {
+		rootHash = []byte{}
+	}
+	imp := &Importer{
+		batch:    db.NewBatch(),
+		version:  version,
+		rootHash: rootHash,
+	}
+	if len(rootHash) != 0 {
+		imp.hashes = [][]byte{rootHash}
+	}
+	return imp
+}
+
+// Add imports the next node of the tree.
+func (imp *Importer) Add(bz []byte) error {
+	if len(imp.hashes) == 0 {
+		return fmt.Errorf("unexpected node after the last node of the tree")
+	}
+	hash := imp.hashes[len(imp.hashes)-1]
+	imp.hashes = imp.hashes[:len(imp.hashes)-1]
+
+	node, err := decodeNode(bz)
+	if err != nil {
+		return err
+	}
+	if node.version > imp.version {
+		return fmt.Errorf("node %X of version %d is newer than the tree", hash, node.version)
+	}
+	if !bytes.Equal(node.hash(), hash) {
+		return fmt.Errorf("node hash mismatch: expected %X, got %X", hash, node.hash())
+	}
+	imp.batch.Set(nodeKeyFormat.Key(hash), bz)
+	if !node.isLeaf() {
+		imp.hashes = append(imp.hashes, node.rightHash, node.leftHash)
+	}
+	return nil
+}
+
+// Commit writes the imported tree to the database once all its nodes have
+// been added.
+func (imp *Importer) Commit() error {
+	if len(imp.hashes) != 0 {
+		return fmt.Errorf("%d nodes of the tree are missing", len(imp.hashes))
+	}
+	imp.batch.Set(rootKeyFormat.Key(imp.version), imp.rootHash)
+	imp.batch.Write()
+	return nil
+}
+
+//----------------------------------------
+
+// node holds the fields of an encoded IAVL node needed to hash it and walk
+// the tree.
+type node struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+func (n node) isLeaf() bool {
+	return n.height == 0
+}
+
+// decodeNode decodes a node encoded by the iavl package.
+func decodeNode(bz []byte) (n node, err error) {
+	var read int
+	if n.height, read, err = amino.DecodeInt8(bz); err != nil {
+		return n, fmt.Errorf("decoding node height: %v", err)
+	}
+	bz = bz[read:]
+	if n.size, read, err = amino.DecodeVarint(bz); err != nil {
+		return n, fmt.Errorf("decoding node size: %v", err)
+	}
+	bz = bz[read:]
+	if n.version, read, err = amino.DecodeVarint(bz); err != nil {
+		return n, fmt.Errorf("decoding node version: %v", err)
+	}
+	bz = bz[read:]
+	if n.key, read, err = amino.DecodeByteSlice(bz); err != nil {
+		return n, fmt.Errorf("decoding node key: %v", err)
+	}
+	bz = bz[read:]
+
+	if n.isLeaf() {
+		if n.value, _, err = amino.DecodeByteSlice(bz); err != nil {
+			return n, fmt.Errorf("decoding node value: %v", err)
+		}
+		return n, nil
+	}
+	if n.leftHash, read, err = amino.DecodeByteSlice(bz); err != nil {
+		return n, fmt.Errorf("decoding node left hash: %v", err)
+	}
+	bz = bz[read:]
+	if n.rightHash, _, err = amino.DecodeByteSlice(bz); err != nil {
+		return n, fmt.Errorf("decoding node right hash: %v", err)
+	}
+	if len(n.leftHash) == 0 || len(n.rightHash) == 0 {
+		return n, fmt.Errorf("inner node without child hash")
+	}
+	return n, nil
+}
+
+// hash computes the hash of the node the same way as the iavl package.
+func (n node) hash() []byte {
+	buf := new(bytes.Buffer)
+	// writing to a buffer never fails
+	_ = amino.EncodeInt8(buf, n.height)
+	_ = amino.EncodeVarint(buf, n.size)
+	_ = amino.EncodeVarint(buf, n.version)
+	if n.isLeaf() {
+		_ = amino.EncodeByteSlice(buf, n.key)
+		_ = amino.EncodeByteSlice(buf, tmhash.Sum(n.value))
+	} else {
+		_ = amino.EncodeByteSlice(buf, n.leftHash)
+		_ = amino.EncodeByteSlice(buf, n.rightHash)
+	}
+	return tmhash.Sum(buf.Bytes())
+}
@@ -0,0 +1,131 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/RNSSolution/iavl"
+)
+
+// newVersionedTree saves several versions of a tree that sets, updates and
+// removes keys, and deletes some of the old versions. It returns the contents
+// of each version kept.
+func newVersionedTree(t *testing.T, db dbm.DB) (*iavl.MutableTree, map[int64]map[string]string) {
+	tree := iavl.NewMutableTree(db, cacheSize)
+	contents := make(map[int64]map[string]string)
+	state := make(map[string]string)
+	for version := int64(1); version <= 10; version++ {
+		for i := 0; i < 20; i++ {
+			key := fmt.Sprintf("key%03d", (int(version)*7+i*13)%50)
+			value := fmt.Sprintf("value %d of version %d", i, version)
+			tree.Set([]byte(key), []byte(value))
+			state[key] = value
+		}
+		for i := 0; i < 5; i++ {
+			key := fmt.Sprintf("key%03d", (int(version)*11+i*17)%50)
+			tree.Remove([]byte(key))
+			delete(state, key)
+		}
+		_, ver, err := tree.SaveVersion()
+		require.NoError(t, err)
+		require.Equal(t, version, ver)
+
+		kept := make(map[string]string, len(state))
+		for k, v := range state {
+			kept[k] = v
+		}
+		contents[version] = kept
+	}
+	for _, version := range []int64{2, 4, 5, 8} {
+		require.NoError(t, tree.DeleteVersion(version))
+		delete(contents, version)
+	}
+	return tree, contents
+}
+
+func exportNodes(t *testing.T, db dbm.DB, version int64) ([]byte, [][]byte) {
+	var nodes [][]byte
+	rootHash, err := Export(db, version, func(node []byte) error {
+		nodes = append(nodes, node)
+		return nil
+	})
+	require.NoError(t, err)
+	return rootHash, nodes
+}
+
+func TestExportImport(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, contents := newVersionedTree(t, db)
+
+	for version, kept := range contents {
+		rootHash, nodes := exportNodes(t, db, version)
+		expected, err := tree.GetImmutable(version)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), rootHash)
+
+		restoredDB := dbm.NewMemDB()
+		imp := NewImporter(restoredDB, version, rootHash)
+		for _, node := range nodes {
+			require.NoError(t, imp.Add(node))
+		}
+		require.NoError(t, imp.Commit())
+
+		// the restored tree is loaded by the iavl package as it was saved
+		restored := iavl.NewMutableTree(restoredDB, cacheSize)
+		ver, err := restored.LoadVersion(version)
+		require.NoError(t, err)
+		require.Equal(t, version, ver)
+		require.Equal(t, rootHash, restored.Hash())
+		require.Equal(t, int64(len(kept)), restored.Size())
+		for k, v := range kept {
+			_, value := restored.Get([]byte(k))
+			require.Equal(t, v, string(value), "key %s of version %d", k, version)
+		}
+
+		// and new versions are saved on top of it
+		restored.Set([]byte("new"), []byte("value"))
+		_, ver, err = restored.SaveVersion()
+		require.NoError(t, err)
+		require.Equal(t, version+1, ver)
+	}
+}
+
+func TestExportDeletedVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	newVersionedTree(t, db)
+	_, err := Export(db, 4, func([]byte) error { return nil })
+	require.Error(t, err)
+}
+
+func TestImportInvalidNodes(t *testing.T) {
+	db := dbm.NewMemDB()
+	newVersionedTree(t, db)
+	rootHash, nodes := exportNodes(t, db, 7)
+	require.True(t, len(nodes) > 2)
+
+	// a tampered node doesn't match its hash
+	imp := NewImporter(dbm.NewMemDB(), 7, rootHash)
+	require.NoError(t, imp.Add(nodes[0]))
+	tampered := append([]byte{}, nodes[1]...)
+	tampered[len(tampered)-1]++
+	require.Error(t, imp.Add(tampered))
+
+	// a tree can't be imported at an older version than its nodes
+	imp = NewImporter(dbm.NewMemDB(), 3, rootHash)
+	require.Error(t, imp.Add(nodes[0]))
+
+	// a tree can't be committed before all its nodes are added
+	imp = NewImporter(dbm.NewMemDB(), 7, rootHash)
+	for _, node := range nodes[:len(nodes)-1] {
+		require.NoError(t, imp.Add(node))
+	}
+	require.Error(t, imp.Commit())
+
+	// nor with more nodes than the tree has
+	require.NoError(t, imp.Add(nodes[len(nodes)-1]))
+	require.Error(t, imp.Add(nodes[0]))
+	require.NoError(t, imp.Commit())
+}
@@ -55,6 +55,11 @@ type Store struct {
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
 	storeEvery int64
+
+	// Reports the versions that must not be deleted yet, e.g. while they are
+	// snapshotted. Such versions are held and released on a later commit.
+	pinned func(version int64) bool
+	held   []int64
 }
 
 // CONTRACT: tree should be fully loaded.
@@ -77,15 +82,19 @@ func (st *Store) Commit() types.CommitID {
 		panic(err)
 	}
 
+	// Release the versions held while they were pinned.
+	held := st.held
+	st.held = nil
+	for _, toRelease := range held {
+		st.releaseVersion(toRelease)
+	}
+
 	// Release an old version of history, if not a sync waypoint.
 	previous := version - 1
 	if st.numRecent < previous {
 		toRelease := previous - st.numRecent
 		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
+			st.releaseVersion(toRelease)
 		}
 	}
 
@@ -95,6 +104,24 @@ func (st *Store) Commit() types.CommitID {
 	}
 }
 
+// releaseVersion deletes an old version, or holds it while it is pinned.
+func (st *Store) releaseVersion(version int64) {
+	if st.pinned != nil && st.pinned(version) {
+		st.held = append(st.held, version)
+		return
+	}
+	err := st.tree.DeleteVersion(version)
+	if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+		panic(err)
+	}
+}
+
+// SetPinned sets the function reporting the versions Commit must not delete
+// yet. The function must be safe to call concurrently with pinning.
+func (st *Store) SetPinned(pinned func(version int64) bool) {
+	st.pinned = pinned
+}
+
 // Implements Committer.
 func (st *Store) LastCommitID() types.CommitID {
 	return types.CommitID{
@@ -109,6 +136,24 @@ func (st *Store) SetPruning(opt types.PruningOptions) {
 	st.storeEvery = opt.KeepEvery()
 }
 
+// Prune deletes the stored versions the given pruning options don't keep, as
+// Commit does for each previous version, and returns the number of versions
+// deleted.
+func (st *Store) Prune(opt types.PruningOptions) (int, error) {
+	latest := st.tree.Version()
+	pruned := 0
+	for version := int64(1); version < latest; version++ {
+		if !st.tree.VersionExists(version) || !opt.ShouldPrune(version, latest) {
+			continue
+		}
+		if err := st.tree.DeleteVersion(version); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
 // VersionExists returns whether or not a given version is stored.
 func (st *Store) VersionExists(version int64) bool {
 	return st.tree.VersionExists(version)
@@ -6,10 +6,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/RNSSolution/iavl"
 	abci "github.com/ColorPlatform/prism/abci/types"
 	cmn "github.com/ColorPlatform/prism/libs/common"
 	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/RNSSolution/iavl"
 
 	"github.com/ColorPlatform/color-sdk/store/errors"
 	"github.com/ColorPlatform/color-sdk/store/types"
@@ -414,6 +414,32 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLPruneOffline(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := UnsafeNewStore(tree, int64(0), int64(1))
+	for i := 0; i < 15; i++ {
+		nextVersion(iavlStore)
+	}
+
+	// prunes the versions the strategy deletes on commit
+	pruned, err := iavlStore.Prune(types.NewPruningOptions(5, 3))
+	require.NoError(t, err)
+	require.Equal(t, 6, pruned)
+	for _, ver := range []int64{3, 6, 9, 10, 11, 12, 13, 14, 15} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+	for _, ver := range []int64{1, 2, 4, 5, 7, 8} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+
+	pruned, err = iavlStore.Prune(types.PruneEverything)
+	require.NoError(t, err)
+	require.Equal(t, 8, pruned)
+	require.True(t, iavlStore.VersionExists(15))
+	require.False(t, iavlStore.VersionExists(14))
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
@@ -526,3 +552,27 @@ func BenchmarkIAVLIteratorNext(b *testing.B) {
 		}
 	}
 }
+
+func TestIAVLPinnedVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := UnsafeNewStore(tree, int64(0), int64(0))
+	pinned := map[int64]bool{}
+	iavlStore.SetPinned(func(version int64) bool { return pinned[version] })
+
+	nextVersion(iavlStore)
+	pinned[1] = true
+	nextVersion(iavlStore)
+	for i := 0; i < 3; i++ {
+		nextVersion(iavlStore)
+		require.True(t, iavlStore.VersionExists(1), "Pruned pinned version 1")
+		require.False(t, iavlStore.VersionExists(iavlStore.LastCommitID().Version-1))
+	}
+
+	// the version is deleted on the next commit once unpinned
+	delete(pinned, 1)
+	require.True(t, iavlStore.VersionExists(1))
+	nextVersion(iavlStore)
+	require.False(t, iavlStore.VersionExists(1), "Unpruned unpinned version 1")
+	require.True(t, iavlStore.VersionExists(iavlStore.LastCommitID().Version))
+}
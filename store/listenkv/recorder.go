@@ -0,0 +1,49 @@
+package listenkv
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var _ types.WriteListener = (*Recorder)(nil)
+
+// Recorder is a WriteListener which records the writes it is notified of.
+type Recorder struct {
+	mtx    sync.Mutex
+	writes []types.StoreKVPair
+}
+
+// NewRecorder returns a new recorder of writes.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// OnWrite implements WriteListener.
+func (r *Recorder) OnWrite(storeKey types.StoreKey, key []byte, value []byte, delete bool) {
+	write := types.StoreKVPair{
+		StoreKey: storeKey.Name(),
+		Delete:   delete,
+		Key:      append([]byte{}, key...),
+	}
+	if !delete {
+		write.Value = append([]byte{}, value...)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.writes = append(r.writes, write)
+}
+
+// PopWrites returns the writes recorded since the last call, grouped by store
+// in order of name and in the order they were made within a store, and clears
+// them.
+func (r *Recorder) PopWrites() []types.StoreKVPair {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	writes := r.writes
+	r.writes = nil
+	sort.SliceStable(writes, func(i, j int) bool { return writes[i].StoreKey < writes[j].StoreKey })
+	return writes
+}
@@ -0,0 +1,92 @@
+package listenkv
+
+import (
+	"io"
+
+	"github.com/ColorPlatform/color-sdk/store/cachekv"
+	"github.com/ColorPlatform/color-sdk/store/tracekv"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var _ types.KVStore = (*Store)(nil)
+
+// Store implements the KVStore interface, notifying a listener of the writes
+// made to the parent KVStore.
+type Store struct {
+	parent   types.KVStore
+	storeKey types.StoreKey
+	listener types.WriteListener
+}
+
+// NewStore returns a reference to a new listening store given a parent
+// KVStore, its key and the listener of its writes.
+func NewStore(parent types.KVStore, storeKey types.StoreKey, listener types.WriteListener) *Store {
+	return &Store{parent: parent, storeKey: storeKey, listener: listener}
+}
+
+// Wrap returns the store, listening to its writes if there is a listener and
+// it is a KVStore which isn't transient.
+func Wrap(storeKey types.StoreKey, store types.CacheWrapper, listener types.WriteListener) types.CacheWrapper {
+	if listener == nil {
+		return store
+	}
+	if _, ok := storeKey.(*types.TransientStoreKey); ok {
+		return store
+	}
+	kvStore, ok := store.(types.KVStore)
+	if !ok {
+		return store
+	}
+	return NewStore(kvStore, storeKey, listener)
+}
+
+// Get implements the KVStore interface.
+func (s *Store) Get(key []byte) []byte {
+	return s.parent.Get(key)
+}
+
+// Set implements the KVStore interface. It sets the key in the parent KVStore
+// and notifies the listener.
+func (s *Store) Set(key []byte, value []byte) {
+	s.parent.Set(key, value)
+	s.listener.OnWrite(s.storeKey, key, value, false)
+}
+
+// Delete implements the KVStore interface. It deletes the key from the parent
+// KVStore and notifies the listener.
+func (s *Store) Delete(key []byte) {
+	s.parent.Delete(key)
+	s.listener.OnWrite(s.storeKey, key, nil, true)
+}
+
+// Has implements the KVStore interface.
+func (s *Store) Has(key []byte) bool {
+	return s.parent.Has(key)
+}
+
+// Iterator implements the KVStore interface.
+func (s *Store) Iterator(start, end []byte) types.Iterator {
+	return s.parent.Iterator(start, end)
+}
+
+// ReverseIterator implements the KVStore interface.
+func (s *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return s.parent.ReverseIterator(start, end)
+}
+
+// GetStoreType implements the KVStore interface. It returns the underlying
+// KVStore type.
+func (s *Store) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// CacheWrap implements the KVStore interface. The writes of the cache are
+// notified when it is written.
+func (s *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (s *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
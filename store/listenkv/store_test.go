@@ -0,0 +1,66 @@
+package listenkv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/ColorPlatform/prism/libs/db"
+
+	"github.com/ColorPlatform/color-sdk/store/dbadapter"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var (
+	key1 = types.NewKVStoreKey("store1")
+	key2 = types.NewKVStoreKey("store2")
+)
+
+func TestListenKVStore(t *testing.T) {
+	recorder := NewRecorder()
+	store := NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, key1, recorder)
+
+	store.Set([]byte("a"), []byte("1"))
+	store.Delete([]byte("b"))
+	require.Equal(t, []byte("1"), store.Get([]byte("a")))
+	require.Equal(t, []types.StoreKVPair{
+		{StoreKey: "store1", Key: []byte("a"), Value: []byte("1")},
+		{StoreKey: "store1", Delete: true, Key: []byte("b")},
+	}, recorder.PopWrites())
+	require.Empty(t, recorder.PopWrites())
+
+	// writes to a cache are notified once written
+	cache := store.CacheWrap().(types.CacheKVStore)
+	cache.Set([]byte("c"), []byte("3"))
+	require.Empty(t, recorder.PopWrites())
+	cache.Write()
+	require.Equal(t, []types.StoreKVPair{
+		{StoreKey: "store1", Key: []byte("c"), Value: []byte("3")},
+	}, recorder.PopWrites())
+}
+
+func TestRecorderGroupsByStore(t *testing.T) {
+	recorder := NewRecorder()
+	value := []byte("1")
+	recorder.OnWrite(key2, []byte("a"), value, false)
+	recorder.OnWrite(key1, []byte("b"), value, false)
+	recorder.OnWrite(key2, []byte("c"), nil, true)
+	recorder.OnWrite(key1, []byte("a"), value, false)
+
+	// the recorded value is a copy
+	value[0] = '2'
+	require.Equal(t, []types.StoreKVPair{
+		{StoreKey: "store1", Key: []byte("b"), Value: []byte("1")},
+		{StoreKey: "store1", Key: []byte("a"), Value: []byte("1")},
+		{StoreKey: "store2", Key: []byte("a"), Value: []byte("1")},
+		{StoreKey: "store2", Delete: true, Key: []byte("c")},
+	}, recorder.PopWrites())
+}
+
+func TestWrapSkipsTransientStores(t *testing.T) {
+	recorder := NewRecorder()
+	store := dbadapter.Store{DB: dbm.NewMemDB()}
+	require.IsType(t, &Store{}, Wrap(key1, store, recorder))
+	require.Equal(t, store, Wrap(types.NewTransientStoreKey("transient"), store, recorder))
+	require.Equal(t, store, Wrap(key1, store, nil))
+}
@@ -26,12 +26,23 @@ type (
 	StoreKey         = types.StoreKey
 	StoreType        = types.StoreType
 	Queryable        = types.Queryable
+	Snapshotter      = types.Snapshotter
+	Pruner           = types.Pruner
+	StoreRename      = types.StoreRename
+	StoreUpgrades    = types.StoreUpgrades
+	StoreUpgrader    = types.StoreUpgrader
+	StoreKVPair      = types.StoreKVPair
+	WriteListener    = types.WriteListener
+	StateListener    = types.StateListener
 	TraceContext     = types.TraceContext
 	Gas              = stypes.Gas
 	GasMeter         = types.GasMeter
 	GasConfig        = stypes.GasConfig
 )
 
+// nolint - reexport
+type ListeningMultiStore = types.ListeningMultiStore
+
 // nolint - reexport
 var (
 	PruneNothing    = types.PruneNothing
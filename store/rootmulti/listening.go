@@ -0,0 +1,54 @@
+package rootmulti
+
+import (
+	"fmt"
+
+	"github.com/ColorPlatform/color-sdk/store/cachemulti"
+	"github.com/ColorPlatform/color-sdk/store/listenkv"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var _ types.ListeningMultiStore = (*Store)(nil)
+
+// AddListener implements CommitMultiStore. The writes to the stores made
+// through the multistore and its caches are recorded from then on.
+func (rs *Store) AddListener(listener types.StateListener) {
+	if rs.recorder == nil {
+		rs.recorder = listenkv.NewRecorder()
+	}
+	rs.listeners = append(rs.listeners, listener)
+}
+
+// ListeningEnabled implements CommitMultiStore.
+func (rs *Store) ListeningEnabled() bool {
+	return len(rs.listeners) > 0
+}
+
+// CacheMultiStoreWithListener implements ListeningMultiStore. The writes of
+// the cache are also recorded for the listeners of the multistore.
+func (rs *Store) CacheMultiStoreWithListener(listener types.WriteListener) types.CacheMultiStore {
+	stores := make(map[types.StoreKey]types.CacheWrapper)
+	for k, v := range rs.stores {
+		var store types.CacheWrapper = v
+		if rs.ListeningEnabled() {
+			store = listenkv.Wrap(k, store, rs.recorder)
+		}
+		store = listenkv.Wrap(k, store, listener)
+		stores[k] = store
+	}
+	return cachemulti.NewStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.traceContext)
+}
+
+// listenCommit passes the writes recorded since the last commit to the
+// listeners. It panics if a listener fails, before the version is persisted.
+func (rs *Store) listenCommit(version int64) {
+	if !rs.ListeningEnabled() {
+		return
+	}
+	writes := rs.recorder.PopWrites()
+	for _, listener := range rs.listeners {
+		if err := listener.ListenCommit(version, writes); err != nil {
+			panic(fmt.Sprintf("failed to stream the writes of version %d: %v", version, err))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package rootmulti
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/ColorPlatform/prism/libs/db"
+
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+type commitListener struct {
+	versions []int64
+	writes   [][]types.StoreKVPair
+	err      error
+}
+
+func (l *commitListener) ListenDeliverTx(height int64, tx []byte, writes []types.StoreKVPair) error {
+	return nil
+}
+
+func (l *commitListener) ListenCommit(version int64, writes []types.StoreKVPair) error {
+	l.versions = append(l.versions, version)
+	l.writes = append(l.writes, writes)
+	return l.err
+}
+
+func TestListenCommit(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.False(t, store.ListeningEnabled())
+	listener := &commitListener{}
+	store.AddListener(listener)
+	require.True(t, store.ListeningEnabled())
+	require.NoError(t, store.LoadLatestVersion())
+
+	// writes made through caches and directly are both streamed
+	cache := store.CacheMultiStore()
+	cache.GetKVStore(store.keysByName["store2"]).Set([]byte("a"), []byte("1"))
+	cache.GetKVStore(store.keysByName["store1"]).Set([]byte("b"), []byte("2"))
+	cache.Write()
+	store.GetKVStore(store.keysByName["store1"]).Delete([]byte("c"))
+	store.Commit()
+
+	// an uncommitted cache is not streamed
+	store.CacheMultiStore().GetKVStore(store.keysByName["store3"]).Set([]byte("d"), []byte("4"))
+	store.Commit()
+
+	require.Equal(t, []int64{1, 2}, listener.versions)
+	require.Equal(t, [][]types.StoreKVPair{
+		{
+			{StoreKey: "store1", Key: []byte("b"), Value: []byte("2")},
+			{StoreKey: "store1", Delete: true, Key: []byte("c")},
+			{StoreKey: "store2", Key: []byte("a"), Value: []byte("1")},
+		},
+		nil,
+	}, listener.writes)
+
+	// a failing listener halts the commit
+	listener.err = errors.New("disk full")
+	require.Panics(t, func() { store.Commit() })
+	require.Equal(t, int64(2), store.LastCommitID().Version)
+}
+
+func TestCacheMultiStoreWithListener(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, store.LoadLatestVersion())
+	key := store.keysByName["store1"]
+
+	var writes []types.StoreKVPair
+	listener := writeListener(func(storeKey types.StoreKey, key, value []byte, delete bool) {
+		writes = append(writes, types.StoreKVPair{StoreKey: storeKey.Name(), Delete: delete, Key: key, Value: value})
+	})
+	cache := store.CacheMultiStoreWithListener(listener)
+	nested := cache.(types.ListeningMultiStore).CacheMultiStoreWithListener(listener)
+	nested.GetKVStore(key).Set([]byte("a"), []byte("1"))
+	require.Empty(t, writes)
+
+	// each level notifies the writes written to it
+	nested.Write()
+	require.Len(t, writes, 1)
+	cache.Write()
+	require.Len(t, writes, 2)
+	require.Equal(t, []byte("1"), store.GetKVStore(key).Get([]byte("a")))
+}
+
+type writeListener func(storeKey types.StoreKey, key, value []byte, delete bool)
+
+func (f writeListener) OnWrite(storeKey types.StoreKey, key, value []byte, delete bool) {
+	f(storeKey, key, value, delete)
+}
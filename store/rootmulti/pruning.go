@@ -0,0 +1,28 @@
+package rootmulti
+
+import (
+	"fmt"
+
+	"github.com/ColorPlatform/color-sdk/store/iavl"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var _ types.Pruner = (*Store)(nil)
+
+// Prune implements Pruner. It prunes the IAVL stores with the pruning options
+// of the multistore.
+func (rs *Store) Prune() (map[string]int, error) {
+	pruned := make(map[string]int)
+	for key, store := range rs.stores {
+		iavlStore, ok := store.(*iavl.Store)
+		if !ok {
+			continue
+		}
+		n, err := iavlStore.Prune(rs.pruningOpts)
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune store %s: %v", key.Name(), err)
+		}
+		pruned[key.Name()] = n
+	}
+	return pruned, nil
+}
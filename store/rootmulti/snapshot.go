@@ -0,0 +1,188 @@
+package rootmulti
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ColorPlatform/color-sdk/store/iavl"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var _ types.Snapshotter = (*Store)(nil)
+
+// snapshotItem is an item of the snapshot of a multistore. The snapshot holds
+// the trees of the IAVL stores ordered by name: an item naming the store and
+// giving the root hash of its tree, followed by the nodes of the tree.
+type snapshotItem struct {
+	Store    string
+	RootHash []byte
+	Node     []byte
+}
+
+// Snapshot implements Snapshotter. It writes the IAVL stores at the given
+// version to w.
+func (rs *Store) Snapshot(version int64, w io.Writer) ([]byte, error) {
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return nil, err
+	}
+	rootHashes := make(map[string][]byte, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		rootHashes[storeInfo.Name] = storeInfo.Core.CommitID.Hash
+	}
+
+	writeItem := func(item snapshotItem) error {
+		bz, err := cdc.MarshalBinaryLengthPrefixed(item)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(bz)
+		return err
+	}
+
+	for _, name := range rs.sortedStoreNames() {
+		params := rs.storesParams[rs.keysByName[name]]
+		switch params.typ {
+		case types.StoreTypeTransient:
+			continue
+		case types.StoreTypeIAVL:
+		default:
+			return nil, fmt.Errorf("cannot snapshot store %s of type %v", name, params.typ)
+		}
+
+		rootHash := rootHashes[name]
+		err := writeItem(snapshotItem{Store: name, RootHash: rootHash})
+		if err != nil {
+			return nil, err
+		}
+		exported, err := iavl.Export(rs.storeDB(params), version, func(node []byte) error {
+			return writeItem(snapshotItem{Node: node})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot store %s: %v", name, err)
+		}
+		if !bytes.Equal(exported, rootHash) {
+			return nil, fmt.Errorf("store %s has root hash %X at version %d instead of %X",
+				name, exported, version, rootHash)
+		}
+	}
+	return cInfo.Hash(), nil
+}
+
+// Restore implements Snapshotter. It restores the IAVL stores of an empty
+// multistore from a snapshot of the given version, checks they hash to the
+// app hash of the version, and loads the version.
+func (rs *Store) Restore(version int64, appHash []byte, r io.Reader) error {
+	if getLatestVersion(rs.db) != 0 {
+		return fmt.Errorf("cannot restore a snapshot over an existing state")
+	}
+
+	var (
+		importer   *iavl.Importer
+		storeName  string
+		storeInfos []storeInfo
+		restored   = make(map[string]bool)
+	)
+	br := bufio.NewReader(r)
+	for {
+		var item snapshotItem
+		n, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &item, 0)
+		if err == io.EOF && n == 0 {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot: %v", err)
+		}
+
+		if item.Store == "" {
+			if importer == nil {
+				return fmt.Errorf("snapshot node does not belong to any store")
+			}
+			if err := importer.Add(item.Node); err != nil {
+				return fmt.Errorf("failed to restore store %s: %v", storeName, err)
+			}
+			continue
+		}
+
+		if importer != nil {
+			if err := importer.Commit(); err != nil {
+				return fmt.Errorf("failed to restore store %s: %v", storeName, err)
+			}
+		}
+		key, ok := rs.keysByName[item.Store]
+		if !ok || rs.storesParams[key].typ != types.StoreTypeIAVL || restored[item.Store] {
+			return fmt.Errorf("unexpected store %s in snapshot", item.Store)
+		}
+		storeName = item.Store
+		restored[storeName] = true
+		importer = iavl.NewImporter(rs.storeDB(rs.storesParams[key]), version, item.RootHash)
+
+		si := storeInfo{}
+		si.Name = storeName
+		si.Core.CommitID = types.CommitID{Version: version, Hash: item.RootHash}
+		storeInfos = append(storeInfos, si)
+	}
+	if importer != nil {
+		if err := importer.Commit(); err != nil {
+			return fmt.Errorf("failed to restore store %s: %v", storeName, err)
+		}
+	}
+
+	for key, params := range rs.storesParams {
+		if params.typ == types.StoreTypeIAVL && !restored[key.Name()] {
+			return fmt.Errorf("store %s is missing from the snapshot", key.Name())
+		}
+	}
+	cInfo := commitInfo{
+		Version:    version,
+		StoreInfos: storeInfos,
+	}
+	if !bytes.Equal(cInfo.Hash(), appHash) {
+		return fmt.Errorf("restored state has hash %X instead of app hash %X", cInfo.Hash(), appHash)
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, version, cInfo)
+	setLatestVersion(batch, version)
+	batch.Write()
+
+	return rs.LoadVersion(version)
+}
+
+// sortedStoreNames returns the names of the mounted stores in order.
+func (rs *Store) sortedStoreNames() []string {
+	names := make([]string, 0, len(rs.keysByName))
+	for name := range rs.keysByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Implements Snapshotter.
+func (rs *Store) PinVersion(version int64) {
+	rs.pinMtx.Lock()
+	defer rs.pinMtx.Unlock()
+	rs.pinned[version]++
+}
+
+// Implements Snapshotter.
+func (rs *Store) UnpinVersion(version int64) {
+	rs.pinMtx.Lock()
+	defer rs.pinMtx.Unlock()
+	if rs.pinned[version] <= 1 {
+		delete(rs.pinned, version)
+		return
+	}
+	rs.pinned[version]--
+}
+
+// isPinned reports whether the version is pinned against pruning.
+func (rs *Store) isPinned(version int64) bool {
+	rs.pinMtx.Lock()
+	defer rs.pinMtx.Unlock()
+	return rs.pinned[version] > 0
+}
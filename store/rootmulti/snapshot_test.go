@@ -0,0 +1,129 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+func newSnapshottedMultiStore(t *testing.T) *Store {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, store.LoadLatestVersion())
+
+	for version := 1; version <= 3; version++ {
+		for i, name := range []string{"store1", "store2", "store3"} {
+			kv := store.getStoreByName(name).(types.KVStore)
+			for j := 0; j < 20*(i+1); j++ {
+				kv.Set([]byte(fmt.Sprintf("key%03d", j)), []byte(fmt.Sprintf("value%d-%d", version, j)))
+			}
+			kv.Delete([]byte(fmt.Sprintf("key%03d", version)))
+		}
+		store.Commit()
+	}
+	return store
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	source := newSnapshottedMultiStore(t)
+
+	for _, version := range []int64{2, 3} {
+		var buf bytes.Buffer
+		appHash, err := source.Snapshot(version, &buf)
+		require.NoError(t, err)
+		snapshot := append([]byte{}, buf.Bytes()...)
+		cInfo, err := getCommitInfo(source.db, version)
+		require.NoError(t, err)
+		require.Equal(t, cInfo.Hash(), appHash)
+
+		target := newMultiStoreWithMounts(dbm.NewMemDB())
+		require.NoError(t, target.LoadLatestVersion())
+		require.NoError(t, target.Restore(version, appHash, &buf))
+		require.Equal(t, types.CommitID{Version: version, Hash: appHash}, target.LastCommitID())
+
+		for i, name := range []string{"store1", "store2", "store3"} {
+			kv := target.getStoreByName(name).(types.KVStore)
+			for j := 0; j < 20*(i+1); j++ {
+				key := []byte(fmt.Sprintf("key%03d", j))
+				if int64(j) == version {
+					require.Nil(t, kv.Get(key), "store %s key %s", name, key)
+					continue
+				}
+				require.Equal(t, []byte(fmt.Sprintf("value%d-%d", version, j)), kv.Get(key), "store %s key %s", name, key)
+			}
+		}
+
+		// a snapshot of the restored store is the same
+		var restored bytes.Buffer
+		_, err = target.Snapshot(version, &restored)
+		require.NoError(t, err)
+		require.Equal(t, snapshot, restored.Bytes())
+
+		// the restored store carries on from the snapshot version
+		target.getStoreByName("store1").(types.KVStore).Set([]byte("new"), []byte("value"))
+		require.Equal(t, version+1, target.Commit().Version)
+	}
+}
+
+func TestRestoreInvalidSnapshot(t *testing.T) {
+	source := newSnapshottedMultiStore(t)
+	var buf bytes.Buffer
+	appHash, err := source.Snapshot(3, &buf)
+	require.NoError(t, err)
+	snapshot := buf.Bytes()
+
+	// wrong app hash
+	target := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, target.LoadLatestVersion())
+	badHash := append([]byte{}, appHash...)
+	badHash[0]++
+	require.Error(t, target.Restore(3, badHash, bytes.NewReader(snapshot)))
+
+	// corrupted node
+	target = newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, target.LoadLatestVersion())
+	corrupted := append([]byte{}, snapshot...)
+	corrupted[len(corrupted)-1]++
+	require.Error(t, target.Restore(3, appHash, bytes.NewReader(corrupted)))
+
+	// truncated snapshot
+	target = newMultiStoreWithMounts(dbm.NewMemDB())
+	require.NoError(t, target.LoadLatestVersion())
+	require.Error(t, target.Restore(3, appHash, bytes.NewReader(snapshot[:len(snapshot)/2])))
+
+	// existing state
+	require.Error(t, source.Restore(3, appHash, bytes.NewReader(snapshot)))
+}
+
+func TestSnapshotPinnedVersion(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.pruningOpts = types.PruneEverything
+	require.NoError(t, store.LoadLatestVersion())
+	commit := func() {
+		store.getStoreByName("store1").(types.KVStore).Set([]byte("key"), []byte(fmt.Sprintf("value%d", store.LastCommitID().Version)))
+		store.Commit()
+	}
+
+	commit()
+	store.PinVersion(1)
+	store.PinVersion(1)
+	commit()
+	commit()
+	_, err := store.Snapshot(1, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	// the version is kept until its last pin is released
+	store.UnpinVersion(1)
+	commit()
+	_, err = store.Snapshot(1, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	store.UnpinVersion(1)
+	commit()
+	_, err = store.Snapshot(1, &bytes.Buffer{})
+	require.Error(t, err)
+}
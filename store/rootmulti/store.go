@@ -4,16 +4,17 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	abci "github.com/ColorPlatform/prism/abci/types"
 	"github.com/ColorPlatform/prism/crypto/merkle"
 	"github.com/ColorPlatform/prism/crypto/tmhash"
 	dbm "github.com/ColorPlatform/prism/libs/db"
 
-	"github.com/ColorPlatform/color-sdk/store/cachemulti"
 	"github.com/ColorPlatform/color-sdk/store/dbadapter"
 	"github.com/ColorPlatform/color-sdk/store/errors"
 	"github.com/ColorPlatform/color-sdk/store/iavl"
+	"github.com/ColorPlatform/color-sdk/store/listenkv"
 	"github.com/ColorPlatform/color-sdk/store/tracekv"
 	"github.com/ColorPlatform/color-sdk/store/transient"
 	"github.com/ColorPlatform/color-sdk/store/types"
@@ -37,6 +38,14 @@ type Store struct {
 
 	traceWriter  io.Writer
 	traceContext types.TraceContext
+
+	// listeners of the writes of each version, recorded by recorder
+	listeners []types.StateListener
+	recorder  *listenkv.Recorder
+
+	// versions pinned against pruning, with the number of times each is pinned
+	pinMtx sync.Mutex
+	pinned map[int64]int
 }
 
 var _ types.CommitMultiStore = (*Store)(nil)
@@ -49,6 +58,7 @@ func NewStore(db dbm.DB) *Store {
 		storesParams: make(map[types.StoreKey]storeParams),
 		stores:       make(map[types.StoreKey]types.CommitStore),
 		keysByName:   make(map[string]types.StoreKey),
+		pinned:       make(map[int64]int),
 	}
 }
 
@@ -102,6 +112,11 @@ func (rs *Store) LoadLatestVersion() error {
 
 // Implements CommitMultiStore.
 func (rs *Store) LoadVersion(ver int64) error {
+	_, err := rs.loadVersion(ver, nil)
+	return err
+}
+
+func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) (map[string]int, error) {
 
 	// Special logic for version 0
 	if ver == 0 {
@@ -109,48 +124,59 @@ func (rs *Store) LoadVersion(ver int64) error {
 			id := types.CommitID{}
 			store, err := rs.loadCommitStoreFromParams(key, id, storeParams)
 			if err != nil {
-				return fmt.Errorf("failed to load Store: %v", err)
+				return nil, fmt.Errorf("failed to load Store: %v", err)
 			}
 			rs.stores[key] = store
 		}
 
 		rs.lastCommitID = types.CommitID{}
-		return nil
+		return nil, nil
 	}
 	// Otherwise, version is 1 or greater
 
 	// Get commitInfo
 	cInfo, err := getCommitInfo(rs.db, ver)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Convert StoreInfos slice to map
-	infos := make(map[types.StoreKey]storeInfo)
+	infos := make(map[string]storeInfo)
 	for _, storeInfo := range cInfo.StoreInfos {
-		infos[rs.nameToKey(storeInfo.Name)] = storeInfo
+		infos[storeInfo.Name] = storeInfo
+	}
+	if err := rs.checkUpgrades(infos, upgrades); err != nil {
+		return nil, err
 	}
 
 	// Load each Store
 	var newStores = make(map[types.StoreKey]types.CommitStore)
 	for key, storeParams := range rs.storesParams {
 		var id types.CommitID
-		info, ok := infos[key]
+		info, ok := infos[key.Name()]
 		if ok {
 			id = info.Core.CommitID
 		}
 
 		store, err := rs.loadCommitStoreFromParams(key, id, storeParams)
 		if err != nil {
-			return fmt.Errorf("failed to load Store: %v", err)
+			return nil, fmt.Errorf("failed to load Store: %v", err)
 		}
 		newStores[key] = store
 	}
 
+	var upgraded map[string]int
+	if upgrades != nil {
+		upgraded, err = rs.upgradeStores(newStores, infos, upgrades)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Success.
 	rs.lastCommitID = cInfo.CommitID()
 	rs.stores = newStores
-	return nil
+	return upgraded, nil
 }
 
 // SetTracer sets the tracer for the MultiStore that the underlying
@@ -194,6 +220,7 @@ func (rs *Store) Commit() types.CommitID {
 
 	// Commit stores.
 	version := rs.lastCommitID.Version + 1
+	rs.listenCommit(version)
 	commitInfo := commitStores(version, rs.stores)
 
 	// Need to update atomically.
@@ -226,11 +253,7 @@ func (rs *Store) CacheWrapWithTrace(_ io.Writer, _ types.TraceContext) types.Cac
 
 // Implements MultiStore.
 func (rs *Store) CacheMultiStore() types.CacheMultiStore {
-	stores := make(map[types.StoreKey]types.CacheWrapper)
-	for k, v := range rs.stores {
-		stores[k] = v
-	}
-	return cachemulti.NewStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.traceContext)
+	return rs.CacheMultiStoreWithListener(nil)
 }
 
 // Implements MultiStore.
@@ -250,6 +273,9 @@ func (rs *Store) GetStore(key types.StoreKey) types.Store {
 func (rs *Store) GetKVStore(key types.StoreKey) types.KVStore {
 	store := rs.stores[key].(types.KVStore)
 
+	if rs.ListeningEnabled() {
+		store = listenkv.Wrap(key, store, rs.recorder).(types.KVStore)
+	}
 	if rs.TracingEnabled() {
 		store = tracekv.NewStore(store, rs.traceWriter, rs.traceContext)
 	}
@@ -347,12 +373,7 @@ func parsePath(path string) (storeName string, subpath string, err errors.Error)
 //----------------------------------------
 
 func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID, params storeParams) (store types.CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
-	}
+	db := rs.storeDB(params)
 	switch params.typ {
 	case types.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
@@ -360,6 +381,9 @@ func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID
 		// return NewCommitMultiStore(db, id)
 	case types.StoreTypeIAVL:
 		store, err = iavl.LoadStore(db, id, rs.pruningOpts)
+		if err == nil {
+			store.(*iavl.Store).SetPinned(rs.isPinned)
+		}
 		return
 	case types.StoreTypeDB:
 		store = commitDBStoreAdapter{dbadapter.Store{db}}
@@ -377,13 +401,13 @@ func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID
 	}
 }
 
-func (rs *Store) nameToKey(name string) types.StoreKey {
-	for key := range rs.storesParams {
-		if key.Name() == name {
-			return key
-		}
+// storeDB returns the database of a store, either its own or a prefix of the
+// multistore database.
+func (rs *Store) storeDB(params storeParams) dbm.DB {
+	if params.db != nil {
+		return dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	}
-	panic("Unknown name " + name)
+	return dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
 }
 
 //----------------------------------------
@@ -0,0 +1,155 @@
+package rootmulti
+
+import (
+	"fmt"
+
+	"github.com/ColorPlatform/color-sdk/store/iavl"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+var _ types.StoreUpgrader = (*Store)(nil)
+
+// GetLatestVersion implements StoreUpgrader.
+func (rs *Store) GetLatestVersion() int64 {
+	return getLatestVersion(rs.db)
+}
+
+// LoadVersionAndUpgrade implements StoreUpgrader. The mounted stores must be
+// the stores of the version after the upgrades: added and renamed stores are
+// mounted, deleted stores and the old names of renamed stores aren't.
+//
+// The data of renamed stores is copied to their new name, and takes effect
+// with the next commit like the dropping of deleted stores. The data left
+// under the old names isn't part of the state anymore, but stays in the
+// database with the previous versions.
+func (rs *Store) LoadVersionAndUpgrade(ver int64, upgrades *types.StoreUpgrades) (map[string]int, error) {
+	if ver == 0 {
+		return nil, fmt.Errorf("no state to upgrade")
+	}
+	if upgrades == nil {
+		upgrades = &types.StoreUpgrades{}
+	}
+	return rs.loadVersion(ver, upgrades)
+}
+
+// checkUpgrades checks the mounted stores match the stores of a version after
+// the given upgrades, if any.
+func (rs *Store) checkUpgrades(infos map[string]storeInfo, upgrades *types.StoreUpgrades) error {
+	if upgrades == nil {
+		for name := range infos {
+			if _, ok := rs.keysByName[name]; !ok {
+				return fmt.Errorf("store %s is not mounted, it must be deleted or renamed by an upgrade", name)
+			}
+		}
+		return nil
+	}
+
+	upgraded := make(map[string]bool)
+	checkNew := func(name string) error {
+		key, ok := rs.keysByName[name]
+		if !ok {
+			return fmt.Errorf("store %s to add is not mounted", name)
+		}
+		if _, ok := infos[name]; ok {
+			return fmt.Errorf("store %s to add already exists", name)
+		}
+		if rs.storesParams[key].typ == types.StoreTypeIAVL && hasData(rs, rs.storesParams[key]) {
+			return fmt.Errorf("store %s to add already has data", name)
+		}
+		return nil
+	}
+	checkOld := func(name string, typ string) error {
+		if _, ok := infos[name]; !ok {
+			return fmt.Errorf("store %s to %s doesn't exist", name, typ)
+		}
+		if _, ok := rs.keysByName[name]; ok {
+			return fmt.Errorf("store %s to %s is still mounted", name, typ)
+		}
+		if upgraded[name] {
+			return fmt.Errorf("store %s is upgraded twice", name)
+		}
+		upgraded[name] = true
+		return nil
+	}
+
+	for _, name := range upgrades.Added {
+		if err := checkNew(name); err != nil {
+			return err
+		}
+	}
+	for _, rename := range upgrades.Renamed {
+		if err := checkOld(rename.OldKey, "rename"); err != nil {
+			return err
+		}
+		if err := checkNew(rename.NewKey); err != nil {
+			return err
+		}
+		if rs.storesParams[rs.keysByName[rename.NewKey]].typ != types.StoreTypeIAVL {
+			return fmt.Errorf("store %s renamed to %s must be an IAVL store", rename.OldKey, rename.NewKey)
+		}
+	}
+	for _, name := range upgrades.Deleted {
+		if err := checkOld(name, "delete"); err != nil {
+			return err
+		}
+	}
+
+	for name := range infos {
+		if _, ok := rs.keysByName[name]; !ok && !upgraded[name] {
+			return fmt.Errorf("store %s is not mounted, it must be deleted or renamed by an upgrade", name)
+		}
+	}
+	return nil
+}
+
+// upgradeStores moves the data of the renamed stores into the loaded stores,
+// and counts the keys of the deleted stores.
+func (rs *Store) upgradeStores(stores map[types.StoreKey]types.CommitStore, infos map[string]storeInfo,
+	upgrades *types.StoreUpgrades) (map[string]int, error) {
+
+	upgraded := make(map[string]int)
+	for _, rename := range upgrades.Renamed {
+		oldStore, err := rs.loadUnmountedStore(rename.OldKey, infos[rename.OldKey])
+		if err != nil {
+			return nil, err
+		}
+		newStore := stores[rs.keysByName[rename.NewKey]].(types.KVStore)
+
+		iter := oldStore.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			newStore.Set(iter.Key(), iter.Value())
+			upgraded[rename.NewKey]++
+		}
+		iter.Close()
+	}
+	for _, name := range upgrades.Deleted {
+		store, err := rs.loadUnmountedStore(name, infos[name])
+		if err != nil {
+			return nil, err
+		}
+		upgraded[name] = 0
+		iter := store.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			upgraded[name]++
+		}
+		iter.Close()
+	}
+	return upgraded, nil
+}
+
+// loadUnmountedStore loads an IAVL store which is no longer mounted.
+func (rs *Store) loadUnmountedStore(name string, info storeInfo) (types.KVStore, error) {
+	params := storeParams{key: types.NewKVStoreKey(name), typ: types.StoreTypeIAVL}
+	store, err := iavl.LoadStore(rs.storeDB(params), info.Core.CommitID, rs.pruningOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load store %s: %v", name, err)
+	}
+	return store.(types.KVStore), nil
+}
+
+// hasData returns true if the database of a store has any data.
+func hasData(rs *Store, params storeParams) bool {
+	iter := rs.storeDB(params).Iterator(nil, nil)
+	defer iter.Close()
+	return iter.Valid()
+}
@@ -0,0 +1,113 @@
+package rootmulti
+
+import (
+	"testing"
+
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+func newUpgradedMultiStore(db dbm.DB) *Store {
+	store := NewStore(db)
+	store.pruningOpts = types.PruneSyncable
+	store.MountStoreWithDB(
+		types.NewKVStoreKey("store1"), types.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(
+		types.NewKVStoreKey("store4"), types.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(
+		types.NewKVStoreKey("store5"), types.StoreTypeIAVL, nil)
+	return store
+}
+
+func TestLoadVersionAndUpgrade(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	for i, name := range []string{"store1", "store2", "store3"} {
+		kv := store.getStoreByName(name).(types.KVStore)
+		for j := 0; j <= i; j++ {
+			kv.Set([]byte{byte(j)}, []byte(name))
+		}
+	}
+	store.Commit()
+	require.Equal(t, int64(1), store.GetLatestVersion())
+
+	// the unmounted stores must be upgraded
+	store = newUpgradedMultiStore(db)
+	require.Error(t, store.LoadLatestVersion())
+	_, err := store.LoadVersionAndUpgrade(1, &types.StoreUpgrades{Deleted: []string{"store3"}})
+	require.Error(t, err)
+
+	upgrades := &types.StoreUpgrades{
+		Added:   []string{"store5"},
+		Renamed: []types.StoreRename{{OldKey: "store2", NewKey: "store4"}},
+		Deleted: []string{"store3"},
+	}
+	store = newUpgradedMultiStore(db)
+	upgraded, err := store.LoadVersionAndUpgrade(1, upgrades)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"store4": 2, "store3": 3}, upgraded)
+
+	require.Equal(t, []byte("store1"), store.getStoreByName("store1").(types.KVStore).Get([]byte{0}))
+	require.Equal(t, []byte("store2"), store.getStoreByName("store4").(types.KVStore).Get([]byte{1}))
+	require.Nil(t, store.getStoreByName("store5").(types.KVStore).Get([]byte{0}))
+	commitID := store.Commit()
+
+	// the upgraded stores make the next version
+	cInfo, err := getCommitInfo(db, 2)
+	require.NoError(t, err)
+	names := make([]string, len(cInfo.StoreInfos))
+	for i, info := range cInfo.StoreInfos {
+		names[i] = info.Name
+	}
+	require.ElementsMatch(t, []string{"store1", "store4", "store5"}, names)
+
+	store = newUpgradedMultiStore(db)
+	require.NoError(t, store.LoadLatestVersion())
+	require.Equal(t, commitID, store.LastCommitID())
+	require.Equal(t, []byte("store2"), store.getStoreByName("store4").(types.KVStore).Get([]byte{0}))
+}
+
+func TestLoadVersionAndUpgradeInvalid(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+	store.getStoreByName("store1").(types.KVStore).Set([]byte("key"), []byte("value"))
+	store.Commit()
+
+	tests := []*types.StoreUpgrades{
+		// store1 already exists
+		{Added: []string{"store1"}},
+		// store6 is not mounted
+		{Added: []string{"store6"}},
+		// store1 is still mounted
+		{Deleted: []string{"store1"}},
+		// store6 doesn't exist
+		{Renamed: []types.StoreRename{{OldKey: "store6", NewKey: "store4"}}},
+	}
+	for i, upgrades := range tests {
+		store := NewStore(db)
+		for _, name := range []string{"store1", "store2", "store3", "store4"} {
+			store.MountStoreWithDB(types.NewKVStoreKey(name), types.StoreTypeIAVL, nil)
+		}
+		_, err := store.LoadVersionAndUpgrade(1, upgrades)
+		require.Error(t, err, "test %d", i)
+	}
+
+	// a store can be upgraded only once
+	store = NewStore(db)
+	store.MountStoreWithDB(types.NewKVStoreKey("store4"), types.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(types.NewKVStoreKey("store5"), types.StoreTypeIAVL, nil)
+	_, err := store.LoadVersionAndUpgrade(1, &types.StoreUpgrades{
+		Renamed: []types.StoreRename{{OldKey: "store1", NewKey: "store4"}, {OldKey: "store1", NewKey: "store5"}},
+		Deleted: []string{"store2", "store3"},
+	})
+	require.Error(t, err)
+
+	// there is no state to upgrade
+	store = newUpgradedMultiStore(dbm.NewMemDB())
+	_, err = store.LoadVersionAndUpgrade(0, nil)
+	require.Error(t, err)
+}
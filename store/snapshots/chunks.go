@@ -0,0 +1,112 @@
+package snapshots
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ColorPlatform/prism/crypto/tmhash"
+)
+
+// chunkWriter cuts the stream written to it into chunks of a given size,
+// which it saves as they fill up.
+type chunkWriter struct {
+	size   int
+	buf    bytes.Buffer
+	save   func(index uint32, chunk []byte) error
+	hashes [][]byte
+}
+
+func newChunkWriter(size int, save func(index uint32, chunk []byte) error) *chunkWriter {
+	return &chunkWriter{
+		size: size,
+		save: save,
+	}
+}
+
+// Write implements io.Writer.
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := cw.size - cw.buf.Len()
+		if n > len(p) {
+			n = len(p)
+		}
+		cw.buf.Write(p[:n])
+		p = p[n:]
+		written += n
+		if cw.buf.Len() == cw.size {
+			if err := cw.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close saves the last chunk.
+func (cw *chunkWriter) Close() error {
+	if cw.buf.Len() == 0 && len(cw.hashes) > 0 {
+		return nil
+	}
+	return cw.flush()
+}
+
+func (cw *chunkWriter) flush() error {
+	chunk := cw.buf.Bytes()
+	if err := cw.save(uint32(len(cw.hashes)), chunk); err != nil {
+		return err
+	}
+	cw.hashes = append(cw.hashes, tmhash.Sum(chunk))
+	cw.buf.Reset()
+	return nil
+}
+
+// chunkReader reads the chunks of a snapshot in order, checking each chunk
+// against its hash before returning it.
+type chunkReader struct {
+	hashes [][]byte
+	load   func(index uint32) ([]byte, error)
+	next   uint32
+	chunk  io.Reader
+}
+
+func newChunkReader(hashes [][]byte, load func(index uint32) ([]byte, error)) *chunkReader {
+	return &chunkReader{
+		hashes: hashes,
+		load:   load,
+		chunk:  bytes.NewReader(nil),
+	}
+}
+
+// Read implements io.Reader.
+func (cr *chunkReader) Read(p []byte) (int, error) {
+	for {
+		n, err := cr.chunk.Read(p)
+		if err != io.EOF || n > 0 {
+			return n, err
+		}
+		if int(cr.next) == len(cr.hashes) {
+			return 0, io.EOF
+		}
+
+		chunk, err := cr.load(cr.next)
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(tmhash.Sum(chunk), cr.hashes[cr.next]) {
+			return 0, fmt.Errorf("chunk %d does not match its hash %X", cr.next, cr.hashes[cr.next])
+		}
+		cr.chunk = bytes.NewReader(chunk)
+		cr.next++
+	}
+}
+
+// Close implements io.Closer.
+func (cr *chunkReader) Close() error {
+	cr.chunk = bytes.NewReader(nil)
+	cr.next = uint32(len(cr.hashes))
+	return nil
+}
+
+var _ io.ReadCloser = (*chunkReader)(nil)
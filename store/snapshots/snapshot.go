@@ -0,0 +1,63 @@
+package snapshots
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ColorPlatform/prism/crypto/merkle"
+)
+
+// CurrentFormat is the format of the snapshots taken by this version: the
+// snapshot of the multistore, compressed with zlib and cut into chunks.
+const CurrentFormat uint32 = 1
+
+// DefaultChunkSize is the default size in bytes of the chunks of a snapshot
+const DefaultChunkSize = 10 * 1024 * 1024
+
+// Snapshot describes a snapshot of the state of the application at a height
+type Snapshot struct {
+	Height      int64    `json:"height"`
+	Format      uint32   `json:"format"`
+	AppHash     []byte   `json:"app_hash"`     // hash of the state at the height
+	ChunkHashes [][]byte `json:"chunk_hashes"` // hashes of the chunks, in order
+	Hash        []byte   `json:"hash"`         // merkle root of the chunk hashes
+}
+
+// NewSnapshot returns a snapshot of the state at a height made of chunks with
+// the given hashes.
+func NewSnapshot(height int64, appHash []byte, chunkHashes [][]byte) Snapshot {
+	return Snapshot{
+		Height:      height,
+		Format:      CurrentFormat,
+		AppHash:     appHash,
+		ChunkHashes: chunkHashes,
+		Hash:        merkle.SimpleHashFromByteSlices(chunkHashes),
+	}
+}
+
+// Validate checks the snapshot can be restored by this version, and that its
+// hash matches its chunks.
+func (s Snapshot) Validate() error {
+	if s.Height <= 0 {
+		return fmt.Errorf("snapshot height must be positive, got %d", s.Height)
+	}
+	if s.Format != CurrentFormat {
+		return fmt.Errorf("unsupported snapshot format %d, expected %d", s.Format, CurrentFormat)
+	}
+	if len(s.ChunkHashes) == 0 {
+		return fmt.Errorf("snapshot has no chunks")
+	}
+	if !bytes.Equal(merkle.SimpleHashFromByteSlices(s.ChunkHashes), s.Hash) {
+		return fmt.Errorf("snapshot hash %X does not match its chunks", s.Hash)
+	}
+	return nil
+}
+
+func (s Snapshot) String() string {
+	return fmt.Sprintf(`Snapshot:
+  Height:   %d
+  Format:   %d
+  Chunks:   %d
+  App Hash: %X
+  Hash:     %X`, s.Height, s.Format, len(s.ChunkHashes), s.AppHash, s.Hash)
+}
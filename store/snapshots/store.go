@@ -0,0 +1,213 @@
+package snapshots
+
+import (
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+)
+
+const metadataFile = "metadata.json"
+
+var cdc = codec.New()
+
+// Store keeps the snapshots of a node on disk. Each snapshot is a directory
+// named after its height, holding its metadata and one file per chunk.
+type Store struct {
+	dir string
+
+	mtx    sync.Mutex
+	saving bool // whether a snapshot is being saved
+}
+
+// NewStore returns a store of the snapshots in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %v", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Save takes a snapshot at the given height, writing the state with write
+// which returns the app hash of the state. Only one snapshot is saved at a
+// time.
+func (s *Store) Save(height int64, chunkSize int, write func(w io.Writer) ([]byte, error)) (*Snapshot, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+	s.mtx.Lock()
+	if s.saving {
+		s.mtx.Unlock()
+		return nil, fmt.Errorf("a snapshot is already being saved")
+	}
+	s.saving = true
+	s.mtx.Unlock()
+	defer func() {
+		s.mtx.Lock()
+		s.saving = false
+		s.mtx.Unlock()
+	}()
+
+	// write the snapshot to a temporary directory, and move it in place once complete
+	tmpDir := s.pathOf(height) + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return nil, err
+	}
+	if err := os.Mkdir(tmpDir, 0755); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cw := newChunkWriter(chunkSize, func(index uint32, chunk []byte) error {
+		return ioutil.WriteFile(chunkPath(tmpDir, index), chunk, 0644)
+	})
+	zw := zlib.NewWriter(cw)
+	appHash, err := write(zw)
+	if err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if err := cw.Close(); err != nil {
+		return nil, err
+	}
+
+	snapshot := NewSnapshot(height, appHash, cw.hashes)
+	bz, err := cdc.MarshalJSONIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, metadataFile), bz, 0644); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(s.pathOf(height)); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpDir, s.pathOf(height)); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Get returns the snapshot at the given height.
+func (s *Store) Get(height int64) (*Snapshot, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(s.pathOf(height), metadataFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no snapshot at height %d", height)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := cdc.UnmarshalJSON(bz, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot at height %d: %v", height, err)
+	}
+	return &snapshot, nil
+}
+
+// List returns the snapshots in the store, the most recent first.
+func (s *Store) List() ([]*Snapshot, error) {
+	heights, err := s.heights()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*Snapshot, 0, len(heights))
+	for _, height := range heights {
+		snapshot, err := s.Get(height)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// LoadChunk returns a chunk of the snapshot at the given height.
+func (s *Store) LoadChunk(height int64, index uint32) ([]byte, error) {
+	snapshot, err := s.Get(height)
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(snapshot.ChunkHashes) {
+		return nil, fmt.Errorf("snapshot at height %d has no chunk %d", height, index)
+	}
+	return ioutil.ReadFile(chunkPath(s.pathOf(height), index))
+}
+
+// Load returns the snapshot at the given height, and a reader of its state
+// which checks every chunk against its hash.
+func (s *Store) Load(height int64) (*Snapshot, io.ReadCloser, error) {
+	snapshot, err := s.Get(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := snapshot.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	cr := newChunkReader(snapshot.ChunkHashes, func(index uint32) ([]byte, error) {
+		return ioutil.ReadFile(chunkPath(s.pathOf(height), index))
+	})
+	zr, err := zlib.NewReader(cr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read snapshot at height %d: %v", height, err)
+	}
+	return snapshot, zr, nil
+}
+
+// Delete deletes the snapshot at the given height.
+func (s *Store) Delete(height int64) error {
+	return os.RemoveAll(s.pathOf(height))
+}
+
+// Prune deletes all but the given number of most recent snapshots, and returns
+// the number of snapshots deleted.
+func (s *Store) Prune(retain uint32) (int, error) {
+	heights, err := s.heights()
+	if err != nil {
+		return 0, err
+	}
+	pruned := 0
+	for i := int(retain); i < len(heights); i++ {
+		if err := s.Delete(heights[i]); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// heights returns the heights of the snapshots in the store, the most recent
+// first.
+func (s *Store) heights() ([]int64, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var heights []int64
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights, nil
+}
+
+func (s *Store) pathOf(height int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(height, 10))
+}
+
+func chunkPath(dir string, index uint32) string {
+	return filepath.Join(dir, strconv.FormatUint(uint64(index), 10))
+}
@@ -0,0 +1,149 @@
+package snapshots
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmn "github.com/ColorPlatform/prism/libs/common"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStore(t *testing.T) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	return store, func() { os.RemoveAll(dir) }
+}
+
+func writeState(state []byte) func(w io.Writer) ([]byte, error) {
+	return func(w io.Writer) ([]byte, error) {
+		_, err := w.Write(state)
+		return []byte("apphash"), err
+	}
+}
+
+func TestStoreSaveLoad(t *testing.T) {
+	store, cleanup := setupStore(t)
+	defer cleanup()
+
+	// random bytes don't compress, so the state takes several chunks
+	state := cmn.RandBytes(10000)
+	snapshot, err := store.Save(3, 1024, writeState(state))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), snapshot.Height)
+	require.Equal(t, CurrentFormat, snapshot.Format)
+	require.Equal(t, []byte("apphash"), snapshot.AppHash)
+	require.True(t, len(snapshot.ChunkHashes) > 1)
+	require.NoError(t, snapshot.Validate())
+
+	got, err := store.Get(3)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, got)
+
+	chunk, err := store.LoadChunk(3, 0)
+	require.NoError(t, err)
+	require.Len(t, chunk, 1024)
+	_, err = store.LoadChunk(3, uint32(len(snapshot.ChunkHashes)))
+	require.Error(t, err)
+
+	loaded, r, err := store.Load(3)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, loaded)
+	bz, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, state, bz)
+
+	_, _, err = store.Load(4)
+	require.Error(t, err)
+}
+
+func TestStoreEmptyState(t *testing.T) {
+	store, cleanup := setupStore(t)
+	defer cleanup()
+
+	snapshot, err := store.Save(1, 1024, writeState(nil))
+	require.NoError(t, err)
+	require.Len(t, snapshot.ChunkHashes, 1)
+
+	_, r, err := store.Load(1)
+	require.NoError(t, err)
+	bz, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Empty(t, bz)
+}
+
+func TestStoreSaveFailure(t *testing.T) {
+	store, cleanup := setupStore(t)
+	defer cleanup()
+
+	_, err := store.Save(1, 1024, func(w io.Writer) ([]byte, error) {
+		return nil, errors.New("failed")
+	})
+	require.Error(t, err)
+	_, err = store.Get(1)
+	require.Error(t, err)
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	_, err = store.Save(1, 0, writeState(nil))
+	require.Error(t, err)
+}
+
+func TestStoreCorruptChunk(t *testing.T) {
+	store, cleanup := setupStore(t)
+	defer cleanup()
+
+	_, err := store.Save(1, 1024, writeState(cmn.RandBytes(5000)))
+	require.NoError(t, err)
+
+	path := chunkPath(store.pathOf(1), 1)
+	chunk, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	chunk[0]++
+	require.NoError(t, ioutil.WriteFile(path, chunk, 0644))
+
+	_, r, err := store.Load(1)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(r)
+	require.Error(t, err)
+}
+
+func TestStoreListPrune(t *testing.T) {
+	store, cleanup := setupStore(t)
+	defer cleanup()
+
+	for _, height := range []int64{5, 1, 10, 3} {
+		_, err := store.Save(height, 1024, writeState([]byte("state")))
+		require.NoError(t, err)
+	}
+	// other entries of the directory are ignored
+	require.NoError(t, os.Mkdir(filepath.Join(store.dir, "other"), 0755))
+
+	list, err := store.List()
+	require.NoError(t, err)
+	heights := make([]int64, len(list))
+	for i, snapshot := range list {
+		heights[i] = snapshot.Height
+	}
+	require.Equal(t, []int64{10, 5, 3, 1}, heights)
+
+	pruned, err := store.Prune(2)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	list, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, int64(10), list[0].Height)
+	require.Equal(t, int64(5), list[1].Height)
+
+	pruned, err = store.Prune(2)
+	require.NoError(t, err)
+	require.Equal(t, 0, pruned)
+}
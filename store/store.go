@@ -1,6 +1,8 @@
 package store
 
 import (
+	"fmt"
+
 	dbm "github.com/ColorPlatform/prism/libs/db"
 
 	"github.com/ColorPlatform/color-sdk/store/rootmulti"
@@ -24,3 +26,25 @@ func NewPruningOptionsFromString(strategy string) (opt PruningOptions) {
 	}
 	return
 }
+
+// NewPruningOptionsFromConfig returns the pruning options of the strategy with
+// the given name. A custom strategy keeps the given number of recent states,
+// and every keepEvery states.
+func NewPruningOptionsFromConfig(strategy string, keepRecent, keepEvery int64) (PruningOptions, error) {
+	switch strategy {
+	case types.PruningStrategyNothing:
+		return PruneNothing, nil
+	case types.PruningStrategyEverything:
+		return PruneEverything, nil
+	case types.PruningStrategySyncable:
+		return PruneSyncable, nil
+	case types.PruningStrategyCustom:
+		opt := types.NewPruningOptions(keepRecent, keepEvery)
+		if err := opt.Validate(); err != nil {
+			return opt, fmt.Errorf("invalid custom pruning: %v", err)
+		}
+		return opt, nil
+	default:
+		return PruningOptions{}, fmt.Errorf("unknown pruning strategy %s", strategy)
+	}
+}
@@ -0,0 +1,156 @@
+package streaming
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ColorPlatform/prism/crypto/tmhash"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+// maxRecordSize bounds the size of a record read back from a block file
+const maxRecordSize = 1 << 30
+
+var cdc = codec.New()
+
+// Record is the write set of a delivered tx, or of a whole block once
+// committed. TxHash is empty for the commit record.
+type Record struct {
+	Height int64               `json:"height"`
+	Commit bool                `json:"commit"`
+	TxHash []byte              `json:"tx_hash"`
+	Pairs  []types.StoreKVPair `json:"pairs"`
+}
+
+// FileSink is a StateListener writing the records of each block to a file of
+// its own in a directory, once the block is committed. The file holds the
+// length-prefixed records of the txs of the block in order, followed by the
+// commit record.
+type FileSink struct {
+	dir string
+
+	mtx     sync.Mutex
+	height  int64
+	records []Record
+}
+
+var _ types.StateListener = (*FileSink)(nil)
+
+// NewFileSink returns a sink writing the block files to the given directory.
+func NewFileSink(dir string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create streaming directory: %v", err)
+	}
+	return &FileSink{dir: dir}, nil
+}
+
+// ListenDeliverTx implements StateListener.
+func (fs *FileSink) ListenDeliverTx(height int64, tx []byte, writes []types.StoreKVPair) error {
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+
+	// drop the records of a block which was never committed
+	if height != fs.height {
+		fs.height = height
+		fs.records = nil
+	}
+	fs.records = append(fs.records, Record{
+		Height: height,
+		TxHash: tmhash.Sum(tx),
+		Pairs:  writes,
+	})
+	return nil
+}
+
+// ListenCommit implements StateListener.
+func (fs *FileSink) ListenCommit(version int64, writes []types.StoreKVPair) error {
+	fs.mtx.Lock()
+	defer fs.mtx.Unlock()
+
+	records := fs.records
+	if fs.height != version {
+		records = nil
+	}
+	fs.height = 0
+	fs.records = nil
+
+	records = append(records, Record{
+		Height: version,
+		Commit: true,
+		Pairs:  writes,
+	})
+	return fs.writeBlock(version, records)
+}
+
+// writeBlock writes the records of a block to a temporary file, and moves it
+// in place once complete.
+func (fs *FileSink) writeBlock(height int64, records []Record) error {
+	path := BlockFile(fs.dir, height)
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create block file: %v", err)
+	}
+	w := bufio.NewWriter(file)
+	for _, record := range records {
+		bz, err := cdc.MarshalBinaryLengthPrefixed(record)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := w.Write(bz); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write block file: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write block file: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write block file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write block file: %v", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// BlockFile returns the path of the file of the block at the given height.
+func BlockFile(dir string, height int64) string {
+	return filepath.Join(dir, fmt.Sprintf("block-%d", height))
+}
+
+// ReadBlock reads back the records of the block at the given height from the
+// directory of a FileSink.
+func ReadBlock(dir string, height int64) ([]Record, error) {
+	file, err := os.Open(BlockFile(dir, height))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRecords(file)
+}
+
+// ReadRecords reads length-prefixed records until the end of r.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	br := bufio.NewReader(r)
+	var records []Record
+	for {
+		if _, err := br.Peek(1); err == io.EOF {
+			return records, nil
+		}
+		var record Record
+		if _, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &record, maxRecordSize); err != nil {
+			return nil, fmt.Errorf("failed to read record: %v", err)
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,49 @@
+package streaming
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/prism/crypto/tmhash"
+
+	"github.com/ColorPlatform/color-sdk/store/types"
+)
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sink, err := NewFileSink(dir)
+	require.NoError(t, err)
+
+	txWrites := []types.StoreKVPair{{StoreKey: "store1", Key: []byte("a"), Value: []byte("1")}}
+	commitWrites := []types.StoreKVPair{
+		{StoreKey: "store1", Key: []byte("a"), Value: []byte("1")},
+		{StoreKey: "store2", Delete: true, Key: []byte("b")},
+	}
+
+	// the txs of a block which isn't committed are dropped
+	require.NoError(t, sink.ListenDeliverTx(1, []byte("dropped"), txWrites))
+	require.NoError(t, sink.ListenDeliverTx(2, []byte("tx1"), txWrites))
+	require.NoError(t, sink.ListenDeliverTx(2, []byte("tx2"), nil))
+	_, err = ReadBlock(dir, 2)
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, sink.ListenCommit(2, commitWrites))
+
+	records, err := ReadBlock(dir, 2)
+	require.NoError(t, err)
+	require.Equal(t, []Record{
+		{Height: 2, TxHash: tmhash.Sum([]byte("tx1")), Pairs: txWrites},
+		{Height: 2, TxHash: tmhash.Sum([]byte("tx2"))},
+		{Height: 2, Commit: true, Pairs: commitWrites},
+	}, records)
+
+	// a block without txs holds only its commit record
+	require.NoError(t, sink.ListenCommit(3, nil))
+	records, err = ReadBlock(dir, 3)
+	require.NoError(t, err)
+	require.Equal(t, []Record{{Height: 3, Commit: true}}, records)
+}
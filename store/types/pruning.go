@@ -1,5 +1,15 @@
 package types
 
+import "fmt"
+
+// pruning strategies, set by name
+const (
+	PruningStrategyNothing    = "nothing"
+	PruningStrategyEverything = "everything"
+	PruningStrategySyncable   = "syncable"
+	PruningStrategyCustom     = "custom"
+)
+
 // PruningStrategy specifies how old states will be deleted over time where
 // keepRecent can be used with keepEvery to create a pruning "strategy".
 type PruningOptions struct {
@@ -24,6 +34,26 @@ func (po PruningOptions) KeepEvery() int64 {
 	return po.keepEvery
 }
 
+// Validate checks the numbers of states to keep aren't negative.
+func (po PruningOptions) Validate() error {
+	if po.keepRecent < 0 {
+		return fmt.Errorf("number of recent states to keep must not be negative, got %d", po.keepRecent)
+	}
+	if po.keepEvery < 0 {
+		return fmt.Errorf("interval of the states to keep must not be negative, got %d", po.keepEvery)
+	}
+	return nil
+}
+
+// ShouldPrune returns true if the state of the given version is deleted once
+// the latest version is committed.
+func (po PruningOptions) ShouldPrune(version, latest int64) bool {
+	if version >= latest-po.keepRecent {
+		return false
+	}
+	return po.keepEvery == 0 || version%po.keepEvery != 0
+}
+
 // default pruning strategies
 var (
 	// PruneEverything means all saved states will be deleted, storing only the current state
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruningOptionsValidate(t *testing.T) {
+	require.NoError(t, PruneSyncable.Validate())
+	require.NoError(t, PruneNothing.Validate())
+	require.NoError(t, PruneEverything.Validate())
+	require.NoError(t, NewPruningOptions(7, 0).Validate())
+	require.Error(t, NewPruningOptions(-1, 10).Validate())
+	require.Error(t, NewPruningOptions(10, -1).Validate())
+}
+
+func TestPruningOptionsShouldPrune(t *testing.T) {
+	tests := []struct {
+		opt         PruningOptions
+		version     int64
+		latest      int64
+		shouldPrune bool
+	}{
+		{PruneNothing, 1, 100, false},
+		{PruneEverything, 99, 100, true},
+		{PruneEverything, 100, 100, false},
+		{NewPruningOptions(5, 3), 1, 7, true},
+		{NewPruningOptions(5, 3), 2, 7, false},
+		{NewPruningOptions(5, 3), 3, 100, false},
+		{NewPruningOptions(5, 3), 94, 100, true},
+		{NewPruningOptions(5, 3), 95, 100, false},
+		{NewPruningOptions(5, 0), 94, 100, true},
+	}
+	for i, tc := range tests {
+		require.Equal(t, tc.shouldPrune, tc.opt.ShouldPrune(tc.version, tc.latest), "test %d", i)
+	}
+}
@@ -35,6 +35,107 @@ type Queryable interface {
 	Query(abci.RequestQuery) abci.ResponseQuery
 }
 
+// Snapshotter allows a CommitMultiStore to write its state at a version to a
+// snapshot, and to restore a fresh store from such a snapshot.
+//
+// This is an optional extension to any CommitMultiStore
+type Snapshotter interface {
+	// Snapshot writes the state at the given version to w, and returns the
+	// app hash of the version.
+	Snapshot(version int64, w io.Writer) (appHash []byte, err error)
+
+	// Restore loads the state of the given version from a snapshot, after
+	// checking it hashes to the given app hash.
+	Restore(version int64, appHash []byte, r io.Reader) error
+
+	// PinVersion keeps pruning from deleting the given version until it is
+	// unpinned, e.g. while it is snapshotted. Pins are counted, so each call
+	// must be matched by a call to UnpinVersion.
+	PinVersion(version int64)
+
+	// UnpinVersion releases a pin of the given version. Once unpinned, the
+	// version is pruned on a later commit if the pruning options don't keep it.
+	UnpinVersion(version int64)
+}
+
+// Pruner allows a CommitMultiStore to delete the old versions its pruning
+// options don't keep, e.g. after the options changed.
+//
+// This is an optional extension to any CommitMultiStore
+type Pruner interface {
+	// Prune deletes the versions of the loaded stores that the pruning options
+	// don't keep, and returns the number of versions deleted in each store.
+	Prune() (map[string]int, error)
+}
+
+// StoreRename renames a store during an upgrade.
+type StoreRename struct {
+	OldKey string `json:"old_key"`
+	NewKey string `json:"new_key"`
+}
+
+// StoreUpgrades lists the stores added, renamed and deleted by an upgrade, by
+// name. Added stores start empty, renamed stores keep their data under their
+// new name, and deleted stores drop out of the state.
+type StoreUpgrades struct {
+	Added   []string      `json:"added"`
+	Renamed []StoreRename `json:"renamed"`
+	Deleted []string      `json:"deleted"`
+}
+
+// StoreUpgrader allows a CommitMultiStore to load a version committed with a
+// different set of mounted stores, upgrading its stores on the way.
+//
+// This is an optional extension to any CommitMultiStore
+type StoreUpgrader interface {
+	// GetLatestVersion returns the latest committed version, without loading it.
+	GetLatestVersion() int64
+
+	// LoadVersionAndUpgrade loads the given version and applies the store
+	// upgrades, which take effect on the next commit. It returns the number of
+	// keys moved into each renamed store and dropped with each deleted store.
+	LoadVersionAndUpgrade(ver int64, upgrades *StoreUpgrades) (map[string]int, error)
+}
+
+// StoreKVPair is a write to a KVStore of a multistore: a key set to a value,
+// or deleted.
+type StoreKVPair struct {
+	StoreKey string `json:"store_key"`
+	Delete   bool   `json:"delete"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value"`
+}
+
+// WriteListener is notified of the writes to the KVStores it listens to.
+type WriteListener interface {
+	// OnWrite is called when a key is set to a value, or deleted.
+	OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool)
+}
+
+// StateListener receives the writes to the state of a CommitMultiStore,
+// grouped by store in order of name and in the order they were made within a
+// store. An error halts the node before the block is committed, so that the
+// block is replayed when the node restarts.
+type StateListener interface {
+	// ListenDeliverTx receives the writes of a transaction delivered in the
+	// block at the given height.
+	ListenDeliverTx(height int64, tx []byte, writes []StoreKVPair) error
+
+	// ListenCommit receives the writes of the block committed as the given
+	// version, before the version is persisted.
+	ListenCommit(version int64, writes []StoreKVPair) error
+}
+
+// ListeningMultiStore allows a MultiStore to notify a listener of the writes
+// made to it through a cache.
+//
+// This is an optional extension to any MultiStore
+type ListeningMultiStore interface {
+	// CacheMultiStoreWithListener returns a cache of the multistore which
+	// notifies the listener of the writes it makes when it is written.
+	CacheMultiStoreWithListener(listener WriteListener) CacheMultiStore
+}
+
 //----------------------------------------
 // MultiStore
 
@@ -95,6 +196,13 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// AddListener adds a listener of the writes to the state, which receives
+	// the writes of each version when it is committed.
+	AddListener(listener StateListener)
+
+	// ListeningEnabled returns if the writes to the state are listened to.
+	ListeningEnabled() bool
 }
 
 //---------subsp-------------------------------
@@ -17,6 +17,14 @@ type (
 	Committer        = types.Committer
 	CommitStore      = types.CommitStore
 	Queryable        = types.Queryable
+	Snapshotter      = types.Snapshotter
+	Pruner           = types.Pruner
+	StoreRename      = types.StoreRename
+	StoreUpgrades    = types.StoreUpgrades
+	StoreUpgrader    = types.StoreUpgrader
+	StoreKVPair      = types.StoreKVPair
+	WriteListener    = types.WriteListener
+	StateListener    = types.StateListener
 	MultiStore       = types.MultiStore
 	CacheMultiStore  = types.CacheMultiStore
 	CommitMultiStore = types.CommitMultiStore
@@ -24,6 +32,9 @@ type (
 	Iterator         = types.Iterator
 )
 
+// nolint - reexport
+type ListeningMultiStore = types.ListeningMultiStore
+
 // Iterator over all the keys with a certain prefix in ascending order
 func KVStorePrefixIterator(kvs KVStore, prefix []byte) Iterator {
 	return types.KVStorePrefixIterator(kvs, prefix)
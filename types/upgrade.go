@@ -0,0 +1,56 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UpgradeNeededError is raised (via panic) from a BeginBlocker when the chain
+// reached the height of a scheduled software upgrade that the running binary
+// does not know how to apply. BaseApp recovers it and halts the node before
+// the block is executed, so that the block can be replayed by the upgraded binary.
+type UpgradeNeededError struct {
+	Name   string // name of the upgrade plan
+	Height int64  // height at which the node halted
+	Info   string // plan info, e.g. where to find the new binary
+}
+
+func (e UpgradeNeededError) Error() string {
+	return fmt.Sprintf("UPGRADE %q NEEDED at height %d: %s", e.Name, e.Height, e.Info)
+}
+
+// UpgradeReport counts the keys changed when an upgrade was applied to the
+// state of the chain on load, or would be in a dry run.
+type UpgradeReport struct {
+	Name    string         `json:"name"`    // name of the upgrade
+	Height  int64          `json:"height"`  // height of the first block after the upgrade
+	DryRun  bool           `json:"dry_run"` // whether the changes were discarded
+	Stores  map[string]int `json:"stores"`  // keys moved into each renamed store or dropped with each deleted store
+	Modules map[string]int `json:"modules"` // keys migrated by each module
+}
+
+func (r UpgradeReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Upgrade %s at height %d", r.Name, r.Height)
+	if r.DryRun {
+		b.WriteString(" (dry run)")
+	}
+	b.WriteString(":")
+	for _, name := range sortedKeys(r.Stores) {
+		fmt.Fprintf(&b, "\n  store %s: %d keys", name, r.Stores[name])
+	}
+	for _, name := range sortedKeys(r.Modules) {
+		fmt.Fprintf(&b, "\n  module %s: %d keys migrated", name, r.Modules[name])
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,26 @@
+package crisis
+
+import (
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// NewAnteHandler wraps the ante handler of the application to refuse the
+// transactions of a halted chain, except those whose messages all go to the
+// crisis module or to one of the given routes
+func NewAnteHandler(k Keeper, ante sdk.AnteHandler, allowedRoutes ...string) sdk.AnteHandler {
+	allowed := map[string]bool{RouterKey: true}
+	for _, route := range allowedRoutes {
+		allowed[route] = true
+	}
+
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		if halt := k.GetHalt(ctx); halt.IsHalted() {
+			for _, msg := range tx.GetMsgs() {
+				if !allowed[msg.Route()] {
+					return ctx, ErrChainHalted(DefaultCodespace, halt).Result(), true
+				}
+			}
+		}
+		return ante(ctx, tx, simulate)
+	}
+}
@@ -0,0 +1,61 @@
+package crisis
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// TagBrokenInvariant tags the blocks whose periodic checks found an invariant broken
+const TagBrokenInvariant = "broken-invariant"
+
+// EndBlocker runs the invariants every InvCheckPeriod blocks and applies the
+// policy of the broken ones
+func EndBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
+	period := k.GetInvCheckPeriod(ctx)
+	if period == 0 || ctx.BlockHeight()%int64(period) != 0 {
+		return nil
+	}
+
+	logger := ctx.Logger().With("module", "x/crisis")
+	policies := k.GetInvariantPolicies(ctx)
+	resTags := sdk.NewTags()
+
+	for _, broken := range k.BrokenInvariants(ctx) {
+		route := broken.Route.FullRoute()
+		policy := policies.PolicyOf(route)
+		logger.Error("Invariant broken", "invariant", route, "err", broken.Err, "policy", policy)
+
+		switch policy {
+		case PolicyHaltNode:
+			panic(fmt.Errorf("invariant %s broken at height %d: %s", route, ctx.BlockHeight(), broken.Err))
+
+		case PolicyHaltChain:
+			// the first broken invariant halts the chain
+			if !k.GetHalt(ctx).IsHalted() {
+				k.SetHalt(ctx, Halt{Route: route, Height: ctx.BlockHeight(), Reason: broken.Err.Error()})
+				logger.Error("Chain halted, only crisis and governance transactions are accepted", "invariant", route)
+			}
+		}
+		resTags = resTags.AppendTag(TagBrokenInvariant, route)
+	}
+	return resTags
+}
+
+// AssertInvariants runs the invariants for this node alone, outside of the
+// consensus: nothing is written to the state and the chain is never halted.
+// Any broken invariant stops the node, whatever its policy.
+func AssertInvariants(ctx sdk.Context, k Keeper) {
+	logger := ctx.Logger().With("module", "invariants")
+	start := time.Now()
+
+	for _, broken := range k.BrokenInvariants(ctx) {
+		ir := broken.Route
+		panic(fmt.Errorf("invariant broken: %s\n"+
+			"\tCRITICAL please submit the following transaction:\n"+
+			"\t\t colorcli tx crisis invariant-broken %v %v", broken.Err, ir.ModuleName, ir.Route))
+	}
+
+	logger.Info("Asserted all invariants", "duration", time.Since(start), "height", ctx.BlockHeight())
+}
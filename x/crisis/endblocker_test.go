@@ -0,0 +1,111 @@
+package crisis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/auth"
+	"github.com/ColorPlatform/color-sdk/x/bank"
+)
+
+func TestEndBlocker(t *testing.T) {
+	ctx, crisisKeeper, _, _ := CreateTestInput(t)
+	failingRoute := dummyRouteWhichFails.FullRoute()
+
+	// the checks are disabled by default
+	require.Nil(t, EndBlocker(ctx.WithBlockHeight(4), crisisKeeper))
+
+	// the checks only run every period
+	crisisKeeper.SetInvCheckPeriod(ctx, 2)
+	require.Nil(t, EndBlocker(ctx.WithBlockHeight(3), crisisKeeper))
+
+	// invariants without policy are logged
+	tags := EndBlocker(ctx.WithBlockHeight(4), crisisKeeper)
+	require.Equal(t, sdk.NewTags(TagBrokenInvariant, failingRoute), tags)
+	require.False(t, crisisKeeper.GetHalt(ctx).IsHalted())
+
+	// the chain halts once
+	crisisKeeper.SetInvariantPolicies(ctx, NewInvariantPolicies(PolicyLog, InvariantPolicy{failingRoute, PolicyHaltChain}))
+	tags = EndBlocker(ctx.WithBlockHeight(4), crisisKeeper)
+	require.Equal(t, sdk.NewTags(TagBrokenInvariant, failingRoute), tags)
+	halt := crisisKeeper.GetHalt(ctx)
+	require.Equal(t, Halt{Route: failingRoute, Height: 4, Reason: "whoops"}, halt)
+
+	EndBlocker(ctx.WithBlockHeight(6), crisisKeeper)
+	require.Equal(t, halt, crisisKeeper.GetHalt(ctx))
+
+	// the nodes stop
+	crisisKeeper.SetInvariantPolicies(ctx, NewInvariantPolicies(PolicyHaltNode))
+	require.Panics(t, func() { EndBlocker(ctx.WithBlockHeight(8), crisisKeeper) })
+}
+
+func TestAssertInvariants(t *testing.T) {
+	ctx, crisisKeeper, _, _ := CreateTestInput(t)
+
+	// the node-local checks never halt the chain
+	crisisKeeper.SetInvariantPolicies(ctx, NewInvariantPolicies(PolicyHaltChain))
+	require.Panics(t, func() { AssertInvariants(ctx, crisisKeeper) })
+	require.False(t, crisisKeeper.GetHalt(ctx).IsHalted())
+
+	// nor follow the policies of the consensus checks
+	crisisKeeper.SetInvariantPolicies(ctx, NewInvariantPolicies(PolicyLog))
+	require.Panics(t, func() { AssertInvariants(ctx, crisisKeeper) })
+}
+
+func TestHaltedChain(t *testing.T) {
+	ctx, crisisKeeper, _, _ := CreateTestInput(t)
+	crisisKeeper.SetConstantFee(ctx, sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000))
+	sender := addrs[0]
+
+	ante := NewAnteHandler(crisisKeeper, func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, sdk.Result, bool) {
+		return ctx, sdk.Result{}, false
+	}, "gov")
+	sendTx := auth.NewStdTx([]sdk.Msg{bank.NewMsgSend(sender, addrs[1], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))},
+		auth.StdFee{}, nil, "")
+	verifyMsg := NewMsgVerifyInvariant(sender, testModuleName, dummyRouteWhichPasses.Route)
+	verifyTx := auth.NewStdTx([]sdk.Msg{verifyMsg}, auth.StdFee{}, nil, "")
+
+	_, res, abort := ante(ctx, sendTx, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	// a halted chain only accepts the crisis and governance transactions
+	crisisKeeper.SetHalt(ctx, Halt{Route: dummyRouteWhichPasses.FullRoute(), Height: 2, Reason: "whoops"})
+	_, res, abort = ante(ctx, sendTx, false)
+	require.True(t, abort)
+	require.Equal(t, CodeChainHalted, res.Code)
+	_, res, abort = ante(ctx, verifyTx, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	// the chain resumes once the invariant which halted it holds
+	res = handleMsgVerifyInvariant(ctx, verifyMsg, crisisKeeper)
+	require.True(t, res.IsOK(), res.Log)
+	require.False(t, crisisKeeper.GetHalt(ctx).IsHalted())
+	_, res, abort = ante(ctx, sendTx, false)
+	require.False(t, abort)
+}
+
+func TestInvariantPolicies(t *testing.T) {
+	policies := NewInvariantPolicies("", InvariantPolicy{"bank/nonnegative-outstanding", PolicyHaltChain})
+	require.NoError(t, policies.Validate())
+	require.Equal(t, PolicyLog, policies.PolicyOf("supply/total"))
+	require.Equal(t, PolicyHaltChain, policies.PolicyOf("bank/nonnegative-outstanding"))
+
+	policies.Default = PolicyHaltNode
+	require.Equal(t, PolicyHaltNode, policies.PolicyOf("supply/total"))
+
+	policies.Default = "panic"
+	require.Error(t, policies.Validate())
+
+	policies = NewInvariantPolicies(PolicyLog,
+		InvariantPolicy{"supply/total", PolicyLog}, InvariantPolicy{"supply/total", PolicyHaltNode})
+	require.Error(t, policies.Validate())
+
+	genesis := DefaultGenesisState()
+	require.NoError(t, ValidateGenesis(genesis))
+	genesis.InvariantPolicies.Routes = []InvariantPolicy{{"supply/total", "halt"}}
+	require.Error(t, ValidateGenesis(genesis))
+}
@@ -10,6 +10,9 @@ const (
 
 	// CodeInvalidInput is the codetype for invalid input for the crisis module
 	CodeInvalidInput sdk.CodeType = 103
+
+	// CodeChainHalted is the codetype for transactions refused by a halted chain
+	CodeChainHalted sdk.CodeType = 104
 )
 
 // ErrNilSender -  no sender provided for the input
@@ -21,3 +24,8 @@ func ErrNilSender(codespace sdk.CodespaceType) sdk.Error {
 func ErrUnknownInvariant(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "unknown invariant")
 }
+
+// ErrChainHalted - the chain is halted by a broken invariant
+func ErrChainHalted(codespace sdk.CodespaceType, halt Halt) sdk.Error {
+	return sdk.NewError(codespace, CodeChainHalted, "chain halted, "+halt.String())
+}
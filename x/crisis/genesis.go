@@ -1,40 +1,57 @@
 package crisis
 
 import (
+	"fmt"
+
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
 // GenesisState - crisis genesis state
 type GenesisState struct {
-	ConstantFee sdk.Coin `json:"constant_fee"`
+	ConstantFee       sdk.Coin          `json:"constant_fee"`
+	InvCheckPeriod    uint64            `json:"inv_check_period"`   // blocks between the invariant checks, 0 to disable them
+	InvariantPolicies InvariantPolicies `json:"invariant_policies"` // policies of the broken invariants
+	Halt              Halt              `json:"halt"`               // broken invariant halting the chain, if any
 }
 
 // NewGenesisState creates a new GenesisState object
-func NewGenesisState(constantFee sdk.Coin) GenesisState {
+func NewGenesisState(constantFee sdk.Coin, invCheckPeriod uint64, policies InvariantPolicies, halt Halt) GenesisState {
 	return GenesisState{
-		ConstantFee: constantFee,
+		ConstantFee:       constantFee,
+		InvCheckPeriod:    invCheckPeriod,
+		InvariantPolicies: policies,
+		Halt:              halt,
 	}
 }
 
 // DefaultGenesisState creates a default GenesisState object
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		ConstantFee: sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)),
+		ConstantFee:       sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(1000)),
+		InvCheckPeriod:    0,
+		InvariantPolicies: NewInvariantPolicies(PolicyLog),
 	}
 }
 
 // new crisis genesis
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	keeper.SetConstantFee(ctx, data.ConstantFee)
+	keeper.SetInvCheckPeriod(ctx, data.InvCheckPeriod)
+	keeper.SetInvariantPolicies(ctx, data.InvariantPolicies)
+	keeper.SetHalt(ctx, data.Halt)
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
 	constantFee := keeper.GetConstantFee(ctx)
-	return NewGenesisState(constantFee)
+	return NewGenesisState(constantFee, keeper.GetInvCheckPeriod(ctx),
+		keeper.GetInvariantPolicies(ctx), keeper.GetHalt(ctx))
 }
 
-// ValidateGenesis - placeholder function
+// ValidateGenesis checks the invariant policies
 func ValidateGenesis(data GenesisState) error {
+	if err := data.InvariantPolicies.Validate(); err != nil {
+		return fmt.Errorf("invalid crisis genesis: %s", err)
+	}
 	return nil
 }
@@ -74,6 +74,12 @@ func handleMsgVerifyInvariant(ctx sdk.Context, msg MsgVerifyInvariant, k Keeper)
 		"sender", msg.Sender.String(),
 		"invariant", msg.InvariantRoute,
 	)
+
+	// the invariant which halted the chain holds again, the chain resumes
+	if halt := k.GetHalt(ctx); halt.IsHalted() && halt.Route == msgFullRoute {
+		k.SetHalt(ctx, Halt{})
+		tags = tags.AppendTag("resumed", msgFullRoute)
+	}
 	return sdk.Result{
 		Tags: tags,
 	}
@@ -39,3 +39,23 @@ func (k *Keeper) RegisterRoute(moduleName, route string, invar sdk.Invariant) {
 func (k Keeper) Routes() []InvarRoute {
 	return k.routes
 }
+
+// BrokenInvariant is an invariant found broken and the error it returned
+type BrokenInvariant struct {
+	Route InvarRoute
+	Err   error
+}
+
+// BrokenInvariants runs every invariant and returns the broken ones. The
+// invariants run on a cached context, anything they write is discarded.
+func (k Keeper) BrokenInvariants(ctx sdk.Context) []BrokenInvariant {
+	cacheCtx, _ := ctx.CacheContext()
+
+	var broken []BrokenInvariant
+	for _, invarRoute := range k.routes {
+		if err := invarRoute.Invar(cacheCtx); err != nil {
+			broken = append(broken, BrokenInvariant{invarRoute, err})
+		}
+	}
+	return broken
+}
@@ -13,12 +13,21 @@ const (
 var (
 	// key for constant fee parameter
 	ParamStoreKeyConstantFee = []byte("ConstantFee")
+	// key for the number of blocks between the periodic invariant checks
+	ParamStoreKeyInvCheckPeriod = []byte("InvCheckPeriod")
+	// key for the policies of the broken invariants
+	ParamStoreKeyInvariantPolicies = []byte("InvariantPolicies")
+	// key for the halt of the chain, governance lifts it with a parameter change
+	ParamStoreKeyHalt = []byte("Halt")
 )
 
 // type declaration for parameters
 func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable(
 		ParamStoreKeyConstantFee, sdk.Coin{},
+		ParamStoreKeyInvCheckPeriod, uint64(0),
+		ParamStoreKeyInvariantPolicies, InvariantPolicies{},
+		ParamStoreKeyHalt, Halt{},
 	)
 }
 
@@ -32,3 +41,37 @@ func (k Keeper) GetConstantFee(ctx sdk.Context) (constantFee sdk.Coin) {
 func (k Keeper) SetConstantFee(ctx sdk.Context, constantFee sdk.Coin) {
 	k.paramSpace.Set(ctx, ParamStoreKeyConstantFee, constantFee)
 }
+
+// GetInvCheckPeriod returns the number of blocks between the periodic
+// invariant checks, 0 if they are disabled
+func (k Keeper) GetInvCheckPeriod(ctx sdk.Context) (period uint64) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyInvCheckPeriod, &period)
+	return
+}
+
+// SetInvCheckPeriod sets the number of blocks between the periodic invariant checks
+func (k Keeper) SetInvCheckPeriod(ctx sdk.Context, period uint64) {
+	k.paramSpace.Set(ctx, ParamStoreKeyInvCheckPeriod, period)
+}
+
+// GetInvariantPolicies returns the policies of the broken invariants
+func (k Keeper) GetInvariantPolicies(ctx sdk.Context) (policies InvariantPolicies) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyInvariantPolicies, &policies)
+	return
+}
+
+// SetInvariantPolicies sets the policies of the broken invariants
+func (k Keeper) SetInvariantPolicies(ctx sdk.Context, policies InvariantPolicies) {
+	k.paramSpace.Set(ctx, ParamStoreKeyInvariantPolicies, policies)
+}
+
+// GetHalt returns the halt of the chain, the zero Halt if it is not halted
+func (k Keeper) GetHalt(ctx sdk.Context) (halt Halt) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyHalt, &halt)
+	return
+}
+
+// SetHalt halts the chain, or resumes it with the zero Halt
+func (k Keeper) SetHalt(ctx sdk.Context, halt Halt) {
+	k.paramSpace.Set(ctx, ParamStoreKeyHalt, halt)
+}
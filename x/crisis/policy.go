@@ -0,0 +1,99 @@
+package crisis
+
+import (
+	"fmt"
+)
+
+// Policy is the action taken when the periodic checks find an invariant broken
+type Policy string
+
+// invariant policies
+const (
+	// PolicyLog logs the broken invariant and tags the block
+	PolicyLog Policy = "log"
+	// PolicyHaltNode stops every node, the state is left as it is
+	PolicyHaltNode Policy = "halt_node"
+	// PolicyHaltChain records the broken invariant in the state, from which
+	// the chain only accepts crisis and governance transactions
+	PolicyHaltChain Policy = "halt_chain"
+)
+
+// ValidatePolicy checks that a policy is known
+func ValidatePolicy(policy Policy) error {
+	switch policy {
+	case PolicyLog, PolicyHaltNode, PolicyHaltChain:
+		return nil
+	default:
+		return fmt.Errorf("unknown invariant policy %q", policy)
+	}
+}
+
+// InvariantPolicy is the policy of a single invariant
+type InvariantPolicy struct {
+	Route  string `json:"route"` // full route of the invariant, module/route
+	Policy Policy `json:"policy"`
+}
+
+// InvariantPolicies are the policies applied to the invariants found broken
+// by the periodic checks
+type InvariantPolicies struct {
+	Default Policy            `json:"default"` // policy of the invariants without their own, log if empty
+	Routes  []InvariantPolicy `json:"routes"`
+}
+
+// NewInvariantPolicies creates a new InvariantPolicies object
+func NewInvariantPolicies(defaultPolicy Policy, routes ...InvariantPolicy) InvariantPolicies {
+	return InvariantPolicies{
+		Default: defaultPolicy,
+		Routes:  routes,
+	}
+}
+
+// Validate checks the policies, it is run on the parameter changes
+func (ip InvariantPolicies) Validate() error {
+	if ip.Default != "" {
+		if err := ValidatePolicy(ip.Default); err != nil {
+			return err
+		}
+	}
+	routes := make(map[string]bool)
+	for _, p := range ip.Routes {
+		if routes[p.Route] {
+			return fmt.Errorf("invariant %s has more than one policy", p.Route)
+		}
+		routes[p.Route] = true
+		if err := ValidatePolicy(p.Policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PolicyOf returns the policy of an invariant
+func (ip InvariantPolicies) PolicyOf(route string) Policy {
+	for _, p := range ip.Routes {
+		if p.Route == route {
+			return p.Policy
+		}
+	}
+	if ip.Default == "" {
+		return PolicyLog
+	}
+	return ip.Default
+}
+
+// Halt records the invariant whose breaking halted the chain
+type Halt struct {
+	Route  string `json:"route"`  // full route of the invariant
+	Height int64  `json:"height"` // height of the check finding it broken
+	Reason string `json:"reason"` // error returned by the invariant
+}
+
+// IsHalted returns true if the chain is halted
+func (h Halt) IsHalted() bool {
+	return h.Route != ""
+}
+
+func (h Halt) String() string {
+	return fmt.Sprintf("invariant %s broken at height %d: %s", h.Route, h.Height, h.Reason)
+}
@@ -22,3 +22,10 @@ func (k Keeper) DistributeFeePool(ctx sdk.Context, amount sdk.Coins, receiveAddr
 	k.SetFeePool(ctx, feePool)
 	return nil
 }
+
+// AddToCommunityPool adds to the community pool coins another module took out of the accounts
+func (k Keeper) AddToCommunityPool(ctx sdk.Context, amount sdk.Coins) {
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(sdk.NewDecCoins(amount))
+	k.SetFeePool(ctx, feePool)
+}
@@ -432,6 +432,10 @@ $ gaiacli query gov params
 			if err != nil {
 				return err
 			}
+			fp, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params/funding", queryRoute), nil)
+			if err != nil {
+				return err
+			}
 
 			var tallyParams gov.TallyParams
 			cdc.MustUnmarshalJSON(tp, &tallyParams)
@@ -439,8 +443,10 @@ $ gaiacli query gov params
 			cdc.MustUnmarshalJSON(dp, &depositParams)
 			var votingParams gov.VotingParams
 			cdc.MustUnmarshalJSON(vp, &votingParams)
+			var fundingParams gov.FundingParams
+			cdc.MustUnmarshalJSON(fp, &fundingParams)
 
-			return cliCtx.PrintOutput(gov.NewParams(votingParams, tallyParams, depositParams))
+			return cliCtx.PrintOutput(gov.NewParams(votingParams, tallyParams, depositParams, fundingParams))
 		},
 	}
 }
@@ -450,12 +456,13 @@ func GetCmdQueryParam(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "param [param-type]",
 		Args:  cobra.ExactArgs(1),
-		Short: "Query the parameters (voting|tallying|deposit) of the governance process",
+		Short: "Query the parameters (voting|tallying|deposit|funding) of the governance process",
 		Long: strings.TrimSpace(`Query the all the parameters for the governance process:
 
 $ gaiacli query gov param voting
 $ gaiacli query gov param tallying
 $ gaiacli query gov param deposit
+$ gaiacli query gov param funding
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -479,8 +486,12 @@ $ gaiacli query gov param deposit
 				var param gov.DepositParams
 				cdc.MustUnmarshalJSON(res, &param)
 				out = param
+			case "funding":
+				var param gov.FundingParams
+				cdc.MustUnmarshalJSON(res, &param)
+				out = param
 			default:
-				return fmt.Errorf("Argument must be one of (voting|tallying|deposit), was %s", args[0])
+				return fmt.Errorf("Argument must be one of (voting|tallying|deposit|funding), was %s", args[0])
 			}
 
 			return cliCtx.PrintOutput(out)
@@ -551,22 +562,126 @@ $ colorcli query gov fundingcycle 1
 	}
 }
 
-// GetCmdQueryProposals implements a query proposals command.
+// GetCmdQueryMilestones implements the command to query the milestones of a proposal.
+func GetCmdQueryMilestones(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "milestones [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the milestones of a proposal",
+		Long: strings.TrimSpace(`
+Query the milestones of a multi-cycle funding proposal along with their review status.
+
+Example:
+$ colorcli query gov milestones 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// validate that the proposal id is a uint
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("proposal-id %s not a valid uint, please input a valid proposal-id", args[0])
+			}
+
+			res, err := gcutils.QueryMilestonesByProposalID(proposalID, cliCtx, cdc, queryRoute)
+			if err != nil {
+				return err
+			}
+
+			var milestones gov.Milestones
+			cdc.MustUnmarshalJSON(res, &milestones)
+			return cliCtx.PrintOutput(milestones)
+		},
+	}
+}
+
+// GetCmdQueryVotingPower implements the command to query the council voting power on a proposal.
+func GetCmdQueryVotingPower(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "voting-power [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the power each council member votes with on a proposal",
+		Long: strings.TrimSpace(`
+Query the power of each council member on a proposal. Members who did not vote are
+counted with the vote of their proxy, and the effective power of a voter includes
+the power of the members it voted for.
+
+Example:
+$ colorcli query gov voting-power 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// validate that the proposal id is a uint
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("proposal-id %s not a valid uint, please input a valid proposal-id", args[0])
+			}
+
+			res, err := gcutils.QueryVotingPowerByProposalID(proposalID, cliCtx, cdc, queryRoute)
+			if err != nil {
+				return err
+			}
+
+			var powers gov.CouncilVoterPowers
+			cdc.MustUnmarshalJSON(res, &powers)
+			return cliCtx.PrintOutput(powers)
+		},
+	}
+}
+
+// GetCmdQueryRanking implements the command to query the funding ranking of a cycle.
+func GetCmdQueryRanking(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ranking [fundingcycle-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the proposal ranking of a funding cycle",
+		Long: strings.TrimSpace(`
+Query the proposal ranking snapshot taken at the end of a funding cycle. Proposals
+are ranked by net council votes (yes - no), then by turnout, submit time and
+proposal ID. The snapshot shows which proposals were funded.
+
+Example:
+$ colorcli query gov ranking 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// validate that the funding cycle id is a uint
+			fundingCycleID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("fundingcycle-id %s not a valid uint, please input a valid fundingcycle-id", args[0])
+			}
+
+			res, err := gcutils.QueryRankingByCycleID(fundingCycleID, cliCtx, cdc, queryRoute)
+			if err != nil {
+				return err
+			}
+
+			var ranking gov.Ranking
+			cdc.MustUnmarshalJSON(res, &ranking)
+			return cliCtx.PrintOutput(ranking)
+		},
+	}
+}
+
+// GetCmdQueryFundingCycles implements the command to query the funding cycles.
 func GetCmdQueryFundingCycles(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "fundingcycles",
 		Short: "Query fundingcycles",
 		Long: strings.TrimSpace(`
-Query for a all fundingcycles:
+Query for a all fundingcycles, optionally one page at a time:
 
 $ colorcli query gov fundingcycles
+$ colorcli query gov fundingcycles --page=2 --limit=10
 
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/fundingcycles", queryRoute), nil)
+			res, err := gcutils.QueryFundingCycles(viper.GetInt(flagPage), viper.GetInt(flagNumLimit), cliCtx, cdc, queryRoute)
 			if err != nil {
 				return err
 			}
@@ -585,6 +700,108 @@ $ colorcli query gov fundingcycles
 		},
 	}
 
+	cmd.Flags().Int(flagPage, 1, "(optional) page of funding cycles to return")
+	cmd.Flags().Int(flagNumLimit, 0, "(optional) number of funding cycles per page. Defaults to all funding cycles")
+
+	return cmd
+}
+
+// GetCmdQueryCurrentFundingCycle implements the command to query the running funding cycle.
+func GetCmdQueryCurrentFundingCycle(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "current-funding-cycle",
+		Args:  cobra.NoArgs,
+		Short: "Query the running funding cycle",
+		Long: strings.TrimSpace(`
+Query the running funding cycle, the time left until its end, whether it entered
+its freeze window and the budget it will distribute with the current treasury income.
+
+Example:
+$ colorcli query gov current-funding-cycle
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := gcutils.QueryCurrentFundingCycle(cliCtx, queryRoute)
+			if err != nil {
+				return err
+			}
+
+			var current gov.CurrentFundingCycle
+			cdc.MustUnmarshalJSON(res, &current)
+			return cliCtx.PrintOutput(current)
+		},
+	}
+}
+
+// GetCmdQueryFundingCycleReport implements the command to query the payouts of a funding cycle.
+func GetCmdQueryFundingCycleReport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "funding-cycle-report [fundingcycle-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the budget and payouts of a funding cycle",
+		Long: strings.TrimSpace(`
+Query the budget of a funding cycle against the amount it disbursed, along with
+the proposals it funded and the amount paid to each of them.
+
+Example:
+$ colorcli query gov funding-cycle-report 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// validate that the funding cycle id is a uint
+			fundingCycleID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("fundingcycle-id %s not a valid uint, please input a valid fundingcycle-id", args[0])
+			}
+
+			res, err := gcutils.QueryFundingCycleReportByID(fundingCycleID, cliCtx, cdc, queryRoute)
+			if err != nil {
+				return err
+			}
+
+			var report gov.FundingCycleReport
+			cdc.MustUnmarshalJSON(res, &report)
+			return cliCtx.PrintOutput(report)
+		},
+	}
+}
+
+// GetCmdQueryEligibility implements the command to query the proposals eligible for funding.
+func GetCmdQueryEligibility(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eligibility",
+		Args:  cobra.NoArgs,
+		Short: "Query the proposals eligible for funding in the running cycle",
+		Long: strings.TrimSpace(`
+Query the proposals in their voting period which would be funded if the running
+funding cycle ended now, ordered by ranking.
+
+Example:
+$ colorcli query gov eligibility
+$ colorcli query gov eligibility --page=1 --limit=10
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := gcutils.QueryEligibility(viper.GetInt(flagPage), viper.GetInt(flagNumLimit), cliCtx, cdc, queryRoute)
+			if err != nil {
+				return err
+			}
+
+			var eligible gov.RankedProposals
+			cdc.MustUnmarshalJSON(res, &eligible)
+			if len(eligible) == 0 {
+				return fmt.Errorf("No eligible proposal found")
+			}
+			return cliCtx.PrintOutput(eligible)
+		},
+	}
+
+	cmd.Flags().Int(flagPage, 1, "(optional) page of proposals to return")
+	cmd.Flags().Int(flagNumLimit, 0, "(optional) number of proposals per page. Defaults to all eligible proposals")
+
 	return cmd
 }
 
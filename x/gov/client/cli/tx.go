@@ -10,6 +10,7 @@ import (
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	authtxb "github.com/ColorPlatform/color-sdk/x/auth/client/txbuilder"
 	"github.com/ColorPlatform/color-sdk/x/gov"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 
 	"strings"
 
@@ -28,6 +29,7 @@ const (
 	flagDepositor     = "depositor"
 	flagStatus        = "status"
 	flagNumLimit      = "limit"
+	flagPage          = "page"
 	flagProposal      = "proposal"
 	flagRequestedFund = "fund"
 	flagCycle         = "cycle"
@@ -40,6 +42,9 @@ type proposal struct {
 	Deposit     string
 	Fund        string
 	Cycle       string
+	Changes     []gov.ParamChange
+	Plan        *upgrade.Plan
+	Milestones  []string
 }
 
 var proposalFlags = []string{
@@ -75,6 +80,44 @@ where proposal.json contains:
 is equivalent to
 
 $ colorcli gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" --deposit="10test" --fund="10test" --cycle=1 --from mykey
+
+Parameter change proposals request no funds and must be submitted through a proposal
+JSON file listing the changes, with each value JSON encoded as stored by the params subspace:
+
+{
+  "title": "Raise quorum",
+  "description": "Raise the council quorum to 20%",
+  "type": "ParameterChange",
+  "deposit": "10test",
+  "changes": [
+    {"subspace": "gov", "key": "tallyparams", "value": "{\"quorum\":\"0.2\",\"threshold\":\"0.05\"}"}
+  ]
+}
+
+Software upgrade proposals request no funds either and must be submitted through a proposal
+JSON file with the upgrade plan, giving either a target height or a target time:
+
+{
+  "title": "Upgrade to v2",
+  "description": "Switch to the v2 binary",
+  "type": "SoftwareUpgrade",
+  "deposit": "10test",
+  "plan": {"name": "v2", "height": 100000, "info": "https://example.com/v2"}
+}
+
+Text proposals funded over several cycles can attach one milestone per cycle after
+the first one through a proposal JSON file. Each following tranche is only paid once
+the council approved the report of the previous milestone:
+
+{
+  "title": "Block explorer",
+  "description": "Build a block explorer",
+  "type": "Text",
+  "deposit": "10test",
+  "fund": "10test",
+  "cycle": "3",
+  "milestones": ["Indexer released", "Web frontend released"]
+}
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proposal, err := parseSubmitProposalFlags()
@@ -101,17 +144,6 @@ $ colorcli gov submit-proposal --title="Test Proposal" --description="My awesome
 				return err
 			}
 
-			// Find Funding amount
-			fundingAmount, err := sdk.ParseCoins(proposal.Fund)
-			if err != nil {
-				return err
-			}
-
-			// Funding Cycle
-			cycle, err := strconv.ParseUint(proposal.Cycle, 10, 64)
-			if err != nil {
-				return err
-			}
 			// ensure account has enough coins
 			if !account.GetCoins().IsAllGTE(amount) {
 				return fmt.Errorf("address %s doesn't have enough coins to pay for this transaction", from)
@@ -121,7 +153,31 @@ $ colorcli gov submit-proposal --title="Test Proposal" --description="My awesome
 			if err != nil {
 				return err
 			}
-			msg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, from, amount, fundingAmount, cycle)
+
+			var msg gov.MsgSubmitProposal
+			switch proposalType {
+			case gov.ProposalTypeParameterChange:
+				msg = gov.NewMsgSubmitParameterChangeProposal(proposal.Title, proposal.Description, from, amount, proposal.Changes)
+			case gov.ProposalTypeSoftwareUpgrade:
+				if proposal.Plan == nil {
+					return fmt.Errorf("software upgrade proposals must be submitted with a plan through --%s", flagProposal)
+				}
+				msg = gov.NewMsgSubmitSoftwareUpgradeProposal(proposal.Title, proposal.Description, from, amount, *proposal.Plan)
+			default:
+				// Find Funding amount
+				fundingAmount, err := sdk.ParseCoins(proposal.Fund)
+				if err != nil {
+					return err
+				}
+
+				// Funding Cycle
+				cycle, err := strconv.ParseUint(proposal.Cycle, 10, 64)
+				if err != nil {
+					return err
+				}
+				msg = gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, from, amount, fundingAmount, cycle)
+				msg.Milestones = proposal.Milestones
+			}
 
 			err = msg.ValidateBasic()
 			if err != nil {
@@ -252,4 +308,151 @@ $ gaiacli tx gov vote 1 yes --from mykey
 	}
 }
 
+// GetCmdReportMilestone implements reporting the current milestone of a proposal.
+func GetCmdReportMilestone(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "report-milestone [proposal-id] [report-hash]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Report the current milestone of a funded proposal",
+		Long: strings.TrimSpace(`
+Submit the hex encoded hash of the report of the current milestone of your proposal.
+The council then votes on the milestone to release the next tranche:
+
+$ colorcli tx gov report-milestone 1 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from := cliCtx.GetFromAddress()
+
+			// validate that the proposal id is a uint
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("proposal-id %s not a valid int, please input a valid proposal-id", args[0])
+			}
+
+			// check to see if the proposal is in the store
+			_, err = govClientUtils.QueryProposalByID(proposalID, cliCtx, cdc, queryRoute)
+			if err != nil {
+				return fmt.Errorf("Failed to fetch proposal-id %d: %s", proposalID, err)
+			}
+
+			msg := gov.NewMsgReportMilestone(from, proposalID, args[1])
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
+
+// GetCmdVoteMilestone implements a council vote on the reported milestone of a proposal.
+func GetCmdVoteMilestone(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vote-milestone [proposal-id] [option]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Vote on the reported milestone of a proposal, options: yes/no/abstain",
+		Long: strings.TrimSpace(`
+Submit a council vote on the reported milestone of a proposal. Yes releases the next
+tranche, no stops the funding of the proposal:
+
+$ colorcli tx gov vote-milestone 1 yes --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from := cliCtx.GetFromAddress()
+
+			// validate that the proposal id is a uint
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("proposal-id %s not a valid int, please input a valid proposal-id", args[0])
+			}
+
+			byteVoteOption, err := gov.VoteOptionFromString(govClientUtils.NormalizeVoteOption(args[1]))
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgVoteMilestone(from, proposalID, byteVoteOption)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
+
+// GetCmdDelegateCouncilVote implements the command to delegate the vote of a council member.
+func GetCmdDelegateCouncilVote(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delegate-vote [proxy-addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Let another council member vote for you on the proposals you do not vote on",
+		Long: strings.TrimSpace(`
+Designate another council member as proxy. The proxy's vote is used for the proposals
+and milestones you do not vote on directly:
+
+$ colorcli tx gov delegate-vote color1gghjut3ccd8ay0zduzj64hwre2fxs9ldmqhffj --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			proxy, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgDelegateCouncilVote(cliCtx.GetFromAddress(), proxy)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
+
+// GetCmdRevokeCouncilVote implements the command to revoke the proxy of a council member.
+func GetCmdRevokeCouncilVote(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-vote",
+		Args:  cobra.NoArgs,
+		Short: "Revoke the proxy voting for you",
+		Long: strings.TrimSpace(`
+Revoke the council member voting for you, your vote is then only counted when you vote:
+
+$ colorcli tx gov revoke-vote --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			msg := gov.NewMsgRevokeCouncilVote(cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, false)
+		},
+	}
+}
+
 // DONTCOVER
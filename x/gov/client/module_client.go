@@ -39,6 +39,12 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 		govCli.GetCmdQueryDeposits(mc.storeKey, mc.cdc),
 		govCli.GetCmdQueryFundingCycle(mc.storeKey, mc.cdc),
 		govCli.GetCmdQueryFundingCycles(mc.storeKey, mc.cdc),
+		govCli.GetCmdQueryCurrentFundingCycle(mc.storeKey, mc.cdc),
+		govCli.GetCmdQueryFundingCycleReport(mc.storeKey, mc.cdc),
+		govCli.GetCmdQueryEligibility(mc.storeKey, mc.cdc),
+		govCli.GetCmdQueryRanking(mc.storeKey, mc.cdc),
+		govCli.GetCmdQueryMilestones(mc.storeKey, mc.cdc),
+		govCli.GetCmdQueryVotingPower(mc.storeKey, mc.cdc),
 		govCli.GetCmdQueryTally(mc.storeKey, mc.cdc))...)
 
 	return govQueryCmd
@@ -54,6 +60,10 @@ func (mc ModuleClient) GetTxCmd() *cobra.Command {
 	govTxCmd.AddCommand(client.PostCommands(
 		govCli.GetCmdDeposit(mc.storeKey, mc.cdc),
 		govCli.GetCmdVote(mc.storeKey, mc.cdc),
+		govCli.GetCmdReportMilestone(mc.storeKey, mc.cdc),
+		govCli.GetCmdVoteMilestone(mc.storeKey, mc.cdc),
+		govCli.GetCmdDelegateCouncilVote(mc.cdc),
+		govCli.GetCmdRevokeCouncilVote(mc.cdc),
 		govCli.GetCmdSubmitProposal(mc.cdc),
 	)...)
 
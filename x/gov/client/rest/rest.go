@@ -15,6 +15,7 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/gov"
 	gcutils "github.com/ColorPlatform/color-sdk/x/gov/client/utils"
 	govClientUtils "github.com/ColorPlatform/color-sdk/x/gov/client/utils"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 // REST Variable names
@@ -34,6 +35,10 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 	r.HandleFunc("/gov/proposals", postProposalHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), depositHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), voteHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/milestones/report", RestProposalID), reportMilestoneHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/milestones/votes", RestProposalID), voteMilestoneHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/gov/council/proxy", delegateCouncilVoteHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/gov/council/proxy/revoke", revokeCouncilVoteHandlerFn(cdc, cliCtx)).Methods("POST")
 
 	r.HandleFunc(
 		fmt.Sprintf("/gov/parameters/{%s}", RestParamsType),
@@ -49,23 +54,32 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), queryDepositsHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositor), queryDepositHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/tally", RestProposalID), queryTallyOnProposalHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/milestones", RestProposalID), queryMilestonesHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/voting_power", RestProposalID), queryVotingPowerHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), queryVotesOnProposalHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes/{%s}", RestProposalID, RestVoter), queryVoteHandlerFn(cdc, cliCtx)).Methods("GET")
 
 	r.HandleFunc("/gov/fundingcycles", queryFundingCycles(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/gov/fundingcycles/current", queryCurrentFundingCycleHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/fundingcycles/{%s}", RestFundingCycleID), queryFudningCycleHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/fundingcycles/{%s}/ranking", RestFundingCycleID), queryRankingHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/fundingcycles/{%s}/report", RestFundingCycleID), queryFundingCycleReportHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/gov/eligibility", queryEligibilityHandlerFn(cdc, cliCtx)).Methods("GET")
 }
 
 // PostProposalReq defines the properties of a proposal request's body.
 type PostProposalReq struct {
-	BaseReq        rest.BaseReq   `json:"base_req"`
-	Title          string         `json:"title"`           // Title of the proposal
-	Description    string         `json:"description"`     // Description of the proposal
-	ProposalType   string         `json:"proposal_type"`   // Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        // Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` // Coins to add to the proposal's deposit
-	RequestedFund  sdk.Coins      `json:"requested_fund"`  // Coins to add to the proposal's deposit
-	FundingCycle   uint64         `json:"funding_cycle"`   /// Funding Cycle
+	BaseReq        rest.BaseReq      `json:"base_req"`
+	Title          string            `json:"title"`           // Title of the proposal
+	Description    string            `json:"description"`     // Description of the proposal
+	ProposalType   string            `json:"proposal_type"`   // Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress    `json:"proposer"`        // Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	RequestedFund  sdk.Coins         `json:"requested_fund"`  // Coins to add to the proposal's deposit
+	FundingCycle   uint64            `json:"funding_cycle"`   /// Funding Cycle
+	Changes        []gov.ParamChange `json:"changes"`         // Parameter changes of a ParameterChange proposal
+	Plan           *upgrade.Plan     `json:"plan"`            // Upgrade plan of a SoftwareUpgrade proposal
+	Milestones     []string          `json:"milestones"`      // Milestones gating the tranches after the first one
 }
 
 // DepositReq defines the properties of a deposit request's body.
@@ -82,6 +96,20 @@ type VoteReq struct {
 	Option  string         `json:"option"` // option from OptionSet chosen by the voter
 }
 
+// ReportMilestoneReq defines the properties of a milestone report request's body.
+type ReportMilestoneReq struct {
+	BaseReq    rest.BaseReq   `json:"base_req"`
+	Proposer   sdk.AccAddress `json:"proposer"`    // address of the proposer
+	ReportHash string         `json:"report_hash"` // hex encoded hash of the milestone report
+}
+
+// CouncilProxyReq defines the properties of a council vote delegation request's body.
+type CouncilProxyReq struct {
+	BaseReq   rest.BaseReq   `json:"base_req"`
+	Delegator sdk.AccAddress `json:"delegator"` // address of the council member delegating its vote
+	Proxy     sdk.AccAddress `json:"proxy"`     // address of the council member voting in its place, ignored on revocation
+}
+
 func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req PostProposalReq
@@ -102,6 +130,17 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalType, req.Proposer, req.InitialDeposit, req.RequestedFund, req.FundingCycle)
+		msg.Milestones = req.Milestones
+		switch proposalType {
+		case gov.ProposalTypeParameterChange:
+			msg = gov.NewMsgSubmitParameterChangeProposal(req.Title, req.Description, req.Proposer, req.InitialDeposit, req.Changes)
+		case gov.ProposalTypeSoftwareUpgrade:
+			if req.Plan == nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "software upgrade proposals require an upgrade plan")
+				return
+			}
+			msg = gov.NewMsgSubmitSoftwareUpgradeProposal(req.Title, req.Description, req.Proposer, req.InitialDeposit, *req.Plan)
+		}
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -191,6 +230,132 @@ func voteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc
 	}
 }
 
+func reportMilestoneHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			err := errors.New("proposalId required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, ok := rest.ParseUint64OrReturnBadRequest(w, strProposalID)
+		if !ok {
+			return
+		}
+
+		var req ReportMilestoneReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgReportMilestone(req.Proposer, proposalID, req.ReportHash)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func voteMilestoneHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			err := errors.New("proposalId required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, ok := rest.ParseUint64OrReturnBadRequest(w, strProposalID)
+		if !ok {
+			return
+		}
+
+		var req VoteReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		voteOption, err := gov.VoteOptionFromString(govClientUtils.NormalizeVoteOption(req.Option))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgVoteMilestone(req.Voter, proposalID, voteOption)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func delegateCouncilVoteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CouncilProxyReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgDelegateCouncilVote(req.Delegator, req.Proxy)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func revokeCouncilVoteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CouncilProxyReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgRevokeCouncilVote(req.Delegator)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
 func queryParamsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -612,11 +777,68 @@ func queryTallyOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 	}
 }
 
+func queryMilestonesHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			err := errors.New("proposalId required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, ok := rest.ParseUint64OrReturnBadRequest(w, strProposalID)
+		if !ok {
+			return
+		}
+
+		res, err := govClientUtils.QueryMilestonesByProposalID(proposalID, cliCtx, cdc, "gov")
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryVotingPowerHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			err := errors.New("proposalId required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, ok := rest.ParseUint64OrReturnBadRequest(w, strProposalID)
+		if !ok {
+			return
+		}
+
+		res, err := govClientUtils.QueryVotingPowerByProposalID(proposalID, cliCtx, cdc, "gov")
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
 // todo: Split this functionality into helper functions to remove the above
 func queryFundingCycles(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		res, err := cliCtx.QueryWithData("custom/gov/fundingcycles", nil)
+		page, limit, ok := parsePageArgs(w, r)
+		if !ok {
+			return
+		}
+
+		res, err := gcutils.QueryFundingCycles(page, limit, cliCtx, cdc, "gov")
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -659,3 +881,103 @@ func queryFudningCycleHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) htt
 		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
+
+func queryRankingHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strFundingCycleID := vars[RestFundingCycleID]
+
+		if len(strFundingCycleID) == 0 {
+			err := errors.New("fundingCycleID required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fundingCycleID, ok := rest.ParseUint64OrReturnBadRequest(w, strFundingCycleID)
+		if !ok {
+			return
+		}
+
+		res, err := gcutils.QueryRankingByCycleID(fundingCycleID, cliCtx, cdc, "gov")
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// parsePageArgs reads the page and limit of a paginated query. Requests without
+// a limit return every entry.
+func parsePageArgs(w http.ResponseWriter, r *http.Request) (page, limit int, ok bool) {
+	err := r.ParseForm()
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, sdk.AppendMsgToErr("could not parse query parameters", err.Error()))
+		return 0, 0, false
+	}
+	_, page, limit, err = rest.ParseHTTPArgs(r)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return 0, 0, false
+	}
+	if r.FormValue("limit") == "" {
+		limit = 0
+	}
+	return page, limit, true
+}
+
+func queryCurrentFundingCycleHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := gcutils.QueryCurrentFundingCycle(cliCtx, "gov")
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryFundingCycleReportHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strFundingCycleID := vars[RestFundingCycleID]
+
+		if len(strFundingCycleID) == 0 {
+			err := errors.New("fundingCycleID required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fundingCycleID, ok := rest.ParseUint64OrReturnBadRequest(w, strFundingCycleID)
+		if !ok {
+			return
+		}
+
+		res, err := gcutils.QueryFundingCycleReportByID(fundingCycleID, cliCtx, cdc, "gov")
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryEligibilityHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, limit, ok := parsePageArgs(w, r)
+		if !ok {
+			return
+		}
+
+		res, err := gcutils.QueryEligibility(page, limit, cliCtx, cdc, "gov")
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
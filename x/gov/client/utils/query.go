@@ -265,3 +265,102 @@ func QueryFundingCycleByID(fundingCycleID uint64, cliCtx context.CLIContext, cdc
 	}
 	return res, err
 }
+
+// QueryRankingByCycleID queries the funding ranking snapshot of a funding cycle
+func QueryRankingByCycleID(fundingCycleID uint64, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	params := gov.NewQueryFuncingCycleParams(fundingCycleID)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/ranking", queryRoute), bz)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryFundingCycles queries a page of the funding cycles, a zero limit returns all of them
+func QueryFundingCycles(page, limit int, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	params := gov.NewQueryPageParams(page, limit)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/fundingcycles", queryRoute), bz)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryCurrentFundingCycle queries the status of the running funding cycle
+func QueryCurrentFundingCycle(cliCtx context.CLIContext, queryRoute string) ([]byte, error) {
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/currentfundingcycle", queryRoute), nil)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryFundingCycleReportByID queries the budget and payouts of a funding cycle
+func QueryFundingCycleReportByID(fundingCycleID uint64, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	params := gov.NewQueryFuncingCycleParams(fundingCycleID)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/fundingcyclereport", queryRoute), bz)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryEligibility queries a page of the proposals eligible for funding, in ranking order
+func QueryEligibility(page, limit int, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	params := gov.NewQueryPageParams(page, limit)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/eligibility", queryRoute), bz)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryMilestonesByProposalID queries the milestones of a proposal
+func QueryMilestonesByProposalID(proposalID uint64, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	params := gov.NewQueryProposalParams(proposalID)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/milestones", queryRoute), bz)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+// QueryVotingPowerByProposalID queries the power each council member counts with on a proposal
+func QueryVotingPowerByProposalID(proposalID uint64, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	params := gov.NewQueryProposalParams(proposalID)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/voting_power", queryRoute), bz)
+	if err != nil {
+		return nil, err
+	}
+	return res, err
+}
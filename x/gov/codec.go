@@ -11,10 +11,15 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "cosmos-sdk/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgVote{}, "cosmos-sdk/MsgVote", nil)
+	cdc.RegisterConcrete(MsgReportMilestone{}, "gov/MsgReportMilestone", nil)
+	cdc.RegisterConcrete(MsgVoteMilestone{}, "gov/MsgVoteMilestone", nil)
+	cdc.RegisterConcrete(MsgDelegateCouncilVote{}, "gov/MsgDelegateCouncilVote", nil)
+	cdc.RegisterConcrete(MsgRevokeCouncilVote{}, "gov/MsgRevokeCouncilVote", nil)
 
 	cdc.RegisterInterface((*ProposalContent)(nil), nil)
 	cdc.RegisterConcrete(TextProposal{}, "gov/TextProposal", nil)
 	cdc.RegisterConcrete(SoftwareUpgradeProposal{}, "gov/SoftwareUpgradeProposal", nil)
+	cdc.RegisterConcrete(ParameterChangeProposal{}, "gov/ParameterChangeProposal", nil)
 }
 
 func init() {
@@ -0,0 +1,275 @@
+package gov
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// CouncilMemberPower is the voting power of a council member
+type CouncilMemberPower struct {
+	Address sdk.AccAddress `json:"address"` //  Address of the council member
+	Power   sdk.Dec        `json:"power"`   //  Shares of the council member
+}
+
+// CouncilSnapshot is the council a proposal is tallied against. It is taken when
+// the proposal enters its voting period so delegations and proxies changed during
+// the vote do not change the result.
+type CouncilSnapshot struct {
+	ProposalID uint64                  `json:"proposal_id"` //  ID of the proposal
+	Height     int64                   `json:"height"`      //  Height the snapshot was taken at
+	Members    []CouncilMemberPower    `json:"members"`     //  Council members and their power
+	Proxies    []CouncilVoteDelegation `json:"proxies"`     //  Council vote delegations between the members
+}
+
+// GetPower returns the power of a council member in the snapshot
+func (cs CouncilSnapshot) GetPower(addr sdk.AccAddress) (sdk.Dec, bool) {
+	for _, member := range cs.Members {
+		if member.Address.Equals(addr) {
+			return member.Power, true
+		}
+	}
+	return sdk.ZeroDec(), false
+}
+
+// GetProxy returns the member a council member delegated its vote to in the snapshot
+func (cs CouncilSnapshot) GetProxy(addr sdk.AccAddress) (sdk.AccAddress, bool) {
+	for _, delegation := range cs.Proxies {
+		if delegation.Delegator.Equals(addr) {
+			return delegation.Proxy, true
+		}
+	}
+	return nil, false
+}
+
+// TotalPower returns the power of the whole council in the snapshot
+func (cs CouncilSnapshot) TotalPower() sdk.Dec {
+	total := sdk.ZeroDec()
+	for _, member := range cs.Members {
+		total = total.Add(member.Power)
+	}
+	return total
+}
+
+// nolint
+func (cs CouncilSnapshot) String() string {
+	out := fmt.Sprintf("Council of proposal %d at height %d:\n", cs.ProposalID, cs.Height)
+	for _, member := range cs.Members {
+		out += fmt.Sprintf("%s - %s\n", member.Address, member.Power)
+	}
+	return strings.TrimSpace(out)
+}
+
+// snapshotCouncil reads the current council from the staking keeper
+func (keeper Keeper) snapshotCouncil(ctx sdk.Context, proposalID uint64) CouncilSnapshot {
+	councilmemberIterator := keeper.stk.GetCouncilMemberIterator(ctx)
+	defer councilmemberIterator.Close()
+
+	members := []CouncilMemberPower{}
+	for ; councilmemberIterator.Valid(); councilmemberIterator.Next() {
+		var member CouncilMemberPower
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(councilmemberIterator.Value(), &member)
+		members = append(members, member)
+	}
+	return CouncilSnapshot{
+		ProposalID: proposalID,
+		Height:     ctx.BlockHeight(),
+		Members:    members,
+		Proxies:    keeper.GetCouncilVoteDelegations(ctx),
+	}
+}
+
+// SetCouncilSnapshot stores the council snapshot of a proposal
+func (keeper Keeper) SetCouncilSnapshot(ctx sdk.Context, snapshot CouncilSnapshot) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(snapshot)
+	store.Set(KeyCouncilSnapshot(snapshot.ProposalID), bz)
+}
+
+// GetCouncilSnapshot returns the council snapshot of a proposal
+func (keeper Keeper) GetCouncilSnapshot(ctx sdk.Context, proposalID uint64) (CouncilSnapshot, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyCouncilSnapshot(proposalID))
+	if bz == nil {
+		return CouncilSnapshot{}, false
+	}
+	var snapshot CouncilSnapshot
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &snapshot)
+	return snapshot, true
+}
+
+// getTallyCouncil returns the council the votes on a proposal are weighed with.
+// Proposals which entered their voting period before snapshots were taken are
+// tallied against the current council.
+func (keeper Keeper) getTallyCouncil(ctx sdk.Context, proposalID uint64) CouncilSnapshot {
+	snapshot, found := keeper.GetCouncilSnapshot(ctx, proposalID)
+	if !found {
+		return keeper.snapshotCouncil(ctx, proposalID)
+	}
+	return snapshot
+}
+
+// isCouncilVoter returns whether an address can vote on a proposal, that is
+// whether it is a member of the council the proposal is tallied against
+func (keeper Keeper) isCouncilVoter(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress) bool {
+	snapshot, found := keeper.GetCouncilSnapshot(ctx, proposalID)
+	if !found {
+		_, found = keeper.stk.GetCouncilMemberShares(ctx, voterAddr)
+		return found
+	}
+	_, found = snapshot.GetPower(voterAddr)
+	return found
+}
+
+// MaxCouncilProxyChain is the number of proxies a council vote can go through.
+// Votes delegated further are not counted.
+const MaxCouncilProxyChain = 3
+
+// CouncilVoteDelegation is the delegation of the vote of a council member to another member
+type CouncilVoteDelegation struct {
+	Delegator sdk.AccAddress `json:"delegator"` //  Council member delegating its vote
+	Proxy     sdk.AccAddress `json:"proxy"`     //  Council member voting in its place
+}
+
+// CouncilVoterPower is the power a council member counts with in the tally of a proposal
+type CouncilVoterPower struct {
+	Voter          sdk.AccAddress `json:"voter"`           //  Address of the council member
+	Power          sdk.Dec        `json:"power"`           //  Power of the member in the council snapshot
+	CastBy         sdk.AccAddress `json:"cast_by"`         //  Member whose vote is used, the voter itself when it voted directly
+	Option         VoteOption     `json:"option"`          //  Option the power is counted for, empty when nobody voted for the member
+	EffectivePower sdk.Dec        `json:"effective_power"` //  Power of the members whose votes were cast by the voter
+}
+
+// nolint
+func (p CouncilVoterPower) String() string {
+	return fmt.Sprintf("%s - power %s - effective power %s - %s (cast by %s)",
+		p.Voter, p.Power, p.EffectivePower, p.Option, p.CastBy)
+}
+
+// CouncilVoterPowers is a collection of CouncilVoterPower
+type CouncilVoterPowers []CouncilVoterPower
+
+// nolint
+func (ps CouncilVoterPowers) String() string {
+	out := ""
+	for _, p := range ps {
+		out += p.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// GetCouncilProxy returns the council member a member delegated its vote to
+func (keeper Keeper) GetCouncilProxy(ctx sdk.Context, delegatorAddr sdk.AccAddress) (sdk.AccAddress, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyCouncilProxy(delegatorAddr))
+	if bz == nil {
+		return nil, false
+	}
+	return sdk.AccAddress(bz), true
+}
+
+func (keeper Keeper) setCouncilProxy(ctx sdk.Context, delegatorAddr, proxyAddr sdk.AccAddress) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyCouncilProxy(delegatorAddr), proxyAddr.Bytes())
+}
+
+// GetCouncilVoteDelegations returns all the council vote delegations
+func (keeper Keeper) GetCouncilVoteDelegations(ctx sdk.Context) (delegations []CouncilVoteDelegation) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, append(PrefixCouncilProxy, KeyDelimiter...))
+	defer iterator.Close()
+
+	prefixLength := len(PrefixCouncilProxy) + len(KeyDelimiter)
+	for ; iterator.Valid(); iterator.Next() {
+		delegations = append(delegations, CouncilVoteDelegation{
+			Delegator: sdk.AccAddress(iterator.Key()[prefixLength:]),
+			Proxy:     sdk.AccAddress(iterator.Value()),
+		})
+	}
+	return delegations
+}
+
+// DelegateCouncilVote lets a proxy vote in place of a council member on the
+// proposals the member does not vote on, from the proposals entering their
+// voting period next
+func (keeper Keeper) DelegateCouncilVote(ctx sdk.Context, delegatorAddr, proxyAddr sdk.AccAddress) sdk.Error {
+	if _, found := keeper.stk.GetCouncilMemberShares(ctx, delegatorAddr); !found {
+		return ErrInvalidCouncilMember(keeper.codespace, delegatorAddr)
+	}
+	if _, found := keeper.stk.GetCouncilMemberShares(ctx, proxyAddr); !found {
+		return ErrInvalidCouncilMember(keeper.codespace, proxyAddr)
+	}
+	if delegatorAddr.Equals(proxyAddr) {
+		return ErrInvalidCouncilProxy(keeper.codespace, "council members cannot delegate their vote to themselves")
+	}
+
+	// the proxies of the proxy count towards the chain of the delegator
+	chain := 1
+	for addr, found := keeper.GetCouncilProxy(ctx, proxyAddr); found; addr, found = keeper.GetCouncilProxy(ctx, addr) {
+		if addr.Equals(delegatorAddr) {
+			return ErrInvalidCouncilProxy(keeper.codespace,
+				fmt.Sprintf("%s already delegates its vote to %s", proxyAddr, delegatorAddr))
+		}
+		chain++
+		if chain > MaxCouncilProxyChain {
+			return ErrInvalidCouncilProxy(keeper.codespace,
+				fmt.Sprintf("vote delegations cannot go through more than %d proxies", MaxCouncilProxyChain))
+		}
+	}
+
+	keeper.setCouncilProxy(ctx, delegatorAddr, proxyAddr)
+	return nil
+}
+
+// RevokeCouncilVote removes the proxy of a council member
+func (keeper Keeper) RevokeCouncilVote(ctx sdk.Context, delegatorAddr sdk.AccAddress) sdk.Error {
+	if _, found := keeper.GetCouncilProxy(ctx, delegatorAddr); !found {
+		return ErrInvalidCouncilProxy(keeper.codespace,
+			fmt.Sprintf("%s did not delegate its vote", delegatorAddr))
+	}
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(KeyCouncilProxy(delegatorAddr))
+	return nil
+}
+
+// getCouncilVoterPowers resolves the vote each member of the council counts
+// with. Members who did not vote follow their proxies in the snapshot, up to
+// MaxCouncilProxyChain of them, until one of the proxies voted.
+func (keeper Keeper) getCouncilVoterPowers(council CouncilSnapshot, votes map[string]VoteOption) CouncilVoterPowers {
+
+	powers := make(CouncilVoterPowers, len(council.Members))
+	castBy := make(map[string]int, len(council.Members))
+	for i, member := range council.Members {
+		powers[i] = CouncilVoterPower{
+			Voter:          member.Address,
+			Power:          member.Power,
+			Option:         OptionEmpty,
+			EffectivePower: sdk.ZeroDec(),
+		}
+		castBy[member.Address.String()] = i
+	}
+
+	for i, member := range council.Members {
+		addr := member.Address
+		option, voted := votes[addr.String()]
+		for chain := 0; !voted && chain < MaxCouncilProxyChain; chain++ {
+			proxy, found := council.GetProxy(addr)
+			if !found {
+				break
+			}
+			addr = proxy
+			option, voted = votes[addr.String()]
+		}
+		// only the votes of members of the council are counted
+		j, found := castBy[addr.String()]
+		if !voted || !found {
+			continue
+		}
+
+		powers[i].CastBy = addr
+		powers[i].Option = option
+		powers[j].EffectivePower = powers[j].EffectivePower.Add(member.Power)
+	}
+	return powers
+}
@@ -0,0 +1,215 @@
+package gov
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/staking"
+)
+
+func TestTallyCouncilSnapshot(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 3, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 4, Time: time.Unix(1000, 0).UTC()})
+	keeper.AddFundingCycle(ctx)
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[0], sdk.NewDec(30)))
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[1], sdk.NewDec(10)))
+	sk.RecordCouncilHistory(ctx)
+
+	tp := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, addrs[0])
+	proposal, err := keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+	proposalID := proposal.ProposalID
+
+	// the council is snapshotted when the proposal enters its voting period
+	ctx = ctx.WithBlockHeight(5)
+	keeper.activateVotingPeriod(ctx, proposal)
+	snapshot, found := keeper.GetCouncilSnapshot(ctx, proposalID)
+	require.True(t, found)
+	require.Equal(t, int64(5), snapshot.Height)
+	require.Equal(t, 2, len(snapshot.Members))
+	require.True(t, snapshot.TotalPower().Equal(sdk.NewDec(40)))
+
+	// delegations made during the vote do not change the council of the proposal
+	ctx = ctx.WithBlockHeight(6)
+	sk.SetCouncilMemberShares(ctx, addrs[1], sdk.NewDec(100))
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[2], sdk.NewDec(100)))
+	sk.RecordCouncilHistory(ctx)
+
+	require.Nil(t, keeper.AddVote(ctx, proposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposalID, addrs[1], OptionNo))
+	require.NotNil(t, keeper.AddVote(ctx, proposalID, addrs[2], OptionNo))
+
+	proposal, ok := keeper.GetProposal(ctx, proposalID)
+	require.True(t, ok)
+	passes, tallyResults, neutral := tally(ctx, keeper, proposal)
+	require.True(t, passes)
+	require.False(t, neutral)
+	require.True(t, tallyResults.Yes.Equal(sdk.NewInt(30)))
+	require.True(t, tallyResults.No.Equal(sdk.NewInt(10)))
+
+	// staking keeps the council of every height it changed at, once per height
+	_, found = sk.GetCouncilAtHeight(ctx, 3)
+	require.False(t, found)
+	council, found := sk.GetCouncilAtHeight(ctx, 5)
+	require.True(t, found)
+	require.Equal(t, int64(4), council.Height)
+	require.Equal(t, 2, len(council.Members))
+	council, found = sk.GetCouncilAtHeight(ctx, 6)
+	require.True(t, found)
+	require.Equal(t, int64(6), council.Height)
+	require.Equal(t, 3, len(council.Members))
+
+	genState := ExportGenesis(ctx, keeper)
+	require.Equal(t, []CouncilSnapshot{snapshot}, genState.CouncilSnapshots)
+
+	// a height without council changes records nothing, and old records are pruned
+	ctx = ctx.WithBlockHeight(7)
+	sk.RecordCouncilHistory(ctx)
+	council, _ = sk.GetCouncilAtHeight(ctx, 7)
+	require.Equal(t, int64(6), council.Height)
+	for height := int64(8); height < 8+staking.CouncilHistoryEntries; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		sk.SetCouncilMemberShares(ctx, addrs[2], sdk.NewDec(height))
+		sk.RecordCouncilHistory(ctx)
+	}
+	_, found = sk.GetCouncilAtHeight(ctx, 7)
+	require.False(t, found)
+	council, found = sk.GetCouncilAtHeight(ctx, 8)
+	require.True(t, found)
+	require.Equal(t, int64(8), council.Height)
+
+	// the council query does not report an empty council for the pruned heights
+	querier := staking.NewQuerier(sk, keeper.cdc)
+	_, err = querier(ctx, []string{staking.QueryCouncil}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(staking.NewQueryCouncilParams(7)),
+	})
+	require.NotNil(t, err)
+	bz, err := querier(ctx, []string{staking.QueryCouncil}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(staking.NewQueryCouncilParams(8)),
+	})
+	require.Nil(t, err)
+	var queried staking.HistoricalCouncil
+	keeper.cdc.MustUnmarshalJSON(bz, &queried)
+	require.Equal(t, 3, len(queried.Members))
+}
+
+func TestTallyCouncilProxies(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 6, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0).UTC()})
+	keeper.AddFundingCycle(ctx)
+	for _, addr := range addrs[:5] {
+		sk.SetCouncilMember(ctx, staking.NewCouncilMember(addr, sdk.NewDec(10)))
+	}
+
+	// proxies must be council members and cannot form cycles or long chains
+	require.NotNil(t, keeper.DelegateCouncilVote(ctx, addrs[0], addrs[5]))
+	require.NotNil(t, keeper.DelegateCouncilVote(ctx, addrs[5], addrs[0]))
+	require.NotNil(t, keeper.DelegateCouncilVote(ctx, addrs[0], addrs[0]))
+	require.Nil(t, keeper.DelegateCouncilVote(ctx, addrs[1], addrs[0]))
+	require.NotNil(t, keeper.DelegateCouncilVote(ctx, addrs[0], addrs[1]))
+	require.Nil(t, keeper.DelegateCouncilVote(ctx, addrs[2], addrs[1]))
+	require.Nil(t, keeper.DelegateCouncilVote(ctx, addrs[3], addrs[2]))
+	require.NotNil(t, keeper.DelegateCouncilVote(ctx, addrs[4], addrs[3]))
+	require.Equal(t, 3, len(keeper.GetCouncilVoteDelegations(ctx)))
+
+	tp := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, addrs[0])
+	proposal, err := keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+	keeper.activateVotingPeriod(ctx, proposal)
+
+	// addrs[1], addrs[2] and addrs[3] are counted with the vote of addrs[0]
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[4], OptionNo))
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+	passes, tallyResults, _ := tally(ctx, keeper, proposal)
+	require.True(t, passes)
+	require.True(t, tallyResults.Yes.Equal(sdk.NewInt(40)))
+	require.True(t, tallyResults.No.Equal(sdk.NewInt(10)))
+
+	// a direct vote overrides the vote of the proxy
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[2], OptionNo))
+	_, tallyResults, _ = tally(ctx, keeper, proposal)
+	require.True(t, tallyResults.Yes.Equal(sdk.NewInt(20)))
+	require.True(t, tallyResults.No.Equal(sdk.NewInt(30)))
+
+	querier := NewQuerier(keeper)
+	bz, errRes := querier(ctx, []string{QueryVotingPower}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryProposalParams(proposal.ProposalID)),
+	})
+	require.Nil(t, errRes)
+	var powers CouncilVoterPowers
+	keeper.cdc.MustUnmarshalJSON(bz, &powers)
+	require.Equal(t, 5, len(powers))
+	for _, power := range powers {
+		switch {
+		case power.Voter.Equals(addrs[0]):
+			require.True(t, power.EffectivePower.Equal(sdk.NewDec(20)))
+		case power.Voter.Equals(addrs[2]):
+			require.True(t, power.EffectivePower.Equal(sdk.NewDec(20)))
+		case power.Voter.Equals(addrs[3]):
+			require.True(t, power.CastBy.Equals(addrs[2]))
+			require.Equal(t, OptionNo, power.Option)
+		}
+	}
+
+	// proxies are snapshotted with the council, so a revocation during the vote
+	// only applies to the proposals entering their voting period later
+	require.Nil(t, keeper.RevokeCouncilVote(ctx, addrs[1]))
+	require.NotNil(t, keeper.RevokeCouncilVote(ctx, addrs[1]))
+	_, tallyResults, _ = tally(ctx, keeper, proposal)
+	require.True(t, tallyResults.Yes.Equal(sdk.NewInt(20)))
+	require.True(t, tallyResults.No.Equal(sdk.NewInt(30)))
+
+	proposal, err = keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+	keeper.activateVotingPeriod(ctx, proposal)
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[4], OptionNo))
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+	_, tallyResults, _ = tally(ctx, keeper, proposal)
+	require.True(t, tallyResults.Yes.Equal(sdk.NewInt(10)))
+	require.True(t, tallyResults.No.Equal(sdk.NewInt(10)))
+}
+
+func TestCouncilSharesOnUnbond(t *testing.T) {
+	mapp, _, sk, addrs, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	valAddrs := []sdk.ValAddress{sdk.ValAddress(addrs[0])}
+	createValidators(t, staking.NewHandler(sk), ctx, valAddrs, []int64{100000})
+
+	shares, found := sk.GetCouncilMemberShares(ctx, addrs[0])
+	require.True(t, found)
+	require.True(t, shares.Equal(sdk.NewDecFromInt(sdk.TokensFromTendermintPower(100000))))
+
+	// a partial unbond above the minimum keeps the member with its remaining shares
+	_, err := sk.Undelegate(ctx, addrs[0], valAddrs[0], sdk.NewDecFromInt(sdk.TokensFromTendermintPower(20000)))
+	require.Nil(t, err)
+	shares, found = sk.GetCouncilMemberShares(ctx, addrs[0])
+	require.True(t, found)
+	require.True(t, shares.Equal(sdk.NewDecFromInt(sdk.TokensFromTendermintPower(80000))))
+	require.NoError(t, staking.CouncilMembersInvariant(sk)(ctx))
+
+	// unbonding below the minimum removes the member
+	_, err = sk.Undelegate(ctx, addrs[0], valAddrs[0], sdk.NewDecFromInt(sdk.TokensFromTendermintPower(60000)))
+	require.Nil(t, err)
+	_, found = sk.GetCouncilMemberShares(ctx, addrs[0])
+	require.False(t, found)
+	require.NoError(t, staking.CouncilMembersInvariant(sk)(ctx))
+}
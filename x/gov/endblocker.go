@@ -22,7 +22,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) sdk.Tags {
 		}
 
 	} else if currentFundingCycle.CheckEqualEndTime(ctx.BlockHeader().Time) {
-		ExecuteProposal(ctx, keeper, resTags)
+		resTags = ExecuteProposal(ctx, keeper, resTags)
 		keeper.AddFundingCycle(ctx)
 	}
 
@@ -46,7 +46,8 @@ func UpdateInactiveProposals(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) s
 		}
 
 		keeper.DeleteProposal(ctx, proposalID)
-		keeper.DeleteDeposits(ctx, proposalID) // delete any associated deposits (burned)
+		resTags = resTags.AppendTags(keeper.DeleteDeposits(ctx, proposalID)) // drop any associated deposits
+		keeper.AfterProposalDropped(ctx, proposalID)
 
 		resTags = resTags.AppendTag(tags.ProposalID, fmt.Sprintf("%d", proposalID))
 		resTags = resTags.AppendTag(tags.ProposalResult, tags.ActionProposalDropped)
@@ -72,7 +73,6 @@ func UpdateActiveProposals(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk
 	results := []TallyResult{}
 	// fetch active proposals whose voting periods have ended (are passed the block time)
 	activeIterator := keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
-	var tagValue = "In Voting Cycle"
 	defer activeIterator.Close()
 	for ; activeIterator.Valid(); activeIterator.Next() {
 		var proposalID uint64
@@ -83,10 +83,14 @@ func UpdateActiveProposals(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk
 			panic(fmt.Sprintf("proposal %d does not exist", proposalID))
 		}
 		passes, tallyResults, netural := tally(ctx, keeper, activeProposal)
+		tagValue := "In Voting Cycle"
 
 		if passes {
-			proposals = append(proposals, activeProposal)
-			results = append(results, tallyResults)
+			// parameter changes and upgrades request no funds and are not ranked for funding
+			if activeProposal.ProposalType().RequestsFunds() {
+				proposals = append(proposals, activeProposal)
+				results = append(results, tallyResults)
+			}
 
 		} else if !passes || netural {
 			activeProposal.Ranking = sdk.ZeroInt()
@@ -107,7 +111,7 @@ func UpdateActiveProposals(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk
 		resTags = resTags.AppendTag(tags.ProposalResult, tagValue)
 		keeper.SetProposal(ctx, activeProposal)
 	}
-	proposals = SortProposalEligibility(proposals, results)
+	proposals, _ = RankProposals(proposals, results)
 	keeper.SetEligibilityDetails(ctx, proposals)
 	return resTags
 }
@@ -117,7 +121,6 @@ func ExecuteProposal(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk.Tags
 	logger := ctx.Logger().With("module", "x/gov")
 	proposals := []Proposal{}
 	results := []TallyResult{}
-	var tagValue string
 
 	// fetch active proposals whose voting periods have ended (are passed the block time)
 	activeIterator := keeper.ActiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
@@ -132,32 +135,58 @@ func ExecuteProposal(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk.Tags
 		}
 
 		passes, tallyResults, netural := tally(ctx, keeper, activeProposal)
+		dropped := false
+		var tagValue string
 
 		if passes {
-			proposals = append(proposals, activeProposal)
-			results = append(results, tallyResults)
+			var execTags sdk.Tags
+			switch content := activeProposal.ProposalContent.(type) {
+			case ParameterChangeProposal:
+				activeProposal, tagValue, execTags = executeParameterChange(ctx, keeper, activeProposal, content)
+			case SoftwareUpgradeProposal:
+				activeProposal, tagValue, execTags = executeSoftwareUpgrade(ctx, keeper, activeProposal, content)
+			default:
+				release, rejected := keeper.ReviewMilestone(ctx, activeProposal)
+				switch {
+				case rejected:
+					activeProposal, tagValue, execTags = cancelProposalFunding(ctx, keeper, activeProposal)
+					dropped = true
+				case release:
+					proposals = append(proposals, activeProposal)
+					results = append(results, tallyResults)
+				default:
+					// the milestone is still waiting for its report or a council majority
+					activeProposal.FundingCycleCount = activeProposal.FundingCycleCount + 1
+					if activeProposal.CheckMaxCycleCount(keeper.GetFundingParams(ctx).MaxCycleCount) {
+						activeProposal, tagValue, execTags = cancelProposalFunding(ctx, keeper, activeProposal)
+						dropped = true
+					}
+				}
+			}
+			resTags = resTags.AppendTags(execTags)
 
 		} else if !passes && !netural {
 			keeper.DeleteProposalEligibility(ctx, activeProposal)
-			keeper.DeleteDeposits(ctx, activeProposal.ProposalID)
+			resTags = resTags.AppendTags(keeper.DeleteDeposits(ctx, activeProposal.ProposalID))
 			keeper.RemoveFromInactiveProposalQueue(ctx, activeProposal.DepositEndTime, activeProposal.ProposalID)
 			keeper.RemoveFromActiveProposalQueue(ctx, activeProposal.VotingEndTime, activeProposal.ProposalID)
 			activeProposal.Status = StatusRejected
 			tagValue = tags.ActionProposalRejected
 			activeProposal.Ranking = sdk.ZeroInt()
+			dropped = true
 
 		} else if !passes && netural {
 			activeProposal.FundingCycleCount = activeProposal.FundingCycleCount + 1
-			maxCycleLimit := activeProposal.CheckMaxCycleCount()
+			maxCycleLimit := activeProposal.CheckMaxCycleCount(keeper.GetFundingParams(ctx).MaxCycleCount)
 			if maxCycleLimit {
 				keeper.DeleteProposalEligibility(ctx, activeProposal)
-				keeper.DeleteDeposits(ctx, activeProposal.ProposalID)
+				resTags = resTags.AppendTags(keeper.DeleteDeposits(ctx, activeProposal.ProposalID))
 				keeper.RemoveFromInactiveProposalQueue(ctx, activeProposal.DepositEndTime, activeProposal.ProposalID)
 				keeper.RemoveFromActiveProposalQueue(ctx, activeProposal.VotingEndTime, activeProposal.ProposalID)
 				activeProposal.Status = StatusRejected
 				tagValue = tags.ActionProposalRejected
 				activeProposal.Ranking = sdk.ZeroInt()
-
+				dropped = true
 			}
 
 		}
@@ -171,6 +200,9 @@ func ExecuteProposal(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk.Tags
 		)
 
 		keeper.SetProposal(ctx, activeProposal)
+		if dropped {
+			keeper.AfterProposalDropped(ctx, proposalID)
+		}
 
 		// TODO check if no remaining cycle left then delete proposal
 		//	keeper.RemoveFromActiveProposalQueue(ctx, activeProposal.VotingEndTime, activeProposal.ProposalID)
@@ -180,8 +212,110 @@ func ExecuteProposal(ctx sdk.Context, keeper Keeper, resTags sdk.Tags) sdk.Tags
 
 	}
 
-	proposals = SortProposalEligibility(proposals, results)
+	proposals, results = RankProposals(proposals, results)
 	keeper.SetEligibilityDetails(ctx, proposals)
-	keeper.TransferFunds(ctx, proposals)
+	payouts := keeper.TransferFunds(ctx, proposals)
+	snapshotRanking(ctx, keeper, proposals, results, payouts)
 	return resTags
 }
+
+// snapshotRanking stores the final ranking of the ending funding cycle along with
+// the amounts paid to the funded proposals
+func snapshotRanking(ctx sdk.Context, keeper Keeper, proposals []Proposal, results []TallyResult,
+	payouts map[uint64]sdk.Coins) {
+
+	fundingCycle, err := keeper.GetCurrentCycle(ctx)
+	if err != nil {
+		return
+	}
+
+	ranking := NewRanking(fundingCycle.CycleID, proposals, results)
+	for i := range ranking.Proposals {
+		payout, funded := payouts[ranking.Proposals[i].ProposalID]
+		ranking.Proposals[i].Funded = funded
+		ranking.Proposals[i].FundedAmount = payout
+	}
+	keeper.SetRanking(ctx, ranking)
+}
+
+// cancelProposalFunding stops the funding of a proposal whose milestone was rejected
+// by the council, or not approved in time. The remaining cycles are cancelled and
+// the deposits are dropped.
+func cancelProposalFunding(ctx sdk.Context, keeper Keeper, proposal Proposal) (Proposal, string, sdk.Tags) {
+	keeper.DeleteProposalEligibility(ctx, proposal)
+	resTags := keeper.DeleteDeposits(ctx, proposal.ProposalID)
+	keeper.RemoveFromInactiveProposalQueue(ctx, proposal.DepositEndTime, proposal.ProposalID)
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.VotingEndTime, proposal.ProposalID)
+	proposal.Status = StatusRejected
+	proposal.RemainingFundingCycle = 0
+	proposal.Ranking = sdk.ZeroInt()
+	return proposal, tags.ActionMilestoneRejected, resTags
+}
+
+// executeParameterChange applies the changes of a passed ParameterChangeProposal
+// and closes the proposal. Deposits are refunded even if the changes fail to apply,
+// as the proposal did pass the council tally.
+func executeParameterChange(ctx sdk.Context, keeper Keeper, proposal Proposal,
+	content ParameterChangeProposal) (Proposal, string, sdk.Tags) {
+
+	logger := ctx.Logger().With("module", "x/gov")
+	var tagValue string
+
+	changeTags, err := keeper.ApplyParamChanges(ctx, content.Changes)
+	if err != nil {
+		proposal.Status = StatusRejected
+		tagValue = tags.ActionProposalFailed
+		logger.Error(
+			fmt.Sprintf("proposal %d (%s) passed but its parameter changes were rolled back: %s",
+				proposal.ProposalID, proposal.GetTitle(), err.Result().Log,
+			),
+		)
+	} else {
+		proposal.Status = StatusPassed
+		tagValue = tags.ActionProposalPassed
+		for _, change := range content.Changes {
+			logger.Info(fmt.Sprintf("proposal %d (%s) changed parameter %s", proposal.ProposalID, proposal.GetTitle(), change))
+		}
+	}
+
+	return closeExecutedProposal(ctx, keeper, proposal), tagValue, changeTags
+}
+
+// executeSoftwareUpgrade schedules the upgrade plan of a passed SoftwareUpgradeProposal
+// and closes the proposal. The plan is rejected if its height or time has already passed.
+func executeSoftwareUpgrade(ctx sdk.Context, keeper Keeper, proposal Proposal,
+	content SoftwareUpgradeProposal) (Proposal, string, sdk.Tags) {
+
+	logger := ctx.Logger().With("module", "x/gov")
+	var tagValue string
+
+	upgradeTags, err := keeper.ScheduleUpgrade(ctx, content.Plan)
+	if err != nil {
+		proposal.Status = StatusRejected
+		tagValue = tags.ActionProposalFailed
+		logger.Error(
+			fmt.Sprintf("proposal %d (%s) passed but its upgrade plan could not be scheduled: %s",
+				proposal.ProposalID, proposal.GetTitle(), err.Result().Log,
+			),
+		)
+	} else {
+		proposal.Status = StatusPassed
+		tagValue = tags.ActionProposalPassed
+		logger.Info(
+			fmt.Sprintf("proposal %d (%s) scheduled upgrade %s at %s",
+				proposal.ProposalID, proposal.GetTitle(), content.Plan.Name, content.Plan.DueAt(),
+			),
+		)
+	}
+
+	return closeExecutedProposal(ctx, keeper, proposal), tagValue, upgradeTags
+}
+
+// closeExecutedProposal refunds the deposits of an executed proposal and removes it from the active queue
+func closeExecutedProposal(ctx sdk.Context, keeper Keeper, proposal Proposal) Proposal {
+	keeper.RefundDeposits(ctx, proposal.ProposalID)
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.VotingEndTime, proposal.ProposalID)
+	proposal.RemainingFundingCycle = 0
+	proposal.Ranking = sdk.ZeroInt()
+	return proposal
+}
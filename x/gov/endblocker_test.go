@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/gov/tags"
 	"github.com/ColorPlatform/color-sdk/x/staking"
 )
 
@@ -252,7 +253,7 @@ func TestTickPassedSubmitProposal(t *testing.T) {
 	require.False(t, err)
 
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -293,7 +294,7 @@ func TestTickPassedLimitFirstFundingCycle(t *testing.T) {
 	header = abci.Header{Height: mapp.LastBlockHeight() + 1}
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -333,7 +334,7 @@ func TestTickSortingProposalEligibility(t *testing.T) {
 	staking.EndBlocker(ctx, sk)
 
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 3
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -406,7 +407,7 @@ func TestTickTransferFunds(t *testing.T) {
 	// staking.EndBlocker(ctx, sk)
 
 	// newHeader = ctx.BlockHeader()
-	// newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	// newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	// newHeader.Height = 3
 	// ctx = ctx.WithBlockHeader(newHeader)
 	// EndBlocker(ctx, keeper)
@@ -435,7 +436,7 @@ func TestTickTransferFunds(t *testing.T) {
 
 	// // Add time of end cycle
 
-	// newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	// newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	// newHeader.Height = 4
 	// ctx = ctx.WithBlockHeader(newHeader)
 	// EndBlocker(ctx, keeper)
@@ -462,7 +463,7 @@ func TestVotingSubmittedProposalBasic(t *testing.T) {
 	EndBlocker(ctx, keeper)
 
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -518,7 +519,7 @@ func TestVotingSubmittedProposalAdvance(t *testing.T) {
 	EndBlocker(ctx, keeper)
 
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -561,3 +562,33 @@ func TestVotingSubmittedProposalAdvance(t *testing.T) {
 	require.False(t, found)
 
 }
+
+func TestExecuteProposalResultTags(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0).UTC()})
+	keeper.AddFundingCycle(ctx)
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[0], sdk.NewDec(10)))
+
+	tp := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, addrs[0])
+	rejected, err := keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+	keeper.activateVotingPeriod(ctx, rejected)
+	require.Nil(t, keeper.AddVote(ctx, rejected.ProposalID, addrs[0], OptionNo))
+	waiting, err := keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+	keeper.activateVotingPeriod(ctx, waiting)
+
+	// a proposal without votes does not report the result of the proposal before it
+	resTags := ExecuteProposal(ctx, keeper, sdk.NewTags())
+	var results []string
+	for _, tag := range resTags {
+		if string(tag.Key) == tags.ProposalResult {
+			results = append(results, string(tag.Value))
+		}
+	}
+	require.Equal(t, []string{tags.ActionProposalRejected, ""}, results)
+}
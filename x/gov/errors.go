@@ -25,6 +25,10 @@ const (
 	CodeInvalidEligibility      sdk.CodeType = 13
 	CodeInvalidCycle            sdk.CodeType = 14
 	CodeInvalidCouncil          sdk.CodeType = 15
+	CodeInvalidParamChange      sdk.CodeType = 16
+	CodeInvalidUpgradePlan      sdk.CodeType = 17
+	CodeInvalidMilestone        sdk.CodeType = 18
+	CodeInvalidCouncilProxy     sdk.CodeType = 19
 )
 
 // Error constructors
@@ -85,3 +89,16 @@ func ErrInvalidCycle(codespace sdk.CodespaceType, msg string) sdk.Error {
 func ErrInvalidCouncilMember(codespace sdk.CodespaceType, address sdk.AccAddress) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidCouncil, fmt.Sprintf("Address %s is not a Council Member", address))
 }
+func ErrInvalidParamChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParamChange, msg)
+}
+
+func ErrInvalidUpgradePlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidUpgradePlan, msg)
+}
+func ErrInvalidMilestone(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidMilestone, msg)
+}
+func ErrInvalidCouncilProxy(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidCouncilProxy, msg)
+}
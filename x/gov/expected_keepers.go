@@ -1,10 +1,14 @@
 package gov
 
-import sdk "github.com/ColorPlatform/color-sdk/types"
+import (
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
+)
 
 // bank keeper expected
 type BankKeeper interface {
 	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
 
 	// TODO remove once governance doesn't require use of accounts
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
@@ -14,5 +18,22 @@ type BankKeeper interface {
 // StakingKeeper expected
 type StakingKeeper interface {
 	GetCouncilMemberIterator(ctx sdk.Context) sdk.Iterator
-	GetCouncilMemberShares(ctx sdk.Context, memAddr sdk.AccAddress) (sdk.Dec,bool)
+	GetCouncilMemberShares(ctx sdk.Context, memAddr sdk.AccAddress) (sdk.Dec, bool)
+	DeflateSupply(ctx sdk.Context, burnedTokens sdk.Int)
+}
+
+// SupplyKeeper expected
+type SupplyKeeper interface {
+	Burn(ctx sdk.Context, amount sdk.Coins)
+}
+
+// CrisisKeeper expected
+type CrisisKeeper interface {
+	RegisterRoute(moduleName, route string, invar sdk.Invariant)
+}
+
+// UpgradeKeeper expected
+type UpgradeKeeper interface {
+	ValidatePlan(ctx sdk.Context, plan upgrade.Plan) sdk.Error
+	ScheduleUpgrade(ctx sdk.Context, plan upgrade.Plan) sdk.Error
 }
@@ -3,7 +3,6 @@ package gov
 import (
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -15,10 +14,13 @@ const (
 	FirstBlockHeight = 1
 	// LimitFirstFundingCycle condation first funding cycle should start after 4 weeks
 	LimitFirstFundingCycle = 0
-	// FourWeeksHours calculate total hours in 4 weeks
-	FourWeeksHours        = time.Hour * time.Duration(24*28)
-	StopFundingBeforeDays = 2 //stop on last two days of funding cycle
+	// OneWeek duration of a week, the minting provisions are computed weekly
+	OneWeek = time.Hour * time.Duration(24*7)
 
+	// Default funding parameters
+	DefaultCycleDuration = 4 * OneWeek
+	DefaultFreezeWindow  = time.Hour * time.Duration(24*2) // stop on last two days of funding cycle
+	DefaultMaxCycleCount = 2
 )
 
 // FundingCycle controlling proposal cycles
@@ -27,6 +29,21 @@ type FundingCycle struct {
 	CycleStartTime  time.Time `json:"cycle_start_time"` //  Time of the funding cycle to start
 	CycleEndTime    time.Time `json:"cycle_end_time"`   //  Time that the funding cycle to end
 	FundedProposals []uint64  `json:"funded_proposals"` // Funded proposals in a funding cycle
+	Budget          sdk.Int   `json:"budget"`           // Treasury budget of the cycle, including the budget carried from the previous cycle
+	Spent           sdk.Int   `json:"spent"`            // Budget paid out to proposals
+	Carried         sdk.Int   `json:"carried"`          // Unspent budget carried over to the next cycle
+}
+
+// NewFundingCycle creates a funding cycle with an empty budget
+func NewFundingCycle(cycleID uint64, startTime, endTime time.Time) FundingCycle {
+	return FundingCycle{
+		CycleID:        cycleID,
+		CycleStartTime: startTime,
+		CycleEndTime:   endTime,
+		Budget:         sdk.ZeroInt(),
+		Spent:          sdk.ZeroInt(),
+		Carried:        sdk.ZeroInt(),
+	}
 }
 
 func (fs FundingCycle) String() string {
@@ -34,9 +51,13 @@ func (fs FundingCycle) String() string {
 	CycleID:                    %d
 	Cycle Start Time:           %s
 	Cycle End Time:             %s
-	Funded Proposals: 	%s
+	Funded Proposals: 	%v
+	Budget:                     %s
+	Spent:                      %s
+	Carried:                    %s
 `,
 		fs.CycleID, fs.CycleStartTime, fs.CycleEndTime, fs.FundedProposals,
+		fs.Budget, fs.Spent, fs.Carried,
 	)
 }
 
@@ -47,22 +68,71 @@ func (fs FundingCycle) CheckEqualEndTime(currentTime time.Time) bool {
 	}
 	return false
 
-}
-func GetPercentageAmount(amount sdk.Dec, percentage float64) int64 {
-	num1, _ := strconv.ParseFloat(amount.String(), 64)
-	percentage = percentage * num1
-	return int64(percentage)
-
 }
 
 type FundingCycles []FundingCycle
 
 // nolint
 func (fs FundingCycles) String() string {
-	out := "ID - [StartTime] [EndTime]\n"
+	out := "ID - [StartTime] [EndTime] - Budget - Spent - Carried - Funded Proposals\n"
 	for _, cycle := range fs {
-		out += fmt.Sprintf("%d - [%s] [%s]\n",
-			cycle.CycleID, cycle.CycleStartTime, cycle.CycleEndTime)
+		out += fmt.Sprintf("%d - [%s] [%s] - %s - %s - %s - %v\n",
+			cycle.CycleID, cycle.CycleStartTime, cycle.CycleEndTime,
+			cycle.Budget, cycle.Spent, cycle.Carried, cycle.FundedProposals)
+	}
+	return strings.TrimSpace(out)
+}
+
+// CurrentFundingCycle is the status of the running funding cycle
+type CurrentFundingCycle struct {
+	FundingCycle    FundingCycle  `json:"funding_cycle"`     //  The running funding cycle
+	TimeRemaining   time.Duration `json:"time_remaining"`    //  Time left until the end of the cycle
+	FreezeStartTime time.Time     `json:"freeze_start_time"` //  Time deposits and votes stop being accepted
+	Frozen          bool          `json:"frozen"`            //  Whether the cycle is in its freeze window
+	EstimatedBudget sdk.Int       `json:"estimated_budget"`  //  Budget the cycle will distribute with the current treasury income
+}
+
+// nolint
+func (c CurrentFundingCycle) String() string {
+	return fmt.Sprintf(`Current Funding Cycle %d:
+  Start Time:         %s
+  End Time:           %s
+  Time Remaining:     %s
+  Freeze Start Time:  %s
+  Frozen:             %t
+  Estimated Budget:   %s`,
+		c.FundingCycle.CycleID, c.FundingCycle.CycleStartTime, c.FundingCycle.CycleEndTime,
+		c.TimeRemaining, c.FreezeStartTime, c.Frozen, c.EstimatedBudget)
+}
+
+// FundedProposal is the amount paid to a proposal in a funding cycle
+type FundedProposal struct {
+	ProposalID uint64    `json:"proposal_id"` //  ID of the proposal
+	Amount     sdk.Coins `json:"amount"`      //  Amount paid to the proposer
+}
+
+// FundingCycleReport compares the budget of a funding cycle with the amounts
+// paid to the funded proposals
+type FundingCycleReport struct {
+	CycleID         uint64           `json:"cycle_id"`
+	CycleStartTime  time.Time        `json:"cycle_start_time"`
+	CycleEndTime    time.Time        `json:"cycle_end_time"`
+	Budget          sdk.Int          `json:"budget"`
+	Spent           sdk.Int          `json:"spent"`
+	Carried         sdk.Int          `json:"carried"`
+	FundedProposals []FundedProposal `json:"funded_proposals"`
+}
+
+// nolint
+func (r FundingCycleReport) String() string {
+	out := fmt.Sprintf(`Funding Cycle %d [%s] [%s]:
+  Budget:   %s
+  Spent:    %s
+  Carried:  %s
+Funded Proposals:
+`, r.CycleID, r.CycleStartTime, r.CycleEndTime, r.Budget, r.Spent, r.Carried)
+	for _, funded := range r.FundedProposals {
+		out += fmt.Sprintf("  %d - %s\n", funded.ProposalID, funded.Amount)
 	}
 	return strings.TrimSpace(out)
 }
@@ -88,25 +158,123 @@ func VerifyAmount(totalRequested sdk.Coins, limit sdk.Int) bool {
 
 }
 
-func SortProposalEligibility(proposals []Proposal, results []TallyResult) []Proposal {
-	sort.Slice(proposals, func(i, j int) bool {
-		votesA := results[i].Yes.Sub(results[i].No)
-		votesB := results[i].Yes.Sub(results[i].No)
+// budgetAllocation is the amount of a funding cycle budget paid to a proposal
+type budgetAllocation struct {
+	Proposal Proposal
+	Amount   sdk.Coins
+}
 
-		return votesA.GT(votesB)
-	})
-	return proposals
+// allocateBudget splits the budget of a funding cycle between the ranked proposals
+// following the funding policy. The requested coins of every denom must also be
+// left in the community pool. It returns the allocations in ranking order and
+// the unspent budget.
+func allocateBudget(budget sdk.Int, pool sdk.Coins, proposals []Proposal, policy FundingPolicy) ([]budgetAllocation, sdk.Int) {
+	var allocations []budgetAllocation
+	remaining := budget
+	for _, proposal := range proposals {
+		payout := proposal.GetRequestedFund()
+		amount := payout.AmountOf(sdk.DefaultBondDenom)
+		if amount.GT(remaining) {
+			if policy != FundingPolicyPartial || !remaining.IsPositive() {
+				continue
+			}
+			amount = remaining
+			payout = sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, amount))
+		}
+		if !pool.IsAllGTE(payout) {
+			continue
+		}
+		pool = pool.Sub(payout)
+		remaining = remaining.Sub(amount)
+		allocations = append(allocations, budgetAllocation{Proposal: proposal, Amount: payout})
+	}
+	return allocations, remaining
 }
 
-//CheckCycleActive Stop Funding on last two days of Funding Cycle
+//CheckCycleActive Stop Funding during the freeze window at the end of the Funding Cycle
 func (keeper Keeper) CheckCycleActive(ctx sdk.Context) bool {
 	currentFundingCycle, err := keeper.GetCurrentCycle(ctx)
 	if err == nil {
 		timeblock := ctx.BlockHeader().Time
-		if !timeblock.After(currentFundingCycle.CycleEndTime.AddDate(0, 0, StopFundingBeforeDays)) {
+		freezeWindow := keeper.GetFundingParams(ctx).FreezeWindow
+		if timeblock.Before(currentFundingCycle.CycleEndTime.Add(-freezeWindow)) {
 			return true
 		}
 		return false
 	}
 	return false
 }
+
+// GetCurrentFundingCycle returns the status of the running funding cycle
+func (keeper Keeper) GetCurrentFundingCycle(ctx sdk.Context) (CurrentFundingCycle, sdk.Error) {
+	fundingCycle, err := keeper.GetCurrentCycle(ctx)
+	if err != nil {
+		return CurrentFundingCycle{}, err
+	}
+
+	fundingParams := keeper.GetFundingParams(ctx)
+	blockTime := ctx.BlockHeader().Time
+	timeRemaining := fundingCycle.CycleEndTime.Sub(blockTime)
+	if timeRemaining < 0 {
+		timeRemaining = 0
+	}
+
+	return CurrentFundingCycle{
+		FundingCycle:    fundingCycle,
+		TimeRemaining:   timeRemaining,
+		FreezeStartTime: fundingCycle.CycleEndTime.Add(-fundingParams.FreezeWindow),
+		Frozen:          !keeper.CheckCycleActive(ctx),
+		EstimatedBudget: keeper.getCycleBudget(ctx, fundingCycle.CycleID),
+	}, nil
+}
+
+// GetFundingCycleReport returns the budget of a funding cycle and the amounts
+// paid to its funded proposals, read from the ranking of the cycle
+func (keeper Keeper) GetFundingCycleReport(ctx sdk.Context, cycleID uint64) (FundingCycleReport, bool) {
+	fundingCycle, found := keeper.GetFundingCycle(ctx, cycleID)
+	if !found {
+		return FundingCycleReport{}, false
+	}
+
+	funded := []FundedProposal{}
+	if ranking, found := keeper.GetRanking(ctx, cycleID); found {
+		for _, ranked := range ranking.Proposals {
+			if ranked.Funded {
+				funded = append(funded, FundedProposal{ranked.ProposalID, ranked.FundedAmount})
+			}
+		}
+	}
+
+	return FundingCycleReport{
+		CycleID:         fundingCycle.CycleID,
+		CycleStartTime:  fundingCycle.CycleStartTime,
+		CycleEndTime:    fundingCycle.CycleEndTime,
+		Budget:          fundingCycle.Budget,
+		Spent:           fundingCycle.Spent,
+		Carried:         fundingCycle.Carried,
+		FundedProposals: funded,
+	}, true
+}
+
+// GetEligibleProposals returns the proposals in their voting period which would
+// be funded at the end of the running cycle, in ranking order
+func (keeper Keeper) GetEligibleProposals(ctx sdk.Context) RankedProposals {
+	eligible := RankedProposals{}
+	for _, proposal := range keeper.GetProposalsFiltered(ctx, nil, nil, StatusVotingPeriod, 0) {
+		if !proposal.Ranking.IsPositive() {
+			continue
+		}
+		eligible = append(eligible, RankedProposal{
+			Rank:          uint64(proposal.Ranking.Int64()),
+			ProposalID:    proposal.ProposalID,
+			NetVotes:      proposal.FinalTallyResult.NetVotes(),
+			Turnout:       proposal.FinalTallyResult.Turnout(),
+			SubmitTime:    proposal.SubmitTime,
+			RequestedFund: proposal.GetRequestedFund(),
+		})
+	}
+	sort.Slice(eligible, func(i, j int) bool {
+		return eligible[i].Rank < eligible[j].Rank
+	})
+	return eligible
+}
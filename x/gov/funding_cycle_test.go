@@ -2,10 +2,13 @@ package gov
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
+	distrtypes "github.com/ColorPlatform/color-sdk/x/distribution/types"
+	"github.com/ColorPlatform/color-sdk/x/mint"
 	abci "github.com/ColorPlatform/prism/abci/types"
 )
 
@@ -66,3 +69,333 @@ func TestEligibilityDelation(t *testing.T) {
 	keeper.ck.SetSendEnabled(ctx, true)
 
 }
+
+func TestFundingParamsCycle(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	start := time.Unix(1000, 0).UTC()
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: start})
+	require.Equal(t, DefaultGenesisState().FundingParams.CycleDuration, keeper.GetFundingParams(ctx).CycleDuration)
+
+	fundingParams := FundingParams{
+		CycleDuration: time.Hour,
+		FreezeWindow:  10 * time.Minute,
+		MaxCycleCount: 3,
+		TreasuryShare: sdk.NewDecWithPrec(25, 2),
+	}
+	keeper.setFundingParams(ctx, fundingParams)
+
+	keeper.AddFundingCycle(ctx)
+	cycle, err := keeper.GetCurrentCycle(ctx)
+	require.Nil(t, err)
+	require.Equal(t, start.Add(time.Hour), cycle.CycleEndTime)
+
+	// votes and deposits are refused during the freeze window
+	require.True(t, keeper.CheckCycleActive(ctx))
+	require.True(t, keeper.CheckCycleActive(ctx.WithBlockTime(start.Add(49*time.Minute))))
+	require.False(t, keeper.CheckCycleActive(ctx.WithBlockTime(start.Add(50*time.Minute))))
+
+	proposal := Proposal{FundingCycleCount: 2}
+	require.False(t, proposal.CheckMaxCycleCount(fundingParams.MaxCycleCount))
+	proposal.FundingCycleCount = 3
+	require.True(t, proposal.CheckMaxCycleCount(fundingParams.MaxCycleCount))
+}
+
+func TestRankProposals(t *testing.T) {
+	submitTime := time.Unix(1000, 0).UTC()
+	content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, nil)
+	proposals := []Proposal{
+		{ProposalID: 1, SubmitTime: submitTime, ProposalContent: content},
+		{ProposalID: 2, SubmitTime: submitTime, ProposalContent: content},
+		{ProposalID: 3, SubmitTime: submitTime.Add(-time.Hour), ProposalContent: content},
+		{ProposalID: 4, SubmitTime: submitTime, ProposalContent: content},
+		{ProposalID: 5, SubmitTime: submitTime, ProposalContent: content},
+	}
+	results := []TallyResult{
+		NewTallyResult(sdk.NewInt(10), sdk.NewInt(0), sdk.NewInt(5)), // net 5, turnout 15
+		NewTallyResult(sdk.NewInt(10), sdk.NewInt(5), sdk.NewInt(5)), // net 5, turnout 20
+		NewTallyResult(sdk.NewInt(10), sdk.NewInt(0), sdk.NewInt(5)), // net 5, turnout 15, submitted earlier
+		NewTallyResult(sdk.NewInt(20), sdk.NewInt(0), sdk.NewInt(0)), // net 20
+		NewTallyResult(sdk.NewInt(10), sdk.NewInt(0), sdk.NewInt(5)), // same as 1, higher ID
+	}
+
+	sortedProposals, sortedResults := RankProposals(proposals, results)
+
+	expected := []uint64{4, 2, 3, 1, 5}
+	for i, proposal := range sortedProposals {
+		require.Equal(t, expected[i], proposal.ProposalID)
+		require.True(t, sortedResults[i].Equals(results[expected[i]-1]))
+	}
+
+	// the ranking does not depend on the input order
+	reversedProposals := make([]Proposal, len(proposals))
+	reversedResults := make([]TallyResult, len(results))
+	for i := range proposals {
+		reversedProposals[len(proposals)-1-i] = proposals[i]
+		reversedResults[len(results)-1-i] = results[i]
+	}
+	sortedAgain, _ := RankProposals(reversedProposals, reversedResults)
+	require.Equal(t, sortedProposals, sortedAgain)
+
+	ranking := NewRanking(7, sortedProposals, sortedResults)
+	require.Equal(t, uint64(7), ranking.CycleID)
+	require.Equal(t, uint64(1), ranking.Proposals[0].Rank)
+	require.Equal(t, sdk.NewInt(20), ranking.Proposals[0].NetVotes)
+	require.Equal(t, sdk.NewInt(20), ranking.Proposals[1].Turnout)
+}
+
+func TestRankingSnapshot(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	querier := NewQuerier(keeper)
+
+	_, found := keeper.GetRanking(ctx, 1)
+	require.False(t, found)
+	_, err := querier(ctx, []string{QueryRanking}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryFuncingCycleParams(1)),
+	})
+	require.NotNil(t, err)
+
+	content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, nil)
+	proposals := []Proposal{{ProposalID: 1, ProposalContent: content}, {ProposalID: 2, ProposalContent: content}}
+	results := []TallyResult{
+		NewTallyResult(sdk.NewInt(1), sdk.NewInt(0), sdk.NewInt(0)),
+		NewTallyResult(sdk.NewInt(2), sdk.NewInt(0), sdk.NewInt(0)),
+	}
+	proposals, results = RankProposals(proposals, results)
+	ranking := NewRanking(1, proposals, results)
+	ranking.Proposals[0].Funded = true
+	keeper.SetRanking(ctx, ranking)
+
+	stored, found := keeper.GetRanking(ctx, 1)
+	require.True(t, found)
+	require.Equal(t, uint64(2), stored.Proposals[0].ProposalID)
+	require.True(t, stored.Proposals[0].Funded)
+	require.False(t, stored.Proposals[1].Funded)
+
+	bz, err := querier(ctx, []string{QueryRanking}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryFuncingCycleParams(1)),
+	})
+	require.Nil(t, err)
+	var queried Ranking
+	keeper.cdc.MustUnmarshalJSON(bz, &queried)
+	require.Equal(t, len(ranking.Proposals), len(queried.Proposals))
+	require.Equal(t, uint64(2), queried.Proposals[0].ProposalID)
+}
+
+func TestAllocateBudget(t *testing.T) {
+	newProposal := func(proposalID uint64, requested int64) Proposal {
+		content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, requested)}, 1, nil)
+		return Proposal{ProposalID: proposalID, ProposalContent: content}
+	}
+	proposals := []Proposal{newProposal(1, 40), newProposal(2, 50), newProposal(3, 30), newProposal(4, 20)}
+	pool := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))
+
+	tests := []struct {
+		policy    FundingPolicy
+		funded    []uint64
+		amounts   []int64
+		remaining int64
+	}{
+		{FundingPolicySkip, []uint64{1, 2}, []int64{40, 50}, 10},
+		{FundingPolicyCarryOver, []uint64{1, 2}, []int64{40, 50}, 10},
+		{FundingPolicyPartial, []uint64{1, 2, 3}, []int64{40, 50, 10}, 0},
+	}
+
+	for i, tc := range tests {
+		allocations, remaining := allocateBudget(sdk.NewInt(100), pool, proposals, tc.policy)
+		require.Equal(t, len(tc.funded), len(allocations), "test: %v", i)
+		for j, allocation := range allocations {
+			require.Equal(t, tc.funded[j], allocation.Proposal.ProposalID, "test: %v", i)
+			require.Equal(t, sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, tc.amounts[j])}, allocation.Amount, "test: %v", i)
+		}
+		require.True(t, sdk.NewInt(tc.remaining).Equal(remaining), "test: %v", i)
+	}
+
+	// proposals that do not fit are skipped, lower ranked ones can still be funded
+	allocations, remaining := allocateBudget(sdk.NewInt(75), pool, proposals, FundingPolicySkip)
+	require.Equal(t, 2, len(allocations))
+	require.Equal(t, uint64(1), allocations[0].Proposal.ProposalID)
+	require.Equal(t, uint64(3), allocations[1].Proposal.ProposalID)
+	require.True(t, sdk.NewInt(5).Equal(remaining))
+
+	// the other denoms requested are only paid while the community pool holds them
+	multiDenom := NewTextProposal("Test", "test", sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10),
+		sdk.NewInt64Coin("other", 30)), 1, nil)
+	proposals = []Proposal{{ProposalID: 5, ProposalContent: multiDenom}, {ProposalID: 6, ProposalContent: multiDenom},
+		newProposal(7, 10)}
+	pool = pool.Add(sdk.NewCoins(sdk.NewInt64Coin("other", 50)))
+	allocations, remaining = allocateBudget(sdk.NewInt(100), pool, proposals, FundingPolicySkip)
+	require.Equal(t, 2, len(allocations))
+	require.Equal(t, uint64(5), allocations[0].Proposal.ProposalID)
+	require.Equal(t, multiDenom.GetRequestedFund(), allocations[0].Amount)
+	require.Equal(t, uint64(7), allocations[1].Proposal.ProposalID)
+	require.True(t, sdk.NewInt(80).Equal(remaining))
+}
+
+func TestTransferFundsPoolCap(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0).UTC()})
+	keeper.distrKeeper.SetCommunityTax(ctx, sdk.NewDecWithPrec(2, 2))
+	keeper.minKeeper.SetMinter(ctx, mint.NewMinter(sdk.ZeroDec(), sdk.NewDec(100000), sdk.ZeroDec(), time.Time{}, time.Time{}))
+	keeper.AddFundingCycle(ctx)
+	budget := keeper.GetTreasuryWeeklyIncome(ctx).Mul(keeper.GetFundingParams(ctx).TreasuryShare).TruncateInt()
+	require.True(t, budget.GT(sdk.NewInt(80)))
+
+	content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 40)}, 1, addrs[0])
+	proposals := []Proposal{
+		{ProposalID: 1, ProposalContent: content, RemainingFundingCycle: 1},
+		{ProposalID: 2, ProposalContent: content, RemainingFundingCycle: 1},
+	}
+	for _, proposal := range proposals {
+		keeper.SetProposal(ctx, proposal)
+	}
+
+	// the budget estimated from the treasury income is capped at the community pool
+	keeper.distrKeeper.SetFeePool(ctx, distrtypes.FeePool{
+		CommunityPool: sdk.NewDecCoins(sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 50)}),
+	})
+	balance := mapp.AccountKeeper.GetAccount(ctx, addrs[0]).GetCoins()
+	payouts := keeper.TransferFunds(ctx, proposals)
+	require.Equal(t, map[uint64]sdk.Coins{1: content.GetRequestedFund()}, payouts)
+	require.Equal(t, balance.Add(content.GetRequestedFund()), mapp.AccountKeeper.GetAccount(ctx, addrs[0]).GetCoins())
+	require.Equal(t, sdk.NewDecCoins(sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)}),
+		keeper.distrKeeper.GetFeePoolCommunityCoins(ctx))
+
+	cycle, err := keeper.GetCurrentCycle(ctx)
+	require.Nil(t, err)
+	require.True(t, sdk.NewInt(50).Equal(cycle.Budget))
+	require.True(t, sdk.NewInt(40).Equal(cycle.Spent))
+	funded, _ := keeper.GetProposal(ctx, 1)
+	require.Equal(t, StatusPassed, funded.Status)
+	skipped, _ := keeper.GetProposal(ctx, 2)
+	require.Equal(t, uint64(1), skipped.RemainingFundingCycle)
+}
+
+func TestFundingCycleQueries(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0).UTC()})
+	querier := NewQuerier(keeper)
+
+	_, err := querier(ctx, []string{QueryCurrentCycle}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	for i := 0; i < 12; i++ {
+		keeper.AddFundingCycle(ctx)
+	}
+
+	// funding cycles are paginated in ID order, requests without page return all of them
+	var cycles FundingCycles
+	bz, err := querier(ctx, []string{QueryCycles}, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &cycles)
+	require.Equal(t, 12, len(cycles))
+	bz, err = querier(ctx, []string{QueryCycles}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryPageParams(2, 5)),
+	})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &cycles)
+	require.Equal(t, 5, len(cycles))
+	require.Equal(t, uint64(5), cycles[0].CycleID)
+	require.Equal(t, uint64(9), cycles[4].CycleID)
+	bz, err = querier(ctx, []string{QueryCycles}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryPageParams(4, 5)),
+	})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &cycles)
+	require.Equal(t, 0, len(cycles))
+
+	// the current cycle reports its time remaining, freeze window and estimated budget
+	keeper.distrKeeper.SetCommunityTax(ctx, sdk.NewDecWithPrec(2, 2))
+	keeper.minKeeper.SetMinter(ctx, mint.NewMinter(sdk.ZeroDec(), sdk.NewDec(100000), sdk.ZeroDec(), time.Time{}, time.Time{}))
+	previous, _ := keeper.GetFundingCycle(ctx, 10)
+	previous.Carried = sdk.NewInt(60)
+	keeper.SetFundingCycle(ctx, previous)
+	fundingParams := keeper.GetFundingParams(ctx)
+	budget := keeper.GetTreasuryWeeklyIncome(ctx).Mul(fundingParams.TreasuryShare).TruncateInt()
+	require.True(t, budget.IsPositive())
+	var current CurrentFundingCycle
+	bz, err = querier(ctx, []string{QueryCurrentCycle}, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &current)
+	require.Equal(t, uint64(11), current.FundingCycle.CycleID)
+	require.Equal(t, fundingParams.CycleDuration, current.TimeRemaining)
+	require.False(t, current.Frozen)
+	require.True(t, budget.AddRaw(60).Equal(current.EstimatedBudget))
+
+	frozenCtx := ctx.WithBlockHeader(abci.Header{Time: current.FreezeStartTime.Add(time.Second)})
+	bz, err = querier(frozenCtx, []string{QueryCurrentCycle}, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &current)
+	require.True(t, current.Frozen)
+	require.Equal(t, fundingParams.FreezeWindow-time.Second, current.TimeRemaining)
+
+	// the report of a cycle lists the amounts paid to its funded proposals
+	cycle, _ := keeper.GetFundingCycle(ctx, 3)
+	cycle.Budget = sdk.NewInt(100)
+	cycle.Spent = sdk.NewInt(40)
+	cycle.Carried = sdk.NewInt(60)
+	keeper.SetFundingCycle(ctx, cycle)
+	content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 50)}, 1, addrs[0])
+	ranking := NewRanking(3, []Proposal{{ProposalID: 1, ProposalContent: content}, {ProposalID: 2, ProposalContent: content}},
+		[]TallyResult{EmptyTallyResult(), EmptyTallyResult()})
+	ranking.Proposals[1].Funded = true
+	ranking.Proposals[1].FundedAmount = sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 40)}
+	keeper.SetRanking(ctx, ranking)
+
+	var report FundingCycleReport
+	bz, err = querier(ctx, []string{QueryCycleReport}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryFuncingCycleParams(3)),
+	})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &report)
+	require.True(t, report.Budget.Equal(sdk.NewInt(100)))
+	require.True(t, report.Spent.Equal(sdk.NewInt(40)))
+	require.Equal(t, []FundedProposal{{2, sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 40)}}}, report.FundedProposals)
+	_, err = querier(ctx, []string{QueryCycleReport}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryFuncingCycleParams(20)),
+	})
+	require.NotNil(t, err)
+
+	// eligible proposals are listed in ranking order, not eligible ones are left out
+	for _, rank := range []int64{2, 0, 1, 3} {
+		proposalID, err := keeper.getNewProposalID(ctx)
+		require.Nil(t, err)
+		keeper.SetProposal(ctx, Proposal{
+			ProposalContent:  content,
+			ProposalID:       proposalID,
+			Status:           StatusVotingPeriod,
+			FinalTallyResult: EmptyTallyResult(),
+			Ranking:          sdk.NewInt(rank),
+		})
+	}
+	var eligible RankedProposals
+	bz, err = querier(ctx, []string{QueryEligibility}, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &eligible)
+	require.Equal(t, 3, len(eligible))
+	require.Equal(t, []uint64{1, 2, 3}, []uint64{eligible[0].Rank, eligible[1].Rank, eligible[2].Rank})
+	require.Equal(t, uint64(1), eligible[1].ProposalID)
+	bz, err = querier(ctx, []string{QueryEligibility}, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(NewQueryPageParams(2, 2)),
+	})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &eligible)
+	require.Equal(t, 1, len(eligible))
+	require.Equal(t, uint64(4), eligible[0].ProposalID)
+}
@@ -15,14 +15,20 @@ const (
 
 // GenesisState - all staking state that must be provided at genesis
 type GenesisState struct {
-	StartingProposalID     uint64                `json:"starting_proposal_id"`
-	StartingFundingCycleID uint64                `json:"starting_funding_id"`
-	Deposits               []DepositWithMetadata `json:"deposits"`
-	Votes                  []VoteWithMetadata    `json:"votes"`
-	Proposals              []Proposal            `json:"proposals"`
-	DepositParams          DepositParams         `json:"deposit_params"`
-	VotingParams           VotingParams          `json:"voting_params"`
-	TallyParams            TallyParams           `json:"tally_params"`
+	StartingProposalID     uint64                      `json:"starting_proposal_id"`
+	StartingFundingCycleID uint64                      `json:"starting_funding_id"`
+	Deposits               []DepositWithMetadata       `json:"deposits"`
+	Votes                  []VoteWithMetadata          `json:"votes"`
+	Proposals              []Proposal                  `json:"proposals"`
+	FundingCycles          []FundingCycle              `json:"funding_cycles"`
+	Milestones             []Milestone                 `json:"milestones"`
+	MilestoneVotes         []MilestoneVoteWithMetadata `json:"milestone_votes"`
+	CouncilSnapshots       []CouncilSnapshot           `json:"council_snapshots"`
+	CouncilVoteDelegations []CouncilVoteDelegation     `json:"council_vote_delegations"`
+	DepositParams          DepositParams               `json:"deposit_params"`
+	VotingParams           VotingParams                `json:"voting_params"`
+	TallyParams            TallyParams                 `json:"tally_params"`
+	FundingParams          FundingParams               `json:"funding_params"`
 }
 
 // DepositWithMetadata (just for genesis)
@@ -37,13 +43,14 @@ type VoteWithMetadata struct {
 	Vote       Vote   `json:"vote"`
 }
 
-func NewGenesisState(startingProposalID uint64, startingFundingCycleID uint64, dp DepositParams, vp VotingParams, tp TallyParams) GenesisState {
+func NewGenesisState(startingProposalID uint64, startingFundingCycleID uint64, dp DepositParams, vp VotingParams, tp TallyParams, fp FundingParams) GenesisState {
 	return GenesisState{
 		StartingProposalID:     startingProposalID,
 		StartingFundingCycleID: startingFundingCycleID,
 		DepositParams:          dp,
 		VotingParams:           vp,
 		TallyParams:            tp,
+		FundingParams:          fp,
 	}
 }
 
@@ -56,6 +63,7 @@ func DefaultGenesisState() GenesisState {
 		DepositParams: DepositParams{
 			MinDeposit:       sdk.Coins{sdk.NewCoin(sdk.DefaultBondDenom, minDepositTokens)},
 			MaxDepositPeriod: DefaultPeriod,
+			DroppedDeposits:  DroppedDepositsBurn,
 		},
 		VotingParams: VotingParams{
 			VotingPeriod: DefaultPeriod,
@@ -64,6 +72,13 @@ func DefaultGenesisState() GenesisState {
 			Quorum:    sdk.NewDecWithPrec(150, 3),
 			Threshold: sdk.NewDecWithPrec(5, 2),
 		},
+		FundingParams: FundingParams{
+			CycleDuration: DefaultCycleDuration,
+			FreezeWindow:  DefaultFreezeWindow,
+			MaxCycleCount: DefaultMaxCycleCount,
+			TreasuryShare: sdk.NewDecWithPrec(5, 1),
+			Policy:        FundingPolicySkip,
+		},
 	}
 }
 
@@ -82,17 +97,56 @@ func (data GenesisState) IsEmpty() bool {
 
 // ValidateGenesis
 func ValidateGenesis(data GenesisState) error {
-	threshold := data.TallyParams.Threshold
-	if threshold.IsNegative() || threshold.GT(sdk.OneDec()) {
-		return fmt.Errorf("Governance vote threshold should be positive and less or equal to one, is %s",
-			threshold.String())
+	if err := data.TallyParams.Validate(); err != nil {
+		return err
+	}
+	if err := data.DepositParams.Validate(); err != nil {
+		return err
+	}
+	if err := data.FundingParams.Validate(); err != nil {
+		return err
 	}
 
-	if !data.DepositParams.MinDeposit.IsValid() {
-		return fmt.Errorf("Governance deposit amount must be a valid sdk.Coins amount, is %s",
-			data.DepositParams.MinDeposit.String())
+	return validateFundingCycles(data.StartingFundingCycleID, data.FundingCycles)
+}
+
+func validateFundingParams(fp FundingParams) error {
+	if fp.CycleDuration <= 0 {
+		return fmt.Errorf("Governance funding cycle duration must be positive, is %s", fp.CycleDuration)
+	}
+	if fp.FreezeWindow < 0 || fp.FreezeWindow >= fp.CycleDuration {
+		return fmt.Errorf("Governance funding freeze window must be positive and shorter than the cycle duration, is %s",
+			fp.FreezeWindow)
 	}
+	if fp.MaxCycleCount == 0 {
+		return fmt.Errorf("Governance max funding cycle count must be positive")
+	}
+	share := fp.TreasuryShare
+	if share.IsNil() || !share.IsPositive() || share.GT(sdk.OneDec()) {
+		return fmt.Errorf("Governance treasury share should be positive and less or equal to one, is %s", share)
+	}
+	if !fp.Policy.IsValid() {
+		return fmt.Errorf("Governance funding policy %q is not valid", fp.Policy)
+	}
+	return nil
+}
 
+func validateFundingCycles(startingFundingCycleID uint64, fundingCycles []FundingCycle) error {
+	for _, cycle := range fundingCycles {
+		if cycle.CycleID >= startingFundingCycleID {
+			return fmt.Errorf("Governance funding cycle %d is not below the starting funding cycle ID %d",
+				cycle.CycleID, startingFundingCycleID)
+		}
+		for _, amount := range []sdk.Int{cycle.Budget, cycle.Spent, cycle.Carried} {
+			if (amount == sdk.Int{}) || amount.IsNegative() {
+				return fmt.Errorf("Governance funding cycle %d has an invalid budget", cycle.CycleID)
+			}
+		}
+		if cycle.Spent.Add(cycle.Carried).GT(cycle.Budget) {
+			return fmt.Errorf("Governance funding cycle %d spends and carries more than its budget %s",
+				cycle.CycleID, cycle.Budget)
+		}
+	}
 	return nil
 }
 
@@ -107,6 +161,7 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	k.setDepositParams(ctx, data.DepositParams)
 	k.setVotingParams(ctx, data.VotingParams)
 	k.setTallyParams(ctx, data.TallyParams)
+	k.setFundingParams(ctx, data.FundingParams)
 	for _, deposit := range data.Deposits {
 		k.setDeposit(ctx, deposit.ProposalID, deposit.Deposit.Depositor, deposit.Deposit)
 	}
@@ -122,16 +177,36 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 		}
 		k.SetProposal(ctx, proposal)
 	}
+	for _, fundingCycle := range data.FundingCycles {
+		k.SetFundingCycle(ctx, fundingCycle)
+	}
+	for _, milestone := range data.Milestones {
+		k.SetMilestone(ctx, milestone)
+	}
+	for _, vote := range data.MilestoneVotes {
+		k.setMilestoneVote(ctx, vote.Index, vote.Vote)
+	}
+	for _, snapshot := range data.CouncilSnapshots {
+		k.SetCouncilSnapshot(ctx, snapshot)
+	}
+	for _, delegation := range data.CouncilVoteDelegations {
+		k.setCouncilProxy(ctx, delegation.Delegator, delegation.Proxy)
+	}
 }
 
 // ExportGenesis - output genesis parameters
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 	startingProposalID, _ := k.peekCurrentProposalID(ctx)
+	startingFundingCycleID, _ := k.peekCurrentFundingCycleID(ctx)
 	depositParams := k.GetDepositParams(ctx)
 	votingParams := k.GetVotingParams(ctx)
 	tallyParams := k.GetTallyParams(ctx)
+	fundingParams := k.GetFundingParams(ctx)
 	var deposits []DepositWithMetadata
 	var votes []VoteWithMetadata
+	var milestones []Milestone
+	var milestoneVotes []MilestoneVoteWithMetadata
+	var councilSnapshots []CouncilSnapshot
 	proposals := k.GetProposalsFiltered(ctx, nil, nil, StatusNil, 0)
 	for _, proposal := range proposals {
 		proposalID := proposal.ProposalID
@@ -149,15 +224,35 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 			k.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), &vote)
 			votes = append(votes, VoteWithMetadata{proposalID, vote})
 		}
+		if snapshot, found := k.GetCouncilSnapshot(ctx, proposalID); found {
+			councilSnapshots = append(councilSnapshots, snapshot)
+		}
+		for _, milestone := range k.GetMilestones(ctx, proposalID) {
+			milestones = append(milestones, milestone)
+			milestoneVotesIterator := k.GetMilestoneVotes(ctx, proposalID, milestone.Index)
+			defer milestoneVotesIterator.Close()
+			for ; milestoneVotesIterator.Valid(); milestoneVotesIterator.Next() {
+				var vote Vote
+				k.cdc.MustUnmarshalBinaryLengthPrefixed(milestoneVotesIterator.Value(), &vote)
+				milestoneVotes = append(milestoneVotes, MilestoneVoteWithMetadata{milestone.Index, vote})
+			}
+		}
 	}
 
 	return GenesisState{
-		StartingProposalID: startingProposalID,
-		Deposits:           deposits,
-		Votes:              votes,
-		Proposals:          proposals,
-		DepositParams:      depositParams,
-		VotingParams:       votingParams,
-		TallyParams:        tallyParams,
+		StartingProposalID:     startingProposalID,
+		StartingFundingCycleID: startingFundingCycleID,
+		Deposits:               deposits,
+		Votes:                  votes,
+		Proposals:              proposals,
+		FundingCycles:          k.GetAllFundingCycle(ctx),
+		Milestones:             milestones,
+		MilestoneVotes:         milestoneVotes,
+		CouncilSnapshots:       councilSnapshots,
+		CouncilVoteDelegations: k.GetCouncilVoteDelegations(ctx),
+		DepositParams:          depositParams,
+		VotingParams:           votingParams,
+		TallyParams:            tallyParams,
+		FundingParams:          fundingParams,
 	}
 }
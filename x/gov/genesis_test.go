@@ -2,10 +2,13 @@ package gov
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/ColorPlatform/prism/abci/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
 func TestEqualProposalID(t *testing.T) {
@@ -126,3 +129,84 @@ func TestImportExportQueues(t *testing.T) {
 	require.True(t, ok)
 	require.True(t, proposal2.Status == StatusRejected)
 }
+
+func TestValidateGenesisFundingParams(t *testing.T) {
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	tests := []func(*FundingParams){
+		func(fp *FundingParams) { fp.CycleDuration = 0 },
+		func(fp *FundingParams) { fp.FreezeWindow = -time.Second },
+		func(fp *FundingParams) { fp.FreezeWindow = fp.CycleDuration },
+		func(fp *FundingParams) { fp.MaxCycleCount = 0 },
+		func(fp *FundingParams) { fp.TreasuryShare = sdk.ZeroDec() },
+		func(fp *FundingParams) { fp.TreasuryShare = sdk.NewDecWithPrec(11, 1) },
+		func(fp *FundingParams) { fp.Policy = FundingPolicy("spend_all") },
+	}
+
+	for i, malleate := range tests {
+		genState := DefaultGenesisState()
+		malleate(&genState.FundingParams)
+		require.NotNil(t, ValidateGenesis(genState), "test: %v", i)
+	}
+}
+
+func TestImportExportFundingCycles(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	keeper.AddFundingCycle(ctx)
+	fundingCycle, err := keeper.GetCurrentCycle(ctx)
+	require.Nil(t, err)
+	fundingCycle.FundedProposals = []uint64{1}
+	fundingCycle.Budget = sdk.NewInt(100)
+	fundingCycle.Spent = sdk.NewInt(60)
+	fundingCycle.Carried = sdk.NewInt(40)
+	keeper.SetFundingCycle(ctx, fundingCycle)
+
+	genState := ExportGenesis(ctx, keeper)
+	require.Equal(t, uint64(1), genState.StartingFundingCycleID)
+	require.Equal(t, []FundingCycle{fundingCycle}, genState.FundingCycles)
+	require.Nil(t, ValidateGenesis(genState))
+
+	genAccs := mapp.AccountKeeper.GetAllAccounts(ctx)
+	mapp2, keeper2, _, _, _, _ := getMockApp(t, 2, genState, genAccs)
+
+	header = abci.Header{Height: mapp2.LastBlockHeight() + 1}
+	mapp2.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx2 := mapp2.BaseApp.NewContext(false, abci.Header{})
+
+	imported, err := keeper2.GetCurrentCycle(ctx2)
+	require.Nil(t, err)
+	require.Equal(t, fundingCycle, imported)
+	require.Equal(t, sdk.NewInt(40), keeper2.getCarriedBudget(ctx2, 1))
+}
+
+func TestValidateGenesisFundingCycles(t *testing.T) {
+	fundingCycle := NewFundingCycle(0, time.Unix(0, 0).UTC(), time.Unix(0, 0).UTC().Add(DefaultCycleDuration))
+	fundingCycle.Budget = sdk.NewInt(100)
+	fundingCycle.Spent = sdk.NewInt(60)
+	fundingCycle.Carried = sdk.NewInt(40)
+
+	genState := DefaultGenesisState()
+	genState.StartingFundingCycleID = 1
+	genState.FundingCycles = []FundingCycle{fundingCycle}
+	require.Nil(t, ValidateGenesis(genState))
+
+	genState.StartingFundingCycleID = 0
+	require.NotNil(t, ValidateGenesis(genState))
+
+	genState.StartingFundingCycleID = 1
+	genState.FundingCycles[0].Carried = sdk.NewInt(41)
+	require.NotNil(t, ValidateGenesis(genState))
+
+	genState.FundingCycles[0].Carried = sdk.NewInt(-1)
+	require.NotNil(t, ValidateGenesis(genState))
+
+	genState.FundingCycles[0].Carried = sdk.Int{}
+	require.NotNil(t, ValidateGenesis(genState))
+}
@@ -17,6 +17,14 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSubmitProposal(ctx, keeper, msg)
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+		case MsgReportMilestone:
+			return handleMsgReportMilestone(ctx, keeper, msg)
+		case MsgVoteMilestone:
+			return handleMsgVoteMilestone(ctx, keeper, msg)
+		case MsgDelegateCouncilVote:
+			return handleMsgDelegateCouncilVote(ctx, keeper, msg)
+		case MsgRevokeCouncilVote:
+			return handleMsgRevokeCouncilVote(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized gov msg type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -26,14 +34,23 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 	var content ProposalContent
-	if ExpectedTreasureIncome(keeper, ctx, msg.RequestedFund.AmountOf(sdk.DefaultBondDenom)) {
+	if msg.ProposalType.RequestsFunds() &&
+		ExpectedTreasureIncome(keeper, ctx, msg.RequestedFund.AmountOf(sdk.DefaultBondDenom)) {
 		return ErrInvalidTreasureIncome(keeper.codespace, msg.ProposalType).Result()
 	}
 	switch msg.ProposalType {
 	case ProposalTypeText:
 		content = NewTextProposal(msg.Title, msg.Description, msg.RequestedFund, msg.FundingCycle, msg.Proposer)
 	case ProposalTypeSoftwareUpgrade:
-		content = NewSoftwareUpgradeProposal(msg.Title, msg.Description, msg.RequestedFund, msg.FundingCycle, msg.Proposer)
+		if err := keeper.ValidateUpgradePlan(ctx, *msg.Plan); err != nil {
+			return err.Result()
+		}
+		content = NewSoftwareUpgradeProposal(msg.Title, msg.Description, *msg.Plan, msg.Proposer)
+	case ProposalTypeParameterChange:
+		if err := keeper.ValidateParamChanges(ctx, msg.Changes); err != nil {
+			return err.Result()
+		}
+		content = NewParameterChangeProposal(msg.Title, msg.Description, msg.Changes, msg.Proposer)
 	default:
 		return ErrInvalidProposalType(keeper.codespace, msg.ProposalType).Result()
 	}
@@ -43,6 +60,7 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 	}
 	proposalID := proposal.ProposalID
 	proposalIDStr := fmt.Sprintf("%d", proposalID)
+	keeper.AddMilestones(ctx, proposalID, msg.Milestones)
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposalID, msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -98,3 +116,59 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 		),
 	}
 }
+
+func handleMsgReportMilestone(ctx sdk.Context, keeper Keeper, msg MsgReportMilestone) sdk.Result {
+	milestone, err := keeper.ReportMilestone(ctx, msg.ProposalID, msg.Proposer, msg.ReportHash)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Proposer, msg.Proposer.String(),
+			tags.ProposalID, fmt.Sprintf("%d", msg.ProposalID),
+			tags.MilestoneIndex, fmt.Sprintf("%d", milestone.Index),
+		),
+	}
+}
+
+func handleMsgVoteMilestone(ctx sdk.Context, keeper Keeper, msg MsgVoteMilestone) sdk.Result {
+	err := keeper.AddMilestoneVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Voter, msg.Voter.String(),
+			tags.ProposalID, fmt.Sprintf("%d", msg.ProposalID),
+		),
+	}
+}
+
+func handleMsgDelegateCouncilVote(ctx sdk.Context, keeper Keeper, msg MsgDelegateCouncilVote) sdk.Result {
+	err := keeper.DelegateCouncilVote(ctx, msg.Delegator, msg.Proxy)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, msg.Delegator.String(),
+			tags.Proxy, msg.Proxy.String(),
+		),
+	}
+}
+
+func handleMsgRevokeCouncilVote(ctx sdk.Context, keeper Keeper, msg MsgRevokeCouncilVote) sdk.Result {
+	err := keeper.RevokeCouncilVote(ctx, msg.Delegator)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, msg.Delegator.String(),
+		),
+	}
+}
@@ -0,0 +1,123 @@
+package gov
+
+import (
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// GovHooks are called by the governance keeper along the lifecycle of proposals
+// and funding cycles. Other modules implement it to follow proposals without
+// polling the governance store.
+type GovHooks interface {
+	AfterProposalSubmission(ctx sdk.Context, proposalID uint64)                            // Must be called after a proposal is submitted
+	AfterProposalDeposit(ctx sdk.Context, proposalID uint64, depositorAddr sdk.AccAddress) // Must be called after a deposit is made
+	AfterProposalActivated(ctx sdk.Context, proposalID uint64)                             // Must be called after a proposal enters its voting period
+	AfterProposalVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress)        // Must be called after a council vote is cast
+	AfterProposalFunded(ctx sdk.Context, proposalID uint64, amount sdk.Coins)              // Must be called after a proposal is paid out
+	AfterProposalDropped(ctx sdk.Context, proposalID uint64)                               // Must be called after a proposal is dropped, rejected or cancelled
+	AfterFundingCycleStarted(ctx sdk.Context, cycleID uint64)                              // Must be called after a funding cycle starts
+}
+
+var _ GovHooks = MultiGovHooks{}
+
+// MultiGovHooks combines the hooks of several modules, called in order
+type MultiGovHooks []GovHooks
+
+// NewMultiGovHooks combines the given hooks
+func NewMultiGovHooks(hooks ...GovHooks) MultiGovHooks {
+	return hooks
+}
+
+// nolint
+func (h MultiGovHooks) AfterProposalSubmission(ctx sdk.Context, proposalID uint64) {
+	for i := range h {
+		h[i].AfterProposalSubmission(ctx, proposalID)
+	}
+}
+func (h MultiGovHooks) AfterProposalDeposit(ctx sdk.Context, proposalID uint64, depositorAddr sdk.AccAddress) {
+	for i := range h {
+		h[i].AfterProposalDeposit(ctx, proposalID, depositorAddr)
+	}
+}
+func (h MultiGovHooks) AfterProposalActivated(ctx sdk.Context, proposalID uint64) {
+	for i := range h {
+		h[i].AfterProposalActivated(ctx, proposalID)
+	}
+}
+func (h MultiGovHooks) AfterProposalVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress) {
+	for i := range h {
+		h[i].AfterProposalVote(ctx, proposalID, voterAddr)
+	}
+}
+func (h MultiGovHooks) AfterProposalFunded(ctx sdk.Context, proposalID uint64, amount sdk.Coins) {
+	for i := range h {
+		h[i].AfterProposalFunded(ctx, proposalID, amount)
+	}
+}
+func (h MultiGovHooks) AfterProposalDropped(ctx sdk.Context, proposalID uint64) {
+	for i := range h {
+		h[i].AfterProposalDropped(ctx, proposalID)
+	}
+}
+func (h MultiGovHooks) AfterFundingCycleStarted(ctx sdk.Context, cycleID uint64) {
+	for i := range h {
+		h[i].AfterFundingCycleStarted(ctx, cycleID)
+	}
+}
+
+// SetHooks sets the governance hooks
+func (keeper *Keeper) SetHooks(gh GovHooks) *Keeper {
+	if keeper.hooks != nil {
+		panic("cannot set governance hooks twice")
+	}
+	keeper.hooks = gh
+	return keeper
+}
+
+// AfterProposalSubmission - call hook if registered
+func (keeper Keeper) AfterProposalSubmission(ctx sdk.Context, proposalID uint64) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterProposalSubmission(ctx, proposalID)
+	}
+}
+
+// AfterProposalDeposit - call hook if registered
+func (keeper Keeper) AfterProposalDeposit(ctx sdk.Context, proposalID uint64, depositorAddr sdk.AccAddress) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterProposalDeposit(ctx, proposalID, depositorAddr)
+	}
+}
+
+// AfterProposalActivated - call hook if registered
+func (keeper Keeper) AfterProposalActivated(ctx sdk.Context, proposalID uint64) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterProposalActivated(ctx, proposalID)
+	}
+}
+
+// AfterProposalVote - call hook if registered
+func (keeper Keeper) AfterProposalVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterProposalVote(ctx, proposalID, voterAddr)
+	}
+}
+
+// AfterProposalFunded - call hook if registered
+func (keeper Keeper) AfterProposalFunded(ctx sdk.Context, proposalID uint64, amount sdk.Coins) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterProposalFunded(ctx, proposalID, amount)
+	}
+}
+
+// AfterProposalDropped - call hook if registered
+func (keeper Keeper) AfterProposalDropped(ctx sdk.Context, proposalID uint64) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterProposalDropped(ctx, proposalID)
+	}
+}
+
+// AfterFundingCycleStarted - call hook if registered
+func (keeper Keeper) AfterFundingCycleStarted(ctx sdk.Context, cycleID uint64) {
+	if keeper.hooks != nil {
+		keeper.hooks.AfterFundingCycleStarted(ctx, cycleID)
+	}
+}
@@ -0,0 +1,118 @@
+package gov
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/mint"
+	"github.com/ColorPlatform/color-sdk/x/staking"
+)
+
+var _ GovHooks = &mockGovHooks{}
+
+type mockGovHooks struct {
+	submitted     []uint64
+	deposited     []uint64
+	activated     []uint64
+	voted         []uint64
+	funded        map[uint64]sdk.Coins
+	dropped       []uint64
+	cyclesStarted []uint64
+}
+
+func (h *mockGovHooks) AfterProposalSubmission(ctx sdk.Context, proposalID uint64) {
+	h.submitted = append(h.submitted, proposalID)
+}
+func (h *mockGovHooks) AfterProposalDeposit(ctx sdk.Context, proposalID uint64, depositorAddr sdk.AccAddress) {
+	h.deposited = append(h.deposited, proposalID)
+}
+func (h *mockGovHooks) AfterProposalActivated(ctx sdk.Context, proposalID uint64) {
+	h.activated = append(h.activated, proposalID)
+}
+func (h *mockGovHooks) AfterProposalVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress) {
+	h.voted = append(h.voted, proposalID)
+}
+func (h *mockGovHooks) AfterProposalFunded(ctx sdk.Context, proposalID uint64, amount sdk.Coins) {
+	h.funded[proposalID] = amount
+}
+func (h *mockGovHooks) AfterProposalDropped(ctx sdk.Context, proposalID uint64) {
+	h.dropped = append(h.dropped, proposalID)
+}
+func (h *mockGovHooks) AfterFundingCycleStarted(ctx sdk.Context, cycleID uint64) {
+	h.cyclesStarted = append(h.cyclesStarted, cycleID)
+}
+
+func TestGovHooks(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+	hooks := &mockGovHooks{funded: make(map[uint64]sdk.Coins)}
+	keeper.SetHooks(NewMultiGovHooks(hooks))
+	require.Panics(t, func() { keeper.SetHooks(hooks) })
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0).UTC()})
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[0], sdk.NewDec(10)))
+
+	keeper.AddFundingCycle(ctx)
+	require.Equal(t, []uint64{0}, hooks.cyclesStarted)
+
+	// proposals left in the inactive queue are dropped
+	proposalID, err := keeper.getNewProposalID(ctx)
+	require.Nil(t, err)
+	tp := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, addrs[0])
+	dropped := Proposal{ProposalContent: tp, ProposalID: proposalID, Status: StatusDepositPeriod, DepositEndTime: ctx.BlockHeader().Time}
+	keeper.SetProposal(ctx, dropped)
+	keeper.InsertInactiveProposalQueue(ctx, dropped.DepositEndTime, dropped.ProposalID)
+	UpdateInactiveProposals(ctx, keeper, sdk.NewTags())
+	require.Equal(t, []uint64{dropped.ProposalID}, hooks.dropped)
+
+	proposal, err := keeper.SubmitProposal(ctx, tp)
+	require.NoError(t, err)
+	err, _ = keeper.AddDeposit(ctx, proposal.ProposalID, addrs[1], sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)})
+	require.Nil(t, err)
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.NotNil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionYes))
+	require.Equal(t, []uint64{proposal.ProposalID}, hooks.submitted)
+	require.Equal(t, []uint64{proposal.ProposalID}, hooks.deposited)
+	require.Equal(t, []uint64{proposal.ProposalID}, hooks.voted)
+
+	// proposals submitted during a funding cycle enter their voting period at once,
+	// the others when the first funding cycle starts
+	require.Equal(t, []uint64{proposal.ProposalID}, hooks.activated)
+	waitingID, err := keeper.getNewProposalID(ctx)
+	require.Nil(t, err)
+	waiting := Proposal{ProposalContent: tp, ProposalID: waitingID, Status: StatusDepositPeriod, DepositEndTime: ctx.BlockHeader().Time}
+	keeper.SetProposal(ctx, waiting)
+	keeper.InsertInactiveProposalQueue(ctx, waiting.DepositEndTime, waiting.ProposalID)
+	keeper.RemoveFromInactiveProposalQueueIterator(ctx)
+	require.Equal(t, []uint64{proposal.ProposalID, waitingID}, hooks.activated)
+
+	// proposals rejected by the council, or whose funding is cancelled, are dropped
+	require.Nil(t, keeper.AddVote(ctx, waitingID, addrs[0], OptionNo))
+	pendingID, err := keeper.getNewProposalID(ctx)
+	require.Nil(t, err)
+	pending := Proposal{
+		ProposalContent:       NewTextProposal("Test", "test", tp.RequestedFund, 3, addrs[0]),
+		ProposalID:            pendingID,
+		RemainingFundingCycle: 2,
+		FundingCycleCount:     1,
+	}
+	keeper.SetProposal(ctx, pending)
+	keeper.AddMilestones(ctx, pendingID, []string{"first release", "second release"})
+	keeper.activateVotingPeriod(ctx, pending)
+	require.Nil(t, keeper.AddVote(ctx, pendingID, addrs[0], OptionYes))
+
+	keeper.distrKeeper.SetCommunityTax(ctx, sdk.NewDecWithPrec(2, 2))
+	keeper.minKeeper.SetMinter(ctx, mint.NewMinter(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), time.Time{}, time.Time{}))
+	ExecuteProposal(ctx, keeper, sdk.NewTags())
+	require.Equal(t, []uint64{dropped.ProposalID, waitingID, pendingID}, hooks.dropped)
+	waiting, _ = keeper.GetProposal(ctx, waitingID)
+	require.Equal(t, StatusRejected, waiting.Status)
+	pending, _ = keeper.GetProposal(ctx, pendingID)
+	require.Equal(t, StatusRejected, pending.Status)
+}
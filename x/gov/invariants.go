@@ -0,0 +1,116 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// register all governance invariants
+func RegisterInvariants(c CrisisKeeper, keeper Keeper) {
+	c.RegisterRoute(ModuleName, "deposits",
+		DepositsInvariant(keeper))
+	c.RegisterRoute(ModuleName, "funding-cycles",
+		FundingCyclesInvariant(keeper))
+	c.RegisterRoute(ModuleName, "proposal-queues",
+		ProposalQueuesInvariant(keeper))
+}
+
+// AllInvariants runs all invariants of the governance module.
+func AllInvariants(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		err := DepositsInvariant(keeper)(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = FundingCyclesInvariant(keeper)(ctx)
+		if err != nil {
+			return err
+		}
+
+		return ProposalQueuesInvariant(keeper)(ctx)
+	}
+}
+
+// DepositsInvariant checks that the deposit account holds exactly the sum of
+// the stored deposits
+func DepositsInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		store := ctx.KVStore(keeper.storeKey)
+		iterator := sdk.KVStorePrefixIterator(store, PrefixDeposits)
+		defer iterator.Close()
+
+		deposited := sdk.Coins{}
+		for ; iterator.Valid(); iterator.Next() {
+			var deposit Deposit
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &deposit)
+			deposited = deposited.Add(deposit.Amount)
+		}
+
+		held := keeper.ck.GetCoins(ctx, DepositedCoinsAccAddr)
+		if !held.IsEqual(deposited) {
+			return fmt.Errorf("deposits invariance:\n"+
+				"\tcoins of the deposit account: %v\n"+
+				"\tsum of deposits: %v", held, deposited)
+		}
+		return nil
+	}
+}
+
+// FundingCyclesInvariant checks that no funding cycle spent and carried more
+// than its treasury budget, and that the amounts paid to the funded proposals
+// of its ranking add up to the amount spent
+func FundingCyclesInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		for _, cycle := range keeper.GetAllFundingCycle(ctx) {
+			if cycle.Spent.IsNegative() || cycle.Carried.IsNegative() ||
+				cycle.Spent.Add(cycle.Carried).GT(cycle.Budget) {
+
+				return fmt.Errorf("funding cycle budget invariance for cycle %d:\n"+
+					"\tbudget: %v\n"+
+					"\tspent: %v\n"+
+					"\tcarried: %v", cycle.CycleID, cycle.Budget, cycle.Spent, cycle.Carried)
+			}
+
+			ranking, found := keeper.GetRanking(ctx, cycle.CycleID)
+			if !found {
+				continue
+			}
+			funded := sdk.ZeroInt()
+			for _, ranked := range ranking.Proposals {
+				if ranked.Funded {
+					funded = funded.Add(ranked.FundedAmount.AmountOf(sdk.DefaultBondDenom))
+				}
+			}
+			if !funded.Equal(cycle.Spent) {
+				return fmt.Errorf("funding cycle payout invariance for cycle %d:\n"+
+					"\tspent: %v\n"+
+					"\tsum of funded amounts: %v", cycle.CycleID, cycle.Spent, funded)
+			}
+		}
+		return nil
+	}
+}
+
+// ProposalQueuesInvariant checks that the active and inactive proposal queues
+// only reference existing proposals
+func ProposalQueuesInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		store := ctx.KVStore(keeper.storeKey)
+		for _, prefix := range [][]byte{PrefixActiveProposalQueue, PrefixInactiveProposalQueue} {
+			iterator := sdk.KVStorePrefixIterator(store, prefix)
+			for ; iterator.Valid(); iterator.Next() {
+				var proposalID uint64
+				keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &proposalID)
+				if _, ok := keeper.GetProposal(ctx, proposalID); !ok {
+					iterator.Close()
+					return fmt.Errorf("proposal queue invariance:\n"+
+						"\tproposal %d in queue %s does not exist", proposalID, prefix)
+				}
+			}
+			iterator.Close()
+		}
+		return nil
+	}
+}
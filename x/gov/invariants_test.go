@@ -0,0 +1,94 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+func TestDepositsInvariant(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.AddFundingCycle(ctx)
+	require.NoError(t, DepositsInvariant(keeper)(ctx))
+
+	proposal, err := keeper.SubmitProposal(ctx, testProposal())
+	require.NoError(t, err)
+	fourStake := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.TokensFromTendermintPower(4)))
+	err, _ = keeper.AddDeposit(ctx, proposal.ProposalID, addrs[0], fourStake)
+	require.Nil(t, err)
+	err, _ = keeper.AddDeposit(ctx, proposal.ProposalID, addrs[1], fourStake)
+	require.Nil(t, err)
+	require.NoError(t, DepositsInvariant(keeper)(ctx))
+
+	// coins sent to the deposit account without a deposit break the invariant
+	_, err = keeper.ck.SendCoins(ctx, addrs[0], DepositedCoinsAccAddr, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+	require.Nil(t, err)
+	require.Error(t, DepositsInvariant(keeper)(ctx))
+}
+
+func TestFundingCyclesInvariant(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.AddFundingCycle(ctx)
+	require.NoError(t, FundingCyclesInvariant(keeper)(ctx))
+
+	cycle, err := keeper.GetCurrentCycle(ctx)
+	require.Nil(t, err)
+	cycle.Budget = sdk.NewInt(10)
+	cycle.Spent = sdk.NewInt(5)
+	cycle.Carried = sdk.NewInt(5)
+	keeper.SetFundingCycle(ctx, cycle)
+	require.NoError(t, FundingCyclesInvariant(keeper)(ctx))
+
+	// the ranking must pay out the amount spent
+	content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 1, nil)
+	ranking := NewRanking(cycle.CycleID, []Proposal{{ProposalID: 1, ProposalContent: content}},
+		[]TallyResult{NewTallyResult(sdk.NewInt(1), sdk.NewInt(0), sdk.NewInt(0))})
+	keeper.SetRanking(ctx, ranking)
+	require.Error(t, FundingCyclesInvariant(keeper)(ctx))
+
+	ranking.Proposals[0].Funded = true
+	ranking.Proposals[0].FundedAmount = content.RequestedFund
+	keeper.SetRanking(ctx, ranking)
+	require.NoError(t, FundingCyclesInvariant(keeper)(ctx))
+
+	// a cycle cannot spend more than its budget
+	cycle.Carried = sdk.NewInt(6)
+	keeper.SetFundingCycle(ctx, cycle)
+	require.Error(t, FundingCyclesInvariant(keeper)(ctx))
+}
+
+func TestProposalQueuesInvariant(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.AddFundingCycle(ctx)
+	proposal, err := keeper.SubmitProposal(ctx, testProposal())
+	require.NoError(t, err)
+	require.NoError(t, ProposalQueuesInvariant(keeper)(ctx))
+
+	keeper.InsertActiveProposalQueue(ctx, proposal.DepositEndTime, proposal.ProposalID+1)
+	require.Error(t, ProposalQueuesInvariant(keeper)(ctx))
+	keeper.RemoveFromActiveProposalQueue(ctx, proposal.DepositEndTime, proposal.ProposalID+1)
+	require.NoError(t, AllInvariants(keeper)(ctx))
+
+	// deleting a proposal removes it from its queue
+	keeper.DeleteProposal(ctx, proposal.ProposalID)
+	require.NoError(t, ProposalQueuesInvariant(keeper)(ctx))
+}
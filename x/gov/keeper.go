@@ -7,8 +7,10 @@ import (
 	codec "github.com/ColorPlatform/color-sdk/codec"
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	distr "github.com/ColorPlatform/color-sdk/x/distribution"
+	"github.com/ColorPlatform/color-sdk/x/gov/tags"
 	"github.com/ColorPlatform/color-sdk/x/mint"
 	"github.com/ColorPlatform/color-sdk/x/params"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 
 	"github.com/ColorPlatform/prism/crypto"
 )
@@ -32,15 +34,16 @@ const (
 
 // Parameter store key
 var (
-	ParamStoreKeyDepositParams      = []byte("depositparams")
-	ParamStoreKeyVotingParams       = []byte("votingparams")
-	ParamStoreKeyTallyParams        = []byte("tallyparams")
-	ParamStoreKeyFundingCycleParams = []byte("fundingcycleprams")
+	ParamStoreKeyDepositParams = []byte("depositparams")
+	ParamStoreKeyVotingParams  = []byte("votingparams")
+	ParamStoreKeyTallyParams   = []byte("tallyparams")
+	ParamStoreKeyFundingParams = []byte("fundingparams")
 
 	// TODO: Find another way to implement this without using accounts, or find a cleaner way to implement it using accounts.
-	DepositedCoinsAccAddr     = sdk.AccAddress(crypto.AddressHash([]byte("govDepositedCoins")))
+	DepositedCoinsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("govDepositedCoins")))
+
+	// Address the dropped deposits were sent to before they were burned, emptied by MigrateBurnedDeposits
 	BurnedDepositCoinsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("govBurnedDepositCoins")))
-	FourWeeksProvission       = sdk.NewDec(4)
 )
 
 // ParamKeyTable Key declaration for parameters
@@ -49,6 +52,7 @@ func ParamKeyTable() params.KeyTable {
 		ParamStoreKeyDepositParams, DepositParams{},
 		ParamStoreKeyVotingParams, VotingParams{},
 		ParamStoreKeyTallyParams, TallyParams{},
+		ParamStoreKeyFundingParams, FundingParams{},
 	)
 }
 
@@ -61,6 +65,12 @@ type Keeper struct {
 
 	minKeeper mint.Keeper
 
+	// The reference to the SupplyKeeper recording the burned deposits
+	supplyKeeper SupplyKeeper
+
+	// The reference to the UpgradeKeeper to schedule software upgrades
+	upgradeKeeper UpgradeKeeper
+
 	// The reference to the Paramstore to get and set gov specific params
 	paramSpace params.Subspace
 
@@ -70,6 +80,9 @@ type Keeper struct {
 	// The reference to the StakingKeeper
 	stk StakingKeeper
 
+	// Hooks of the modules following the proposal lifecycle
+	hooks GovHooks
+
 	// The ValidatorSet to get information about validators
 	vs sdk.ValidatorSet
 
@@ -91,21 +104,23 @@ type Keeper struct {
 // - depositing funds into proposals, and activating upon sufficient funds being deposited
 // - users voting on proposals, with weight proportional to stake in the system
 // - and tallying the result of the vote.
-func NewKeeper(cdc *codec.Codec, dk distr.Keeper, mk mint.Keeper, key sdk.StoreKey, paramsKeeper params.Keeper,
+func NewKeeper(cdc *codec.Codec, dk distr.Keeper, mk mint.Keeper, spk SupplyKeeper, uk UpgradeKeeper, key sdk.StoreKey, paramsKeeper params.Keeper,
 	paramSpace params.Subspace, ck BankKeeper, sk StakingKeeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
 
 	return Keeper{
-		storeKey:     key,
-		distrKeeper:  dk,
-		minKeeper:    mk,
-		paramsKeeper: paramsKeeper,
-		paramSpace:   paramSpace.WithKeyTable(ParamKeyTable()),
-		ck:           ck,
-		stk:          sk,
-		ds:           ds,
-		vs:           ds.GetValidatorSet(),
-		cdc:          cdc,
-		codespace:    codespace,
+		storeKey:      key,
+		distrKeeper:   dk,
+		minKeeper:     mk,
+		supplyKeeper:  spk,
+		upgradeKeeper: uk,
+		paramsKeeper:  paramsKeeper,
+		paramSpace:    paramSpace.WithKeyTable(ParamKeyTable()),
+		ck:            ck,
+		stk:           sk,
+		ds:            ds,
+		vs:            ds.GetValidatorSet(),
+		cdc:           cdc,
+		codespace:     codespace,
 	}
 }
 
@@ -131,13 +146,14 @@ func (keeper Keeper) SubmitProposal(ctx sdk.Context, content ProposalContent) (p
 		DepositEndTime:        submitTime,
 	}
 	keeper.SetProposal(ctx, proposal)
+	keeper.AfterProposalSubmission(ctx, proposalID)
+
 	_, err = keeper.GetCurrentCycle(ctx)
 	if err != nil {
 		keeper.InsertInactiveProposalQueue(ctx, proposal.DepositEndTime, proposalID)
 	} else {
 		keeper.activateVotingPeriod(ctx, proposal)
 	}
-
 	return
 }
 
@@ -272,9 +288,11 @@ func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
 	proposal.VotingEndTime = proposal.VotingStartTime
 	proposal.Status = StatusVotingPeriod
 	keeper.SetProposal(ctx, proposal)
+	keeper.SetCouncilSnapshot(ctx, keeper.snapshotCouncil(ctx, proposal.ProposalID))
 
 	keeper.RemoveFromInactiveProposalQueue(ctx, proposal.DepositEndTime, proposal.ProposalID)
 	keeper.InsertActiveProposalQueue(ctx, proposal.VotingEndTime, proposal.ProposalID)
+	keeper.AfterProposalActivated(ctx, proposal.ProposalID)
 }
 
 // Params
@@ -300,6 +318,13 @@ func (keeper Keeper) GetTallyParams(ctx sdk.Context) TallyParams {
 	return tallyParams
 }
 
+// GetFundingParams Returns the current FundingParams from the global param store
+func (keeper Keeper) GetFundingParams(ctx sdk.Context) FundingParams {
+	var fundingParams FundingParams
+	keeper.paramSpace.Get(ctx, ParamStoreKeyFundingParams, &fundingParams)
+	return fundingParams
+}
+
 func (keeper Keeper) setDepositParams(ctx sdk.Context, depositParams DepositParams) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeyDepositParams, &depositParams)
 }
@@ -312,12 +337,69 @@ func (keeper Keeper) setTallyParams(ctx sdk.Context, tallyParams TallyParams) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeyTallyParams, &tallyParams)
 }
 
+func (keeper Keeper) setFundingParams(ctx sdk.Context, fundingParams FundingParams) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyFundingParams, &fundingParams)
+}
+
+// Parameter changes
+
+// ValidateParamChanges checks every change against the KeyTable of its params subspace
+func (keeper Keeper) ValidateParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	for _, change := range changes {
+		subspace, ok := keeper.paramsKeeper.GetSubspace(change.Subspace)
+		if !ok {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("Unknown params subspace %s", change.Subspace))
+		}
+		err := subspace.Validate([]byte(change.Key), []byte(change.Value))
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	return nil
+}
+
+// ApplyParamChanges applies the changes of a passed proposal all at once.
+// Changes are written to a cached context which is only committed if every
+// change decodes, so a single bad value rolls back the whole proposal.
+func (keeper Keeper) ApplyParamChanges(ctx sdk.Context, changes []ParamChange) (sdk.Tags, sdk.Error) {
+	resTags := sdk.NewTags()
+	cacheCtx, writeCache := ctx.CacheContext()
+	for _, change := range changes {
+		subspace, ok := keeper.paramsKeeper.GetSubspace(change.Subspace)
+		if !ok {
+			return nil, ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("Unknown params subspace %s", change.Subspace))
+		}
+		err := subspace.Update(cacheCtx, []byte(change.Key), []byte(change.Value))
+		if err != nil {
+			return nil, ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+		resTags = resTags.AppendTag(tags.ParamChanged, fmt.Sprintf("%s/%s", change.Subspace, change.Key))
+	}
+	writeCache()
+	return resTags, nil
+}
+
+// Software upgrades
+
+// ValidateUpgradePlan checks that the plan of a software upgrade proposal can still be scheduled
+func (keeper Keeper) ValidateUpgradePlan(ctx sdk.Context, plan upgrade.Plan) sdk.Error {
+	return keeper.upgradeKeeper.ValidatePlan(ctx, plan)
+}
+
+// ScheduleUpgrade records the plan of a passed software upgrade proposal in the upgrade store
+func (keeper Keeper) ScheduleUpgrade(ctx sdk.Context, plan upgrade.Plan) (sdk.Tags, sdk.Error) {
+	err := keeper.upgradeKeeper.ScheduleUpgrade(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewTags(tags.UpgradeScheduled, plan.Name), nil
+}
+
 // Votes
 
 // AddVote Adds a vote on a specific proposal
 func (keeper Keeper) AddVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, option VoteOption) sdk.Error {
-	_, chk := keeper.stk.GetCouncilMemberShares(ctx, voterAddr)
-	if chk == false {
+	if !keeper.isCouncilVoter(ctx, proposalID, voterAddr) {
 		return ErrInvalidCouncilMember(keeper.codespace, voterAddr)
 	}
 	activeCycle := keeper.CheckCycleActive(ctx)
@@ -343,6 +425,7 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.A
 		Option:     option,
 	}
 	keeper.setVote(ctx, proposalID, voterAddr, vote)
+	keeper.AfterProposalVote(ctx, proposalID, voterAddr)
 
 	return nil
 }
@@ -437,6 +520,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID uint64, depositorAdd
 		keeper.setDeposit(ctx, proposalID, depositorAddr, currDeposit)
 	}
 
+	keeper.AfterProposalDeposit(ctx, proposalID, depositorAddr)
 	return nil, activatedVotingPeriod
 }
 
@@ -465,46 +549,86 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID uint64) {
 	}
 }
 
-// TransferFunds Transfer funds from treasury to depositor
-func (keeper Keeper) TransferFunds(ctx sdk.Context, proposals []Proposal) {
-	totalFundCount := sdk.NewCoins()
-	weeklyIncome := keeper.GetTreasuryWeeklyIncome(ctx)
-	limit := sdk.NewInt(GetPercentageAmount(weeklyIncome, 0.5))
-
+// TransferFunds pays the ranked proposals out of the treasury budget of the current
+// funding cycle according to the funding policy, records the budget, spent and
+// carried amounts on the cycle and returns the amount paid to each proposal
+func (keeper Keeper) TransferFunds(ctx sdk.Context, proposals []Proposal) map[uint64]sdk.Coins {
+	logger := ctx.Logger().With("module", "x/gov")
+	fundingParams := keeper.GetFundingParams(ctx)
 	fundingcycle, err := keeper.GetCurrentCycle(ctx)
 	if err != nil {
-		return
+		return nil
 	}
 
-	for _, proposal := range proposals {
+	pool, _ := keeper.distrKeeper.GetFeePoolCommunityCoins(ctx).TruncateDecimal()
+	budget := keeper.getCycleBudget(ctx, fundingcycle.CycleID)
+	allocations, remaining := allocateBudget(budget, pool, proposals, fundingParams.Policy)
+	payouts := make(map[uint64]sdk.Coins, len(allocations))
 
-		totalFundCount = totalFundCount.Add(proposal.GetRequestedFund())
-		if VerifyAmount(totalFundCount, limit) {
-
-			proposal = proposal.ReduceCycleCount()
-			if proposal.IsZeroRemainingCycle() {
-				proposal.Status = StatusPassed
-				keeper.RefundDeposits(ctx, proposal.ProposalID)
-				keeper.DeleteProposalEligibility(ctx, proposal)
-				keeper.RemoveFromInactiveProposalQueue(ctx, proposal.DepositEndTime, proposal.ProposalID)
-				keeper.RemoveFromActiveProposalQueue(ctx, proposal.VotingEndTime, proposal.ProposalID)
-				proposal.Ranking = sdk.ZeroInt()
-			}
+	for _, allocation := range allocations {
+		proposal := allocation.Proposal
+		err := keeper.distrKeeper.DistributeFeePool(ctx, allocation.Amount, proposal.GetProposer())
+		if err != nil {
+			// the proposal is left as it is and ranked again in the next cycle
+			logger.Error(fmt.Sprintf("proposal %d (%s) could not be funded with %s: %s",
+				proposal.ProposalID, proposal.GetTitle(), allocation.Amount, err.Result().Log))
+			remaining = remaining.Add(allocation.Amount.AmountOf(sdk.DefaultBondDenom))
+			continue
+		}
 
-			err := keeper.distrKeeper.DistributeFeePool(ctx, proposal.GetRequestedFund(), proposal.GetProposer())
-			if err != nil {
-				panic("should not happen")
-			}
-			fundingcycle.FundedProposals = append(fundingcycle.FundedProposals, proposal.ProposalID)
-			keeper.SetProposal(ctx, proposal)
+		proposal = proposal.ReduceCycleCount()
+		if proposal.IsZeroRemainingCycle() {
+			proposal.Status = StatusPassed
+			keeper.RefundDeposits(ctx, proposal.ProposalID)
+			keeper.DeleteProposalEligibility(ctx, proposal)
+			keeper.RemoveFromInactiveProposalQueue(ctx, proposal.DepositEndTime, proposal.ProposalID)
+			keeper.RemoveFromActiveProposalQueue(ctx, proposal.VotingEndTime, proposal.ProposalID)
+			proposal.Ranking = sdk.ZeroInt()
 		}
+
+		payouts[proposal.ProposalID] = allocation.Amount
+		fundingcycle.FundedProposals = append(fundingcycle.FundedProposals, proposal.ProposalID)
+		keeper.SetProposal(ctx, proposal)
+		keeper.AfterProposalFunded(ctx, proposal.ProposalID, allocation.Amount)
+	}
+
+	fundingcycle.Budget = budget
+	fundingcycle.Spent = budget.Sub(remaining)
+	fundingcycle.Carried = sdk.ZeroInt()
+	if fundingParams.Policy == FundingPolicyCarryOver {
+		fundingcycle.Carried = remaining
 	}
 	keeper.SetFundingCycle(ctx, fundingcycle)
+	return payouts
+}
 
+// getCycleBudget returns the budget of a funding cycle, the treasury share of the
+// income plus the budget carried over, capped at what the community pool holds
+func (keeper Keeper) getCycleBudget(ctx sdk.Context, cycleID uint64) sdk.Int {
+	pool, _ := keeper.distrKeeper.GetFeePoolCommunityCoins(ctx).TruncateDecimal()
+	budget := keeper.GetTreasuryWeeklyIncome(ctx).Mul(keeper.GetFundingParams(ctx).TreasuryShare).TruncateInt()
+	budget = budget.Add(keeper.getCarriedBudget(ctx, cycleID))
+	return sdk.MinInt(budget, pool.AmountOf(sdk.DefaultBondDenom))
 }
 
-// DeleteDeposits Deletes all the deposits on a specific proposal without refunding them
-func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID uint64) {
+// getCarriedBudget returns the budget carried over from the funding cycle
+// preceding the given one
+func (keeper Keeper) getCarriedBudget(ctx sdk.Context, cycleID uint64) sdk.Int {
+	if cycleID == 0 {
+		return sdk.ZeroInt()
+	}
+	previousCycle, found := keeper.GetFundingCycle(ctx, cycleID-1)
+	if !found {
+		return sdk.ZeroInt()
+	}
+	return previousCycle.Carried
+}
+
+// DeleteDeposits Deletes all the deposits on a specific proposal without refunding them.
+// The deposits are burned or sent to the community pool, depending on the deposit params.
+func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID uint64) sdk.Tags {
+	resTags := sdk.EmptyTags()
+	policy := keeper.GetDepositParams(ctx).DroppedDeposits
 	store := ctx.KVStore(keeper.storeKey)
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 	defer depositsIterator.Close()
@@ -512,14 +636,57 @@ func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID uint64) {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), deposit)
 
-		// TODO: Find a way to do this without using accounts.
-		_, err := keeper.ck.SendCoins(ctx, DepositedCoinsAccAddr, BurnedDepositCoinsAccAddr, deposit.Amount)
+		resTags = resTags.AppendTags(keeper.dropDeposit(ctx, deposit.Amount, policy))
+
+		store.Delete(depositsIterator.Key())
+	}
+	return resTags
+}
+
+// dropDeposit takes the coins of a deposit out of the deposit account and burns
+// them, or sends them to the community pool
+func (keeper Keeper) dropDeposit(ctx sdk.Context, amount sdk.Coins, policy DroppedDepositPolicy) sdk.Tags {
+	_, _, err := keeper.ck.SubtractCoins(ctx, DepositedCoinsAccAddr, amount)
+	if err != nil {
+		panic("should not happen")
+	}
+
+	if policy == DroppedDepositsCommunityPool {
+		keeper.distrKeeper.AddToCommunityPool(ctx, amount)
+		return sdk.NewTags(tags.DepositToPool, amount.String())
+	}
+	keeper.burn(ctx, amount)
+	return sdk.NewTags(tags.DepositBurned, amount.String())
+}
+
+// burn takes burned coins out of the staking pool and records them in the supply
+func (keeper Keeper) burn(ctx sdk.Context, amount sdk.Coins) {
+	keeper.stk.DeflateSupply(ctx, amount.AmountOf(sdk.DefaultBondDenom))
+	keeper.supplyKeeper.Burn(ctx, amount)
+}
+
+// MigrateBurnedDeposits burns the coins sent to BurnedDepositCoinsAccAddr by the
+// previous versions, which only moved the dropped deposits to that address. It
+// is run by the upgrade introducing the burn of the dropped deposits.
+func (keeper Keeper) MigrateBurnedDeposits(ctx sdk.Context) sdk.Tags {
+	resTags := sdk.EmptyTags()
+	burned := keeper.ck.GetCoins(ctx, BurnedDepositCoinsAccAddr)
+	if !burned.IsZero() {
+		_, _, err := keeper.ck.SubtractCoins(ctx, BurnedDepositCoinsAccAddr, burned)
 		if err != nil {
-			panic("should not happen")
+			panic(err)
 		}
+		keeper.burn(ctx, burned)
+		resTags = resTags.AppendTag(tags.DepositBurned, burned.String())
+	}
 
-		store.Delete(depositsIterator.Key())
+	// deposit params stored before the policy existed burn the dropped deposits
+	depositParams := keeper.GetDepositParams(ctx)
+	if !depositParams.DroppedDeposits.IsValid() {
+		depositParams.DroppedDeposits = DroppedDepositsBurn
+		keeper.setDepositParams(ctx, depositParams)
 	}
+	return resTags
 }
 
 // ProposalQueues
@@ -632,14 +799,11 @@ func (keeper Keeper) AddFundingCycle(ctx sdk.Context) {
 		panic("AddFundingCycle fail to get New Funding Cycle ID")
 	}
 	startTime := ctx.BlockHeader().Time
-	endTime := ctx.BlockHeader().Time.Add(FourWeeksHours)
+	endTime := ctx.BlockHeader().Time.Add(keeper.GetFundingParams(ctx).CycleDuration)
 
-	fundingCycle := FundingCycle{
-		CycleID:        fundingCycleID,
-		CycleStartTime: startTime,
-		CycleEndTime:   endTime,
-	}
+	fundingCycle := NewFundingCycle(fundingCycleID, startTime, endTime)
 	keeper.SetFundingCycle(ctx, fundingCycle)
+	keeper.AfterFundingCycleStarted(ctx, fundingCycleID)
 }
 
 // GetNewFundingCycleID Gets the next available FundingCycleID and increments it
@@ -764,10 +928,31 @@ func (keeper Keeper) SetEligibilityDetails(ctx sdk.Context, proposals []Proposal
 
 }
 
+// SetRanking stores the ranking snapshot of a funding cycle
+func (keeper Keeper) SetRanking(ctx sdk.Context, ranking Ranking) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(ranking)
+	store.Set(KeyRanking(ranking.CycleID), bz)
+}
+
+// GetRanking returns the ranking snapshot of a funding cycle
+func (keeper Keeper) GetRanking(ctx sdk.Context, cycleID uint64) (Ranking, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyRanking(cycleID))
+	if bz == nil {
+		return Ranking{}, false
+	}
+	var ranking Ranking
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &ranking)
+	return ranking, true
+}
+
+// GetTreasuryWeeklyIncome returns the treasury income over a funding cycle
 func (keeper Keeper) GetTreasuryWeeklyIncome(ctx sdk.Context) sdk.Dec {
 	communityTx := keeper.distrKeeper.GetCommunityTax(ctx)
 	weeklyProivssion := keeper.minKeeper.GetMinter(ctx).WeeklyProvisions
-	treasuryIncome := weeklyProivssion.Mul(FourWeeksProvission)
+	cycleWeeks := sdk.NewDec(int64(keeper.GetFundingParams(ctx).CycleDuration)).QuoInt64(int64(OneWeek))
+	treasuryIncome := weeklyProivssion.Mul(cycleWeeks)
 	treasuryIncome = treasuryIncome.Mul(communityTx)
 	return treasuryIncome
 
@@ -19,6 +19,9 @@ var (
 	PrefixInactiveProposalQueue = []byte("inactiveProposalQueue")
 	PrefixFudingCycleQueue      = []byte("fundingCycles")
 	PrefixEligibilityQueue      = []byte("proposalEligibility")
+	PrefixRanking               = []byte("rankings")
+	PrefixCouncilProxy          = []byte("councilproxies")
+	PrefixDeposits              = []byte("deposits:")
 )
 
 // Key for getting a specific proposal from the store
@@ -51,6 +54,14 @@ func KeyEligibility(eligibilityID uint64) []byte {
 	}, KeyDelimiter)
 }
 
+// Key for getting the ranking snapshot of a funding cycle from the store
+func KeyRanking(cycleID uint64) []byte {
+	return bytes.Join([][]byte{
+		PrefixRanking,
+		sdk.Uint64ToBigEndian(cycleID),
+	}, KeyDelimiter)
+}
+
 // Key for getting a specific deposit from the store
 func KeyDeposit(proposalID uint64, depositorAddr sdk.AccAddress) []byte {
 	return []byte(fmt.Sprintf("deposits:%d:%d", proposalID, depositorAddr))
@@ -61,6 +72,34 @@ func KeyVote(proposalID uint64, voterAddr sdk.AccAddress) []byte {
 	return []byte(fmt.Sprintf("votes:%d:%d", proposalID, voterAddr))
 }
 
+// Key for getting a specific milestone of a proposal from the store
+func KeyMilestone(proposalID, index uint64) []byte {
+	return []byte(fmt.Sprintf("milestones:%d:%d", proposalID, index))
+}
+
+// Key for getting a specific vote on a milestone from the store
+func KeyMilestoneVote(proposalID, index uint64, voterAddr sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("milestonevotes:%d:%d:%d", proposalID, index, voterAddr))
+}
+
+// Key for getting all votes on a milestone from the store
+func KeyMilestoneVotesSubspace(proposalID, index uint64) []byte {
+	return []byte(fmt.Sprintf("milestonevotes:%d:%d:", proposalID, index))
+}
+
+// Key for getting the council snapshot of a proposal from the store
+func KeyCouncilSnapshot(proposalID uint64) []byte {
+	return []byte(fmt.Sprintf("councilsnapshots:%d", proposalID))
+}
+
+// Key for getting the proxy a council member delegated its vote to from the store
+func KeyCouncilProxy(delegatorAddr sdk.AccAddress) []byte {
+	return bytes.Join([][]byte{
+		PrefixCouncilProxy,
+		delegatorAddr.Bytes(),
+	}, KeyDelimiter)
+}
+
 // Key for getting all deposits on a proposal from the store
 func KeyDepositsSubspace(proposalID uint64) []byte {
 	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
@@ -9,6 +9,13 @@ import (
 	abci "github.com/ColorPlatform/prism/abci/types"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/bank"
+	distrtypes "github.com/ColorPlatform/color-sdk/x/distribution/types"
+	"github.com/ColorPlatform/color-sdk/x/gov/tags"
+	"github.com/ColorPlatform/color-sdk/x/mint"
+	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 func TestGetSetProposal(t *testing.T) {
@@ -180,6 +187,87 @@ func TestDeposits(t *testing.T) {
 
 }
 
+func TestDeleteDeposits(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	supplyKeeper := keeper.supplyKeeper.(supply.Keeper)
+	keeper.distrKeeper.SetFeePool(ctx, distrtypes.FeePool{CommunityPool: sdk.DecCoins{}})
+
+	fourStake := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.TokensFromTendermintPower(4)))
+	initialSupply := supplyKeeper.GetSupply(ctx)
+	initialPool := sk.GetPool(ctx)
+
+	// deposit the coins without going through a proposal
+	deposit := func(proposalID uint64, depositor sdk.AccAddress) {
+		_, err := keeper.ck.SendCoins(ctx, depositor, DepositedCoinsAccAddr, fourStake)
+		require.Nil(t, err)
+		keeper.setDeposit(ctx, proposalID, depositor, Deposit{depositor, proposalID, fourStake})
+	}
+
+	// Dropped deposits are burned by default
+	deposit(1, addrs[0])
+	require.Equal(t, fourStake, keeper.ck.GetCoins(ctx, DepositedCoinsAccAddr))
+
+	resTags := keeper.DeleteDeposits(ctx, 1)
+	require.Equal(t, sdk.NewTags(tags.DepositBurned, fourStake.String()), resTags)
+	require.True(t, keeper.ck.GetCoins(ctx, DepositedCoinsAccAddr).IsZero())
+	_, found := keeper.GetDeposit(ctx, 1, addrs[0])
+	require.False(t, found)
+	require.Equal(t, initialSupply.Total.Sub(fourStake), supplyKeeper.GetSupply(ctx).Total)
+	require.Equal(t, fourStake, supplyKeeper.GetSupply(ctx).Burned)
+	require.Equal(t, initialPool.NotBondedTokens.Sub(fourStake.AmountOf(sdk.DefaultBondDenom)),
+		sk.GetPool(ctx).NotBondedTokens)
+
+	// Dropped deposits go to the community pool when the params say so
+	depositParams := keeper.GetDepositParams(ctx)
+	depositParams.DroppedDeposits = DroppedDepositsCommunityPool
+	keeper.setDepositParams(ctx, depositParams)
+
+	deposit(2, addrs[1])
+
+	resTags = keeper.DeleteDeposits(ctx, 2)
+	require.Equal(t, sdk.NewTags(tags.DepositToPool, fourStake.String()), resTags)
+	require.True(t, keeper.ck.GetCoins(ctx, DepositedCoinsAccAddr).IsZero())
+	require.Equal(t, sdk.NewDecCoins(fourStake), keeper.distrKeeper.GetFeePoolCommunityCoins(ctx))
+	require.Equal(t, initialSupply.Total.Sub(fourStake), supplyKeeper.GetSupply(ctx).Total)
+	require.Equal(t, fourStake, supplyKeeper.GetSupply(ctx).Burned)
+}
+
+func TestMigrateBurnedDeposits(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	supplyKeeper := keeper.supplyKeeper.(supply.Keeper)
+
+	// deposits dropped by the previous versions only moved to the burn address
+	fourStake := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.TokensFromTendermintPower(4)))
+	_, _, err := keeper.ck.(bank.Keeper).AddCoins(ctx, BurnedDepositCoinsAccAddr, fourStake)
+	require.Nil(t, err)
+	supplyKeeper.Inflate(ctx, fourStake)
+	initialSupply := supplyKeeper.GetSupply(ctx)
+
+	depositParams := keeper.GetDepositParams(ctx)
+	depositParams.DroppedDeposits = ""
+	keeper.setDepositParams(ctx, depositParams)
+
+	resTags := keeper.MigrateBurnedDeposits(ctx)
+	require.Equal(t, sdk.NewTags(tags.DepositBurned, fourStake.String()), resTags)
+	require.True(t, keeper.ck.GetCoins(ctx, BurnedDepositCoinsAccAddr).IsZero())
+	require.Equal(t, initialSupply.Total.Sub(fourStake), supplyKeeper.GetSupply(ctx).Total)
+	require.Equal(t, fourStake, supplyKeeper.GetSupply(ctx).Burned)
+	require.Equal(t, DroppedDepositsBurn, keeper.GetDepositParams(ctx).DroppedDeposits)
+
+	// a second run has nothing left to burn
+	require.Empty(t, keeper.MigrateBurnedDeposits(ctx))
+}
+
 func TestVotes(t *testing.T) {
 	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2, GenesisState{}, nil)
 	SortAddresses(addrs)
@@ -273,3 +361,148 @@ func TestProposalQueues(t *testing.T) {
 	require.Equal(t, proposalID, proposal.ProposalID)
 	activeIterator.Close()
 }
+
+func TestParamChanges(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	subspace := keeper.paramSpace.Name()
+	tallyKey := string(ParamStoreKeyTallyParams)
+	votingKey := string(ParamStoreKeyVotingParams)
+
+	newTally := TallyParams{Quorum: sdk.NewDecWithPrec(2, 1), Threshold: sdk.NewDecWithPrec(5, 1)}
+	tallyChange := NewParamChange(subspace, tallyKey, string(keeper.cdc.MustMarshalJSON(newTally)))
+	badVotingChange := NewParamChange(subspace, votingKey, `"not a duration"`)
+
+	require.Nil(t, keeper.ValidateParamChanges(ctx, []ParamChange{tallyChange}))
+	require.NotNil(t, keeper.ValidateParamChanges(ctx, []ParamChange{NewParamChange("unknown", tallyKey, tallyChange.Value)}))
+	require.NotNil(t, keeper.ValidateParamChanges(ctx, []ParamChange{NewParamChange(subspace, "unknown", tallyChange.Value)}))
+	require.NotNil(t, keeper.ValidateParamChanges(ctx, []ParamChange{tallyChange, badVotingChange}))
+
+	// a single bad value rolls back every change of the proposal
+	oldTally := keeper.GetTallyParams(ctx)
+	_, err := keeper.ApplyParamChanges(ctx, []ParamChange{tallyChange, badVotingChange})
+	require.NotNil(t, err)
+	require.True(t, oldTally.Quorum.Equal(keeper.GetTallyParams(ctx).Quorum))
+
+	resTags, err := keeper.ApplyParamChanges(ctx, []ParamChange{tallyChange})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(resTags))
+	require.True(t, newTally.Quorum.Equal(keeper.GetTallyParams(ctx).Quorum))
+	require.True(t, newTally.Threshold.Equal(keeper.GetTallyParams(ctx).Threshold))
+
+	// values breaking the funding cycles are refused on submission and when applied
+	fundingKey := string(ParamStoreKeyFundingParams)
+	for _, mutate := range []func(*FundingParams){
+		func(fp *FundingParams) { fp.CycleDuration = 0 },
+		func(fp *FundingParams) { fp.FreezeWindow = fp.CycleDuration },
+		func(fp *FundingParams) { fp.TreasuryShare = sdk.NewDecWithPrec(15, 1) },
+	} {
+		badFunding := keeper.GetFundingParams(ctx)
+		mutate(&badFunding)
+		badFundingChange := NewParamChange(subspace, fundingKey, string(keeper.cdc.MustMarshalJSON(badFunding)))
+		require.NotNil(t, keeper.ValidateParamChanges(ctx, []ParamChange{badFundingChange}))
+		_, err = keeper.ApplyParamChanges(ctx, []ParamChange{badFundingChange})
+		require.NotNil(t, err)
+	}
+	badThreshold := NewParamChange(subspace, tallyKey, string(keeper.cdc.MustMarshalJSON(
+		TallyParams{Quorum: newTally.Quorum, Threshold: sdk.NewDec(2)})))
+	require.NotNil(t, keeper.ValidateParamChanges(ctx, []ParamChange{badThreshold}))
+
+	newFunding := keeper.GetFundingParams(ctx)
+	newFunding.CycleDuration = time.Hour
+	newFunding.FreezeWindow = time.Minute
+	fundingChange := NewParamChange(subspace, fundingKey, string(keeper.cdc.MustMarshalJSON(newFunding)))
+	_, err = keeper.ApplyParamChanges(ctx, []ParamChange{fundingChange})
+	require.Nil(t, err)
+	require.Equal(t, time.Hour, keeper.GetFundingParams(ctx).CycleDuration)
+}
+
+func TestSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+
+	require.NotNil(t, keeper.ValidateUpgradePlan(ctx, upgrade.NewPlan("v2", 10, time.Time{}, "")))
+	plan := upgrade.NewPlan("v2", 20, time.Time{}, "https://example.com/v2")
+	require.Nil(t, keeper.ValidateUpgradePlan(ctx, plan))
+
+	proposal := Proposal{ProposalContent: NewSoftwareUpgradeProposal("Upgrade", "Upgrade to v2", plan, nil), ProposalID: 1}
+	keeper.SetProposal(ctx, proposal)
+
+	proposal, tagValue, resTags := executeSoftwareUpgrade(ctx, keeper, proposal, proposal.ProposalContent.(SoftwareUpgradeProposal))
+	require.Equal(t, StatusPassed, proposal.Status)
+	require.Equal(t, tags.ActionProposalPassed, tagValue)
+	require.Equal(t, sdk.NewTags(tags.UpgradeScheduled, "v2"), resTags)
+
+	stored, found := keeper.upgradeKeeper.(upgrade.Keeper).GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	// the plan of a proposal passing after the upgrade height is not scheduled
+	late := upgrade.NewPlan("v3", 30, time.Time{}, "")
+	proposal = Proposal{ProposalContent: NewSoftwareUpgradeProposal("Upgrade", "Upgrade to v3", late, nil), ProposalID: 2}
+	keeper.SetProposal(ctx, proposal)
+	proposal, tagValue, _ = executeSoftwareUpgrade(ctx.WithBlockHeight(30), keeper, proposal, proposal.ProposalContent.(SoftwareUpgradeProposal))
+	require.Equal(t, StatusRejected, proposal.Status)
+	require.Equal(t, tags.ActionProposalFailed, tagValue)
+
+	stored, _ = keeper.upgradeKeeper.(upgrade.Keeper).GetUpgradePlan(ctx)
+	require.Equal(t, plan, stored)
+}
+
+func TestSoftwareUpgradeScheduledAtCycleEnd(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 2, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 10, Time: time.Unix(1000, 0).UTC()})
+	keeper.distrKeeper.SetCommunityTax(ctx, sdk.NewDecWithPrec(2, 2))
+	keeper.minKeeper.SetMinter(ctx, mint.NewMinter(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec(), time.Time{}, time.Time{}))
+	keeper.AddFundingCycle(ctx)
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[0], sdk.NewDec(10)))
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[1], sdk.NewDec(30)))
+
+	plan := upgrade.NewPlan("v2", 20, time.Time{}, "")
+	proposal, err := keeper.SubmitProposal(ctx, NewSoftwareUpgradeProposal("Upgrade", "Upgrade to v2", plan, addrs[0]))
+	require.NoError(t, err)
+	keeper.activateVotingPeriod(ctx, proposal)
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+
+	// early votes passing the running tally do not schedule the plan
+	ctx = ctx.WithBlockHeight(11)
+	UpdateActiveProposals(ctx, keeper, sdk.NewTags())
+	_, found := keeper.upgradeKeeper.(upgrade.Keeper).GetUpgradePlan(ctx)
+	require.False(t, found)
+	proposal, ok := keeper.GetProposal(ctx, proposal.ProposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusVotingPeriod, proposal.Status)
+
+	// so the later votes can still reject it at the end of the cycle
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionNo))
+	ExecuteProposal(ctx, keeper, sdk.NewTags())
+	_, found = keeper.upgradeKeeper.(upgrade.Keeper).GetUpgradePlan(ctx)
+	require.False(t, found)
+	proposal, ok = keeper.GetProposal(ctx, proposal.ProposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusRejected, proposal.Status)
+
+	// while a proposal still passing at the end of the cycle schedules its plan
+	proposal, err = keeper.SubmitProposal(ctx, NewSoftwareUpgradeProposal("Upgrade", "Upgrade to v2", plan, addrs[0]))
+	require.NoError(t, err)
+	keeper.activateVotingPeriod(ctx, proposal)
+	require.Nil(t, keeper.AddVote(ctx, proposal.ProposalID, addrs[1], OptionYes))
+	ExecuteProposal(ctx, keeper, sdk.NewTags())
+	stored, found := keeper.upgradeKeeper.(upgrade.Keeper).GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+	proposal, _ = keeper.GetProposal(ctx, proposal.ProposalID)
+	require.Equal(t, StatusPassed, proposal.Status)
+}
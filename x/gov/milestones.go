@@ -0,0 +1,243 @@
+package gov
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// MilestoneStatus is the review status of a milestone
+type MilestoneStatus string
+
+// Valid milestone statuses
+const (
+	MilestoneStatusPending  MilestoneStatus = "pending"  // waiting for the proposer report
+	MilestoneStatusReported MilestoneStatus = "reported" // report submitted, under council review
+	MilestoneStatusApproved MilestoneStatus = "approved" // the council released the next tranche
+	MilestoneStatusRejected MilestoneStatus = "rejected" // the council stopped the funding of the proposal
+)
+
+// Milestone is a deliverable of a multi-cycle funding proposal. The proposal
+// receives its first tranche without milestone and each following tranche
+// only once the council approved the report of the previous milestone.
+type Milestone struct {
+	ProposalID       uint64          `json:"proposal_id"`        //  ID of the proposal
+	Index            uint64          `json:"index"`              //  Position of the milestone, milestone i gates tranche i+1
+	Description      string          `json:"description"`        //  Deliverable promised by the proposer
+	Status           MilestoneStatus `json:"status"`             //  Review status of the milestone
+	ReportHash       string          `json:"report_hash"`        //  Hex encoded hash of the milestone report
+	ReportTime       time.Time       `json:"report_time"`        //  Time the report was submitted
+	FinalTallyResult TallyResult     `json:"final_tally_result"` //  Result of the council review
+}
+
+// NewMilestone creates a pending milestone
+func NewMilestone(proposalID, index uint64, description string) Milestone {
+	return Milestone{
+		ProposalID:       proposalID,
+		Index:            index,
+		Description:      description,
+		Status:           MilestoneStatusPending,
+		FinalTallyResult: EmptyTallyResult(),
+	}
+}
+
+// nolint
+func (m Milestone) String() string {
+	return fmt.Sprintf(`Milestone %d of proposal %d:
+  Description:  %s
+  Status:       %s
+  Report Hash:  %s
+  Report Time:  %s`,
+		m.Index, m.ProposalID, m.Description, m.Status, m.ReportHash, m.ReportTime)
+}
+
+// Milestones is a collection of Milestone
+type Milestones []Milestone
+
+// nolint
+func (ms Milestones) String() string {
+	out := "Index - (Status) Description\n"
+	for _, m := range ms {
+		out += fmt.Sprintf("%d - (%s) %s\n", m.Index, m.Status, m.Description)
+	}
+	return strings.TrimSpace(out)
+}
+
+// MilestoneVoteWithMetadata (just for genesis)
+type MilestoneVoteWithMetadata struct {
+	Index uint64 `json:"index"`
+	Vote  Vote   `json:"vote"`
+}
+
+// SetMilestone stores a milestone
+func (keeper Keeper) SetMilestone(ctx sdk.Context, milestone Milestone) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(milestone)
+	store.Set(KeyMilestone(milestone.ProposalID, milestone.Index), bz)
+}
+
+// GetMilestone returns a milestone of a proposal
+func (keeper Keeper) GetMilestone(ctx sdk.Context, proposalID, index uint64) (Milestone, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyMilestone(proposalID, index))
+	if bz == nil {
+		return Milestone{}, false
+	}
+	var milestone Milestone
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &milestone)
+	return milestone, true
+}
+
+// GetMilestones returns the milestones of a proposal in order
+func (keeper Keeper) GetMilestones(ctx sdk.Context, proposalID uint64) Milestones {
+	milestones := Milestones{}
+	for index := uint64(0); ; index++ {
+		milestone, found := keeper.GetMilestone(ctx, proposalID, index)
+		if !found {
+			return milestones
+		}
+		milestones = append(milestones, milestone)
+	}
+}
+
+// AddMilestones attaches the milestones described by the proposer to a proposal
+func (keeper Keeper) AddMilestones(ctx sdk.Context, proposalID uint64, descriptions []string) {
+	for index, description := range descriptions {
+		keeper.SetMilestone(ctx, NewMilestone(proposalID, uint64(index), description))
+	}
+}
+
+// GetCurrentMilestone returns the milestone gating the next tranche of a proposal.
+// It returns false if the proposal has no milestone or did not receive its first
+// tranche yet.
+func (keeper Keeper) GetCurrentMilestone(ctx sdk.Context, proposal Proposal) (Milestone, bool) {
+	paidTranches := proposal.GetFundingCycle() - proposal.RemainingFundingCycle
+	if paidTranches == 0 {
+		return Milestone{}, false
+	}
+	return keeper.GetMilestone(ctx, proposal.ProposalID, paidTranches-1)
+}
+
+// ReportMilestone records the report of the current milestone of a proposal and
+// opens its council review
+func (keeper Keeper) ReportMilestone(ctx sdk.Context, proposalID uint64, proposer sdk.AccAddress, reportHash string) (Milestone, sdk.Error) {
+	proposal, ok := keeper.GetProposal(ctx, proposalID)
+	if !ok {
+		return Milestone{}, ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if !proposal.GetProposer().Equals(proposer) {
+		return Milestone{}, ErrInvalidMilestone(keeper.codespace,
+			fmt.Sprintf("%s is not the proposer of proposal %d", proposer, proposalID))
+	}
+	if proposal.Status != StatusVotingPeriod {
+		return Milestone{}, ErrInactiveProposal(keeper.codespace, proposalID)
+	}
+	milestone, found := keeper.GetCurrentMilestone(ctx, proposal)
+	if !found {
+		return Milestone{}, ErrInvalidMilestone(keeper.codespace,
+			fmt.Sprintf("proposal %d has no milestone to report", proposalID))
+	}
+	if milestone.Status != MilestoneStatusPending {
+		return Milestone{}, ErrInvalidMilestone(keeper.codespace,
+			fmt.Sprintf("milestone %d of proposal %d is already %s", milestone.Index, proposalID, milestone.Status))
+	}
+
+	milestone.Status = MilestoneStatusReported
+	milestone.ReportHash = strings.ToLower(reportHash)
+	milestone.ReportTime = ctx.BlockHeader().Time
+	keeper.SetMilestone(ctx, milestone)
+	return milestone, nil
+}
+
+// AddMilestoneVote adds a council vote on the reported milestone of a proposal
+func (keeper Keeper) AddMilestoneVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, option VoteOption) sdk.Error {
+	if !keeper.isCouncilVoter(ctx, proposalID, voterAddr) {
+		return ErrInvalidCouncilMember(keeper.codespace, voterAddr)
+	}
+	if !keeper.CheckCycleActive(ctx) {
+		return ErrInvalidCycle(keeper.codespace, "No Active Cycle Found.")
+	}
+	proposal, ok := keeper.GetProposal(ctx, proposalID)
+	if !ok {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	milestone, found := keeper.GetCurrentMilestone(ctx, proposal)
+	if !found || milestone.Status != MilestoneStatusReported {
+		return ErrInvalidMilestone(keeper.codespace,
+			fmt.Sprintf("proposal %d has no milestone under review", proposalID))
+	}
+	if !validVoteOption(option) {
+		return ErrInvalidVote(keeper.codespace, option)
+	}
+
+	vote := Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Option:     option,
+	}
+	keeper.setMilestoneVote(ctx, milestone.Index, vote)
+	return nil
+}
+
+// GetMilestoneVote returns the vote of a council member on a milestone
+func (keeper Keeper) GetMilestoneVote(ctx sdk.Context, proposalID, index uint64, voterAddr sdk.AccAddress) (Vote, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyMilestoneVote(proposalID, index, voterAddr))
+	if bz == nil {
+		return Vote{}, false
+	}
+	var vote Vote
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &vote)
+	return vote, true
+}
+
+func (keeper Keeper) setMilestoneVote(ctx sdk.Context, index uint64, vote Vote) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(vote)
+	store.Set(KeyMilestoneVote(vote.ProposalID, index, vote.Voter), bz)
+}
+
+// GetMilestoneVotes returns an iterator over the votes on a milestone
+func (keeper Keeper) GetMilestoneVotes(ctx sdk.Context, proposalID, index uint64) sdk.Iterator {
+	store := ctx.KVStore(keeper.storeKey)
+	return sdk.KVStorePrefixIterator(store, KeyMilestoneVotesSubspace(proposalID, index))
+}
+
+// ReviewMilestone tallies the council votes on the current milestone of a proposal
+// at the end of a funding cycle. It returns whether the next tranche can be paid
+// and whether the milestone was rejected. Proposals without milestones are always
+// released.
+func (keeper Keeper) ReviewMilestone(ctx sdk.Context, proposal Proposal) (release bool, rejected bool) {
+	milestone, found := keeper.GetCurrentMilestone(ctx, proposal)
+	if !found {
+		return true, false
+	}
+
+	switch milestone.Status {
+	case MilestoneStatusApproved:
+		return true, false
+	case MilestoneStatusRejected:
+		return false, true
+	case MilestoneStatusPending:
+		return false, false
+	}
+
+	votesIterator := keeper.GetMilestoneVotes(ctx, proposal.ProposalID, milestone.Index)
+	defer votesIterator.Close()
+	// the council which voted the proposal also reviews its milestones
+	council := keeper.getTallyCouncil(ctx, proposal.ProposalID)
+	passes, tallyResults, neutral := tallyVotes(ctx, keeper, council, votesIterator)
+	milestone.FinalTallyResult = tallyResults
+
+	switch {
+	case passes:
+		milestone.Status = MilestoneStatusApproved
+	case !neutral:
+		milestone.Status = MilestoneStatusRejected
+	}
+	keeper.SetMilestone(ctx, milestone)
+
+	return passes, milestone.Status == MilestoneStatusRejected
+}
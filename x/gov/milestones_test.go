@@ -0,0 +1,98 @@
+package gov
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/gov/tags"
+	"github.com/ColorPlatform/color-sdk/x/staking"
+)
+
+func TestMilestoneReview(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 3, GenesisState{}, nil)
+
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0).UTC()})
+	keeper.AddFundingCycle(ctx)
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[0], sdk.NewDec(10)))
+	sk.SetCouncilMember(ctx, staking.NewCouncilMember(addrs[1], sdk.NewDec(10)))
+
+	proposalID, err := keeper.getNewProposalID(ctx)
+	require.Nil(t, err)
+	content := NewTextProposal("Test", "test", sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 5)}, 3, addrs[0])
+	proposal := Proposal{
+		ProposalContent:       content,
+		ProposalID:            proposalID,
+		Status:                StatusVotingPeriod,
+		RemainingFundingCycle: 3,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.AddMilestones(ctx, proposal.ProposalID, []string{"first release", "second release"})
+	require.Equal(t, 2, len(keeper.GetMilestones(ctx, proposal.ProposalID)))
+
+	// the first tranche is released without milestone
+	_, found := keeper.GetCurrentMilestone(ctx, proposal)
+	require.False(t, found)
+	release, rejected := keeper.ReviewMilestone(ctx, proposal)
+	require.True(t, release)
+	require.False(t, rejected)
+	_, err = keeper.ReportMilestone(ctx, proposal.ProposalID, addrs[0], "abcd")
+	require.NotNil(t, err)
+
+	// the second tranche waits for the report of the first milestone
+	proposal.RemainingFundingCycle = 2
+	keeper.SetProposal(ctx, proposal)
+	release, rejected = keeper.ReviewMilestone(ctx, proposal)
+	require.False(t, release)
+	require.False(t, rejected)
+	require.NotNil(t, keeper.AddMilestoneVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+
+	_, err = keeper.ReportMilestone(ctx, proposal.ProposalID, addrs[1], "abcd")
+	require.NotNil(t, err)
+	milestone, err := keeper.ReportMilestone(ctx, proposal.ProposalID, addrs[0], "ABCD")
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), milestone.Index)
+	require.Equal(t, MilestoneStatusReported, milestone.Status)
+	require.Equal(t, "abcd", milestone.ReportHash)
+	_, err = keeper.ReportMilestone(ctx, proposal.ProposalID, addrs[0], "abcd")
+	require.NotNil(t, err)
+
+	require.NotNil(t, keeper.AddMilestoneVote(ctx, proposal.ProposalID, addrs[2], OptionYes))
+	require.Nil(t, keeper.AddMilestoneVote(ctx, proposal.ProposalID, addrs[0], OptionYes))
+	require.Nil(t, keeper.AddMilestoneVote(ctx, proposal.ProposalID, addrs[1], OptionYes))
+
+	release, rejected = keeper.ReviewMilestone(ctx, proposal)
+	require.True(t, release)
+	require.False(t, rejected)
+	milestone, _ = keeper.GetMilestone(ctx, proposal.ProposalID, 0)
+	require.Equal(t, MilestoneStatusApproved, milestone.Status)
+	require.True(t, milestone.FinalTallyResult.Yes.Equal(sdk.NewInt(20)))
+
+	// the third tranche is stopped by the council
+	proposal.RemainingFundingCycle = 1
+	keeper.SetProposal(ctx, proposal)
+	_, err = keeper.ReportMilestone(ctx, proposal.ProposalID, addrs[0], "abcd")
+	require.Nil(t, err)
+	require.Nil(t, keeper.AddMilestoneVote(ctx, proposal.ProposalID, addrs[0], OptionNo))
+	require.Nil(t, keeper.AddMilestoneVote(ctx, proposal.ProposalID, addrs[1], OptionNo))
+
+	release, rejected = keeper.ReviewMilestone(ctx, proposal)
+	require.False(t, release)
+	require.True(t, rejected)
+
+	proposal, tagValue, _ := cancelProposalFunding(ctx, keeper, proposal)
+	require.Equal(t, StatusRejected, proposal.Status)
+	require.Equal(t, uint64(0), proposal.RemainingFundingCycle)
+	require.Equal(t, tags.ActionMilestoneRejected, tagValue)
+
+	genState := ExportGenesis(ctx, keeper)
+	require.Equal(t, 2, len(genState.Milestones))
+	require.Equal(t, 4, len(genState.MilestoneVotes))
+}
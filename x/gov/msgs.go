@@ -1,32 +1,43 @@
 package gov
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 // Governance message types and routes
 const (
-	TypeMsgDeposit        = "deposit"
-	TypeMsgVote           = "vote"
-	TypeMsgSubmitProposal = "submit_proposal"
+	TypeMsgDeposit             = "deposit"
+	TypeMsgVote                = "vote"
+	TypeMsgSubmitProposal      = "submit_proposal"
+	TypeMsgReportMilestone     = "report_milestone"
+	TypeMsgVoteMilestone       = "vote_milestone"
+	TypeMsgDelegateCouncilVote = "delegate_council_vote"
+	TypeMsgRevokeCouncilVote   = "revoke_council_vote"
 
 	MaxDescriptionLength int = 5000
 	MaxTitleLength       int = 140
+	MaxReportHashLength  int = 64
 )
 
-var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
+var _, _, _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}, MsgReportMilestone{}, MsgVoteMilestone{}
+var _, _ sdk.Msg = MsgDelegateCouncilVote{}, MsgRevokeCouncilVote{}
 
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string         `json:"title"`           //  Title of the proposal
-	Description    string         `json:"description"`     //  Description of the proposal
-	ProposalType   ProposalKind   `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
-	RequestedFund  sdk.Coins      `json:"requested_fund"`  //  Requested Proposal Fund
-	FundingCycle   uint64         `json:"funding_cycle"`   //  Fund Cycle
+	Title          string         `json:"title"`                //  Title of the proposal
+	Description    string         `json:"description"`          //  Description of the proposal
+	ProposalType   ProposalKind   `json:"proposal_type"`        //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress `json:"proposer"`             //  Address of the proposer
+	InitialDeposit sdk.Coins      `json:"initial_deposit"`      //  Initial deposit paid by sender. Must be strictly positive.
+	RequestedFund  sdk.Coins      `json:"requested_fund"`       //  Requested Proposal Fund
+	FundingCycle   uint64         `json:"funding_cycle"`        //  Fund Cycle
+	Changes        []ParamChange  `json:"changes,omitempty"`    //  Parameter changes, only for ParameterChange proposals
+	Plan           *upgrade.Plan  `json:"plan,omitempty"`       //  Upgrade plan, only for SoftwareUpgrade proposals
+	Milestones     []string       `json:"milestones,omitempty"` //  Milestones gating the tranches after the first one, only for Text proposals
 }
 
 func NewMsgSubmitProposal(title, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit, requestedFund sdk.Coins, fundingcycle uint64) MsgSubmitProposal {
@@ -41,6 +52,36 @@ func NewMsgSubmitProposal(title, description string, proposalType ProposalKind,
 	}
 }
 
+// NewMsgSubmitParameterChangeProposal creates a ParameterChange proposal message.
+// Parameter change proposals do not request any funds and run for a single cycle.
+func NewMsgSubmitParameterChangeProposal(title, description string, proposer sdk.AccAddress, initialDeposit sdk.Coins, changes []ParamChange) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		RequestedFund:  sdk.NewCoins(),
+		FundingCycle:   1,
+		Changes:        changes,
+	}
+}
+
+// NewMsgSubmitSoftwareUpgradeProposal creates a SoftwareUpgrade proposal message.
+// Software upgrade proposals do not request any funds and run for a single cycle.
+func NewMsgSubmitSoftwareUpgradeProposal(title, description string, proposer sdk.AccAddress, initialDeposit sdk.Coins, plan upgrade.Plan) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeSoftwareUpgrade,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		RequestedFund:  sdk.NewCoins(),
+		FundingCycle:   1,
+		Plan:           &plan,
+	}
+}
+
 //nolint
 func (msg MsgSubmitProposal) Route() string { return RouterKey }
 func (msg MsgSubmitProposal) Type() string  { return TypeMsgSubmitProposal }
@@ -73,17 +114,43 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if msg.InitialDeposit.IsAnyNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
-	if len(msg.RequestedFund.String()) == 0 {
-		return sdk.ErrInvalidCoins(msg.RequestedFund.String())
+	if msg.ProposalType != ProposalTypeParameterChange && len(msg.Changes) != 0 {
+		return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("Parameter changes are not allowed in %s proposals", msg.ProposalType))
 	}
-	if msg.RequestedFund.AmountOf(sdk.DefaultBondDenom).IsZero() {
-		return sdk.ErrUnauthorized("Value Should be in uclr")
+	if msg.ProposalType != ProposalTypeSoftwareUpgrade && msg.Plan != nil {
+		return ErrInvalidUpgradePlan(DefaultCodespace, fmt.Sprintf("Upgrade plans are not allowed in %s proposals", msg.ProposalType))
 	}
-	if !msg.RequestedFund.IsValid() {
-		return sdk.ErrInvalidCoins(msg.RequestedFund.String())
-	}
-	if msg.RequestedFund.IsAnyNegative() {
-		return sdk.ErrInvalidCoins(msg.RequestedFund.String())
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		if err := validateParamChanges(msg.Changes); err != nil {
+			return err
+		}
+		if !msg.RequestedFund.Empty() {
+			return ErrInvalidParamChange(DefaultCodespace, "Parameter change proposals cannot request funds")
+		}
+	case ProposalTypeSoftwareUpgrade:
+		if msg.Plan == nil {
+			return ErrInvalidUpgradePlan(DefaultCodespace, "Software upgrade proposal must contain an upgrade plan")
+		}
+		if err := msg.Plan.ValidateBasic(); err != nil {
+			return err
+		}
+		if !msg.RequestedFund.Empty() {
+			return ErrInvalidUpgradePlan(DefaultCodespace, "Software upgrade proposals cannot request funds")
+		}
+	default:
+		if len(msg.RequestedFund.String()) == 0 {
+			return sdk.ErrInvalidCoins(msg.RequestedFund.String())
+		}
+		if msg.RequestedFund.AmountOf(sdk.DefaultBondDenom).IsZero() {
+			return sdk.ErrUnauthorized("Value Should be in uclr")
+		}
+		if !msg.RequestedFund.IsValid() {
+			return sdk.ErrInvalidCoins(msg.RequestedFund.String())
+		}
+		if msg.RequestedFund.IsAnyNegative() {
+			return sdk.ErrInvalidCoins(msg.RequestedFund.String())
+		}
 	}
 	if msg.FundingCycle == 0 {
 		return sdk.ErrUnauthorized("Zero cycle is not allowed")
@@ -91,12 +158,60 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if msg.FundingCycle > 6 {
 		return sdk.ErrUnauthorized("Fund cycle more than 6 is not allowed")
 	}
+	if err := validateMilestones(msg.ProposalType, msg.FundingCycle, msg.Milestones); err != nil {
+		return err
+	}
 	if msg.InitialDeposit.AmountOf(sdk.DefaultBondDenom).LT(FeeLimit) {
 		return sdk.ErrUnauthorized("Minimum Deposit fee should be 10,000 CLR")
 	}
 	return nil
 }
 
+// validateMilestones checks that a proposal either has no milestone or one milestone
+// per tranche after the first one.
+func validateMilestones(proposalType ProposalKind, fundingCycle uint64, milestones []string) sdk.Error {
+	if len(milestones) == 0 {
+		return nil
+	}
+	if proposalType != ProposalTypeText {
+		return ErrInvalidMilestone(DefaultCodespace, fmt.Sprintf("Milestones are not allowed in %s proposals", proposalType))
+	}
+	if uint64(len(milestones)) != fundingCycle-1 {
+		return ErrInvalidMilestone(DefaultCodespace,
+			fmt.Sprintf("Proposal funded over %d cycles must have %d milestones, has %d", fundingCycle, fundingCycle-1, len(milestones)))
+	}
+	for i, milestone := range milestones {
+		if len(milestone) == 0 {
+			return ErrInvalidMilestone(DefaultCodespace, fmt.Sprintf("Milestone %d has no description", i))
+		}
+		if len(milestone) > MaxDescriptionLength {
+			return ErrInvalidMilestone(DefaultCodespace,
+				fmt.Sprintf("Milestone %d description is longer than max length of %d", i, MaxDescriptionLength))
+		}
+	}
+	return nil
+}
+
+// validateParamChanges performs the stateless checks on parameter changes.
+// Keys and values are checked against the subspace KeyTables when the proposal is submitted.
+func validateParamChanges(changes []ParamChange) sdk.Error {
+	if len(changes) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "Parameter change proposal must contain at least one change")
+	}
+	for _, change := range changes {
+		if len(change.Subspace) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "Parameter change subspace cannot be empty")
+		}
+		if len(change.Key) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "Parameter change key cannot be empty")
+		}
+		if len(change.Value) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("Parameter change %s/%s has no value", change.Subspace, change.Key))
+		}
+	}
+	return nil
+}
+
 func (msg MsgSubmitProposal) String() string {
 	return fmt.Sprintf("MsgSubmitProposal{%s, %s, %s, %v,%v}", msg.Title, msg.Description, msg.ProposalType, msg.InitialDeposit, msg.RequestedFund)
 }
@@ -212,3 +327,186 @@ func (msg MsgVote) GetSignBytes() []byte {
 func (msg MsgVote) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Voter}
 }
+
+// MsgReportMilestone
+type MsgReportMilestone struct {
+	ProposalID uint64         `json:"proposal_id"` // ID of the proposal
+	Proposer   sdk.AccAddress `json:"proposer"`    //  Address of the proposer
+	ReportHash string         `json:"report_hash"` //  Hex encoded hash of the milestone report
+}
+
+func NewMsgReportMilestone(proposer sdk.AccAddress, proposalID uint64, reportHash string) MsgReportMilestone {
+	return MsgReportMilestone{
+		ProposalID: proposalID,
+		Proposer:   proposer,
+		ReportHash: reportHash,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgReportMilestone) Route() string { return RouterKey }
+func (msg MsgReportMilestone) Type() string  { return TypeMsgReportMilestone }
+
+// Implements Msg.
+func (msg MsgReportMilestone) ValidateBasic() sdk.Error {
+	if msg.Proposer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Proposer.String())
+	}
+	hash, err := hex.DecodeString(msg.ReportHash)
+	if err != nil {
+		return ErrInvalidMilestone(DefaultCodespace, fmt.Sprintf("Report hash %s is not hex encoded", msg.ReportHash))
+	}
+	if len(hash) == 0 || len(hash) > MaxReportHashLength {
+		return ErrInvalidMilestone(DefaultCodespace,
+			fmt.Sprintf("Report hash must be between 1 and %d bytes long", MaxReportHashLength))
+	}
+	return nil
+}
+
+func (msg MsgReportMilestone) String() string {
+	return fmt.Sprintf("MsgReportMilestone{%v - %s}", msg.ProposalID, msg.ReportHash)
+}
+
+// Implements Msg.
+func (msg MsgReportMilestone) GetSignBytes() []byte {
+	bz := msgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Implements Msg.
+func (msg MsgReportMilestone) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Proposer}
+}
+
+// MsgVoteMilestone
+type MsgVoteMilestone struct {
+	ProposalID uint64         `json:"proposal_id"` // ID of the proposal
+	Voter      sdk.AccAddress `json:"voter"`       //  address of the council member
+	Option     VoteOption     `json:"option"`      //  Yes releases the next tranche, No stops the funding
+}
+
+func NewMsgVoteMilestone(voter sdk.AccAddress, proposalID uint64, option VoteOption) MsgVoteMilestone {
+	return MsgVoteMilestone{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Option:     option,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgVoteMilestone) Route() string { return RouterKey }
+func (msg MsgVoteMilestone) Type() string  { return TypeMsgVoteMilestone }
+
+// Implements Msg.
+func (msg MsgVoteMilestone) ValidateBasic() sdk.Error {
+	if msg.Voter.Empty() {
+		return sdk.ErrInvalidAddress(msg.Voter.String())
+	}
+	if !validVoteOption(msg.Option) {
+		return ErrInvalidVote(DefaultCodespace, msg.Option)
+	}
+	return nil
+}
+
+func (msg MsgVoteMilestone) String() string {
+	return fmt.Sprintf("MsgVoteMilestone{%v - %s}", msg.ProposalID, msg.Option)
+}
+
+// Implements Msg.
+func (msg MsgVoteMilestone) GetSignBytes() []byte {
+	bz := msgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Implements Msg.
+func (msg MsgVoteMilestone) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+// MsgDelegateCouncilVote
+type MsgDelegateCouncilVote struct {
+	Delegator sdk.AccAddress `json:"delegator"` //  address of the council member delegating its vote
+	Proxy     sdk.AccAddress `json:"proxy"`     //  address of the council member voting in its place
+}
+
+func NewMsgDelegateCouncilVote(delegator, proxy sdk.AccAddress) MsgDelegateCouncilVote {
+	return MsgDelegateCouncilVote{
+		Delegator: delegator,
+		Proxy:     proxy,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgDelegateCouncilVote) Route() string { return RouterKey }
+func (msg MsgDelegateCouncilVote) Type() string  { return TypeMsgDelegateCouncilVote }
+
+// Implements Msg.
+func (msg MsgDelegateCouncilVote) ValidateBasic() sdk.Error {
+	if msg.Delegator.Empty() {
+		return sdk.ErrInvalidAddress(msg.Delegator.String())
+	}
+	if msg.Proxy.Empty() {
+		return sdk.ErrInvalidAddress(msg.Proxy.String())
+	}
+	if msg.Delegator.Equals(msg.Proxy) {
+		return ErrInvalidCouncilProxy(DefaultCodespace, "council members cannot delegate their vote to themselves")
+	}
+	return nil
+}
+
+func (msg MsgDelegateCouncilVote) String() string {
+	return fmt.Sprintf("MsgDelegateCouncilVote{%s -> %s}", msg.Delegator, msg.Proxy)
+}
+
+// Implements Msg.
+func (msg MsgDelegateCouncilVote) GetSignBytes() []byte {
+	bz := msgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Implements Msg.
+func (msg MsgDelegateCouncilVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+// MsgRevokeCouncilVote
+type MsgRevokeCouncilVote struct {
+	Delegator sdk.AccAddress `json:"delegator"` //  address of the council member taking its vote back
+}
+
+func NewMsgRevokeCouncilVote(delegator sdk.AccAddress) MsgRevokeCouncilVote {
+	return MsgRevokeCouncilVote{
+		Delegator: delegator,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgRevokeCouncilVote) Route() string { return RouterKey }
+func (msg MsgRevokeCouncilVote) Type() string  { return TypeMsgRevokeCouncilVote }
+
+// Implements Msg.
+func (msg MsgRevokeCouncilVote) ValidateBasic() sdk.Error {
+	if msg.Delegator.Empty() {
+		return sdk.ErrInvalidAddress(msg.Delegator.String())
+	}
+	return nil
+}
+
+func (msg MsgRevokeCouncilVote) String() string {
+	return fmt.Sprintf("MsgRevokeCouncilVote{%s}", msg.Delegator)
+}
+
+// Implements Msg.
+func (msg MsgRevokeCouncilVote) GetSignBytes() []byte {
+	bz := msgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Implements Msg.
+func (msg MsgRevokeCouncilVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
@@ -3,11 +3,13 @@ package gov
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	"github.com/ColorPlatform/color-sdk/x/mock"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 var (
@@ -34,8 +36,8 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.AccAddress{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -54,6 +56,77 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for parameter change proposals
+func TestMsgSubmitParameterChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.NewCoins())
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000000))
+	change := NewParamChange(DefaultParamspace, string(ParamStoreKeyTallyParams), `{"quorum":"0.2","threshold":"0.5"}`)
+	tests := []struct {
+		changes    []ParamChange
+		expectPass bool
+	}{
+		{[]ParamChange{change}, true},
+		{nil, false},
+		{[]ParamChange{NewParamChange("", change.Key, change.Value)}, false},
+		{[]ParamChange{NewParamChange(change.Subspace, "", change.Value)}, false},
+		{[]ParamChange{NewParamChange(change.Subspace, change.Key, "")}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", addrs[0], deposit, tc.changes)
+		if tc.expectPass {
+			require.NoError(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.Error(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// parameter changes cannot request funds
+	msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", addrs[0], deposit, []ParamChange{change})
+	msg.RequestedFund = coinsPos
+	require.Error(t, msg.ValidateBasic())
+
+	// and other proposal types cannot carry parameter changes
+	msg = NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], deposit, coinsPos, 1)
+	msg.Changes = []ParamChange{change}
+	require.Error(t, msg.ValidateBasic())
+}
+
+// test ValidateBasic for software upgrade proposals
+func TestMsgSubmitSoftwareUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.NewCoins())
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10000000000))
+	tests := []struct {
+		plan       upgrade.Plan
+		expectPass bool
+	}{
+		{upgrade.NewPlan("v2", 100, time.Time{}, "info"), true},
+		{upgrade.NewPlan("v2", 0, time.Now(), "info"), true},
+		{upgrade.NewPlan("", 100, time.Time{}, "info"), false},
+		{upgrade.NewPlan("v2", 0, time.Time{}, "info"), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", addrs[0], deposit, tc.plan)
+		if tc.expectPass {
+			require.NoError(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.Error(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// software upgrades cannot request funds
+	plan := upgrade.NewPlan("v2", 100, time.Time{}, "info")
+	msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", addrs[0], deposit, plan)
+	msg.RequestedFund = coinsPos
+	require.Error(t, msg.ValidateBasic())
+
+	// and other proposal types cannot carry a plan
+	msg = NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], deposit, coinsPos, 1)
+	msg.Plan = &plan
+	require.Error(t, msg.ValidateBasic())
+}
+
 func TestMsgDepositGetSignBytes(t *testing.T) {
 	addr := sdk.AccAddress("addr1")
 	msg := NewMsgDeposit(addr, 0, coinsPos)
@@ -113,3 +186,65 @@ func TestMsgVote(t *testing.T) {
 		}
 	}
 }
+
+func TestMsgSubmitProposalMilestones(t *testing.T) {
+	tests := []struct {
+		proposalType ProposalKind
+		fundingCycle uint64
+		milestones   []string
+		expectPass   bool
+	}{
+		{ProposalTypeText, 3, nil, true},
+		{ProposalTypeText, 3, []string{"first", "second"}, true},
+		{ProposalTypeText, 3, []string{"first"}, false},
+		{ProposalTypeText, 1, []string{"first"}, false},
+		{ProposalTypeText, 2, []string{""}, false},
+		{ProposalTypeParameterChange, 2, []string{"first"}, false},
+	}
+
+	for i, tc := range tests {
+		require.Equal(t, tc.expectPass, validateMilestones(tc.proposalType, tc.fundingCycle, tc.milestones) == nil, "test: %v", i)
+	}
+}
+
+func TestMsgReportMilestone(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.NewCoins())
+	tests := []struct {
+		proposer   sdk.AccAddress
+		reportHash string
+		expectPass bool
+	}{
+		{addrs[0], "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", true},
+		{sdk.AccAddress{}, "9f86d081", false},
+		{addrs[0], "", false},
+		{addrs[0], "not hex", false},
+		{addrs[0], strings.Repeat("ab", MaxReportHashLength+1), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgReportMilestone(tc.proposer, 1, tc.reportHash)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgVoteMilestone(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.NewCoins())
+	require.Nil(t, NewMsgVoteMilestone(addrs[0], 1, OptionYes).ValidateBasic())
+	require.Nil(t, NewMsgVoteMilestone(addrs[0], 1, OptionNo).ValidateBasic())
+	require.NotNil(t, NewMsgVoteMilestone(sdk.AccAddress{}, 1, OptionYes).ValidateBasic())
+	require.NotNil(t, NewMsgVoteMilestone(addrs[0], 1, VoteOption(0x13)).ValidateBasic())
+}
+
+func TestMsgDelegateCouncilVote(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(2, sdk.NewCoins())
+	require.Nil(t, NewMsgDelegateCouncilVote(addrs[0], addrs[1]).ValidateBasic())
+	require.NotNil(t, NewMsgDelegateCouncilVote(addrs[0], addrs[0]).ValidateBasic())
+	require.NotNil(t, NewMsgDelegateCouncilVote(sdk.AccAddress{}, addrs[1]).ValidateBasic())
+	require.NotNil(t, NewMsgDelegateCouncilVote(addrs[0], sdk.AccAddress{}).ValidateBasic())
+	require.Nil(t, NewMsgRevokeCouncilVote(addrs[0]).ValidateBasic())
+	require.NotNil(t, NewMsgRevokeCouncilVote(sdk.AccAddress{}).ValidateBasic())
+}
@@ -9,19 +9,55 @@ import (
 
 // Param around deposits for governance
 type DepositParams struct {
-	MinDeposit       sdk.Coins     `json:"min_deposit"`        //  Minimum deposit for a proposal to enter voting period.
-	MaxDepositPeriod time.Duration `json:"max_deposit_period"` //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
+	MinDeposit       sdk.Coins            `json:"min_deposit"`        //  Minimum deposit for a proposal to enter voting period.
+	MaxDepositPeriod time.Duration        `json:"max_deposit_period"` //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
+	DroppedDeposits  DroppedDepositPolicy `json:"dropped_deposits"`   //  What happens to the deposits of dropped and rejected proposals. Initial value: burn
 }
 
 func (dp DepositParams) String() string {
 	return fmt.Sprintf(`Deposit Params:
   Min Deposit:        %s
-  Max Deposit Period: %s`, dp.MinDeposit, dp.MaxDepositPeriod)
+  Max Deposit Period: %s
+  Dropped Deposits:   %s`, dp.MinDeposit, dp.MaxDepositPeriod, dp.DroppedDeposits)
 }
 
 // Checks equality of DepositParams
 func (dp DepositParams) Equal(dp2 DepositParams) bool {
-	return dp.MinDeposit.IsEqual(dp2.MinDeposit) && dp.MaxDepositPeriod == dp2.MaxDepositPeriod
+	return dp.MinDeposit.IsEqual(dp2.MinDeposit) && dp.MaxDepositPeriod == dp2.MaxDepositPeriod &&
+		dp.DroppedDeposits == dp2.DroppedDeposits
+}
+
+// Validate checks the params, including those changed by governance proposals
+func (dp DepositParams) Validate() error {
+	if !dp.MinDeposit.IsValid() {
+		return fmt.Errorf("Governance deposit amount must be a valid sdk.Coins amount, is %s",
+			dp.MinDeposit.String())
+	}
+	if !dp.DroppedDeposits.IsValid() {
+		return fmt.Errorf("Governance dropped deposit policy %q is not valid", dp.DroppedDeposits)
+	}
+	return nil
+}
+
+// DroppedDepositPolicy defines what happens to the deposits of the proposals
+// that are dropped or rejected
+type DroppedDepositPolicy string
+
+const (
+	// DroppedDepositsBurn removes the deposits from the supply
+	DroppedDepositsBurn DroppedDepositPolicy = "burn"
+	// DroppedDepositsCommunityPool sends the deposits to the community pool
+	DroppedDepositsCommunityPool DroppedDepositPolicy = "community_pool"
+)
+
+// IsValid returns true if the dropped deposit policy is known
+func (ddp DroppedDepositPolicy) IsValid() bool {
+	switch ddp {
+	case DroppedDepositsBurn, DroppedDepositsCommunityPool:
+		return true
+	default:
+		return false
+	}
 }
 
 // Param around Tallying votes in governance
@@ -37,6 +73,16 @@ func (tp TallyParams) String() string {
 		tp.Quorum, tp.Threshold)
 }
 
+// Validate checks the params, including those changed by governance proposals
+func (tp TallyParams) Validate() error {
+	threshold := tp.Threshold
+	if threshold.IsNil() || threshold.IsNegative() || threshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("Governance vote threshold should be positive and less or equal to one, is %s",
+			threshold.String())
+	}
+	return nil
+}
+
 // Param around Voting in governance
 type VotingParams struct {
 	VotingPeriod time.Duration `json:"voting_period"` //  Length of the voting period.
@@ -47,22 +93,76 @@ func (vp VotingParams) String() string {
   Voting Period:      %s`, vp.VotingPeriod)
 }
 
+// Param around funding cycles
+type FundingParams struct {
+	CycleDuration time.Duration `json:"cycle_duration"`  //  Length of a funding cycle. Initial value: 4 weeks
+	FreezeWindow  time.Duration `json:"freeze_window"`   //  Period at the end of a cycle during which votes and deposits are refused. Initial value: 2 days
+	MaxCycleCount uint64        `json:"max_cycle_count"` //  Number of cycles a proposal can stay without majority before it is rejected. Initial value: 2
+	TreasuryShare sdk.Dec       `json:"treasury_share"`  //  Share of the treasury income of a cycle that can be paid to proposals. Initial value: 0.5
+	Policy        FundingPolicy `json:"policy"`          //  How the budget of a cycle is spent. Initial value: skip
+}
+
+func (fp FundingParams) String() string {
+	return fmt.Sprintf(`Funding Params:
+  Cycle Duration:     %s
+  Freeze Window:      %s
+  Max Cycle Count:    %d
+  Treasury Share:     %s
+  Policy:             %s`,
+		fp.CycleDuration, fp.FreezeWindow, fp.MaxCycleCount, fp.TreasuryShare, fp.Policy)
+}
+
+// Validate checks the params, including those changed by governance proposals,
+// so that the funding cycles of the EndBlocker stay well formed
+func (fp FundingParams) Validate() error {
+	return validateFundingParams(fp)
+}
+
+// FundingPolicy defines how the treasury budget of a funding cycle is spent on
+// the ranked proposals
+type FundingPolicy string
+
+const (
+	// FundingPolicySkip funds proposals in ranking order and skips those that do
+	// not fit in the remaining budget. The unspent budget is lost.
+	FundingPolicySkip FundingPolicy = "skip"
+	// FundingPolicyPartial funds the first proposal that does not fit in the
+	// remaining budget with what is left of it.
+	FundingPolicyPartial FundingPolicy = "partial"
+	// FundingPolicyCarryOver skips the proposals that do not fit and carries the
+	// unspent budget over to the next funding cycle.
+	FundingPolicyCarryOver FundingPolicy = "carry_over"
+)
+
+// IsValid returns true if the funding policy is known
+func (fp FundingPolicy) IsValid() bool {
+	switch fp {
+	case FundingPolicySkip, FundingPolicyPartial, FundingPolicyCarryOver:
+		return true
+	default:
+		return false
+	}
+}
+
 // Params returns all of the governance params
 type Params struct {
 	VotingParams  VotingParams  `json:"voting_params"`
 	TallyParams   TallyParams   `json:"tally_params"`
 	DepositParams DepositParams `json:"deposit_params"`
+	FundingParams FundingParams `json:"funding_params"`
 }
 
 func (gp Params) String() string {
 	return gp.VotingParams.String() + "\n" +
-		gp.TallyParams.String() + "\n" + gp.DepositParams.String()
+		gp.TallyParams.String() + "\n" + gp.DepositParams.String() + "\n" +
+		gp.FundingParams.String()
 }
 
-func NewParams(vp VotingParams, tp TallyParams, dp DepositParams) Params {
+func NewParams(vp VotingParams, tp TallyParams, dp DepositParams, fp FundingParams) Params {
 	return Params{
 		VotingParams:  vp,
 		DepositParams: dp,
 		TallyParams:   tp,
+		FundingParams: fp,
 	}
 }
@@ -3,16 +3,11 @@ package gov
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
-)
-
-const (
-	//MaxCycleCount store the max limit for proposal
-	MaxCycleCount = 2
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 )
 
 // Proposal is a struct used by gov module internally
@@ -59,8 +54,9 @@ func (p Proposal) IsZeroRemainingCycle() bool {
 	return p.RemainingFundingCycle == 0
 }
 
-func (p Proposal) CheckMaxCycleCount() bool {
-	return p.FundingCycleCount == MaxCycleCount
+// CheckMaxCycleCount returns true once the proposal stayed for the max number of cycles without majority
+func (p Proposal) CheckMaxCycleCount(maxCycleCount uint64) bool {
+	return p.FundingCycleCount >= maxCycleCount
 }
 func (p Proposal) ReduceCycleCount() Proposal {
 
@@ -132,11 +128,13 @@ func (tp TextProposal) GetProposer() sdk.AccAddress { return tp.Proposer }
 // Software Upgrade Proposals
 type SoftwareUpgradeProposal struct {
 	TextProposal
+	Plan upgrade.Plan `json:"plan"` //  Upgrade plan scheduled once the proposal passes
 }
 
-func NewSoftwareUpgradeProposal(title, description string, requestfund sdk.Coins, fundingcycle uint64, proposer sdk.AccAddress) SoftwareUpgradeProposal {
+func NewSoftwareUpgradeProposal(title, description string, plan upgrade.Plan, proposer sdk.AccAddress) SoftwareUpgradeProposal {
 	return SoftwareUpgradeProposal{
-		TextProposal: NewTextProposal(title, description, requestfund, fundingcycle, proposer),
+		TextProposal: NewTextProposal(title, description, sdk.NewCoins(), 1, proposer),
+		Plan:         plan,
 	}
 }
 
@@ -146,6 +144,44 @@ var _ ProposalContent = SoftwareUpgradeProposal{}
 // nolint
 func (sup SoftwareUpgradeProposal) ProposalType() ProposalKind { return ProposalTypeSoftwareUpgrade }
 
+// ParamChange defines a single parameter update carried by a ParameterChangeProposal
+type ParamChange struct {
+	Subspace string `json:"subspace"` //  Name of the params subspace, e.g. "staking"
+	Key      string `json:"key"`      //  Parameter key registered in the subspace KeyTable
+	Value    string `json:"value"`    //  JSON encoded value, as stored by the params subspace
+}
+
+func NewParamChange(subspace, key, value string) ParamChange {
+	return ParamChange{
+		Subspace: subspace,
+		Key:      key,
+		Value:    value,
+	}
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s: %s", pc.Subspace, pc.Key, pc.Value)
+}
+
+// Parameter Change Proposals
+type ParameterChangeProposal struct {
+	TextProposal
+	Changes []ParamChange `json:"changes"` //  Parameter changes applied once the proposal passes
+}
+
+func NewParameterChangeProposal(title, description string, changes []ParamChange, proposer sdk.AccAddress) ParameterChangeProposal {
+	return ParameterChangeProposal{
+		TextProposal: NewTextProposal(title, description, sdk.NewCoins(), 1, proposer),
+		Changes:      changes,
+	}
+}
+
+// Implements Proposal Interface
+var _ ProposalContent = ParameterChangeProposal{}
+
+// nolint
+func (pcp ParameterChangeProposal) ProposalType() ProposalKind { return ProposalTypeParameterChange }
+
 // ProposalQueue
 type ProposalQueue []uint64
 
@@ -186,6 +222,12 @@ func validProposalType(pt ProposalKind) bool {
 	return false
 }
 
+// RequestsFunds returns true if proposals of this type request funds from the treasury
+// and are ranked for funding. Parameter changes and software upgrades are executed instead.
+func (pt ProposalKind) RequestsFunds() bool {
+	return pt == ProposalTypeText
+}
+
 // Marshal needed for protobuf compatibility
 func (pt ProposalKind) Marshal() ([]byte, error) {
 	return []byte{byte(pt)}, nil
@@ -386,6 +428,16 @@ func (tr TallyResult) Equals(comp TallyResult) bool {
 		tr.No.Equal(comp.No))
 }
 
+// NetVotes returns the yes votes minus the no votes
+func (tr TallyResult) NetVotes() sdk.Int {
+	return tr.Yes.Sub(tr.No)
+}
+
+// Turnout returns the total of the votes cast
+func (tr TallyResult) Turnout() sdk.Int {
+	return tr.Yes.Add(tr.No).Add(tr.Abstain)
+}
+
 func (tr TallyResult) String() string {
 	return fmt.Sprintf(`Tally Result:
   Yes:        %s
@@ -393,13 +445,10 @@ func (tr TallyResult) String() string {
   No:         %s`, tr.Yes, tr.Abstain, tr.No)
 }
 
-///ExpectedTreasureIncome Calculate Funding requested must be no more than 50% of Treasury income per cycle
+///ExpectedTreasureIncome Calculate Funding requested must be less than the treasury share of the income per cycle
 func ExpectedTreasureIncome(keeper Keeper, ctx sdk.Context, Requestedfund sdk.Int) bool {
 	limit := keeper.GetTreasuryWeeklyIncome(ctx)
-	formula := 0.5 //Deduct 5%
-	num1, _ := strconv.ParseFloat(limit.String(), 64)
-	formula = formula * num1
-	var treasuryIncome sdk.Int = sdk.NewInt(int64(formula))
+	treasuryIncome := limit.Mul(keeper.GetFundingParams(ctx).TreasuryShare).TruncateInt()
 	if !(Requestedfund.LT(treasuryIncome)) {
 		return true
 	}
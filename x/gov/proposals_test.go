@@ -73,7 +73,7 @@ func TestProposalSubmission(t *testing.T) {
 	staking.EndBlocker(ctx, sk)
 
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 3
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -2,6 +2,7 @@ package gov
 
 import (
 	"fmt"
+	"sort"
 
 	abci "github.com/ColorPlatform/prism/abci/types"
 
@@ -11,19 +12,26 @@ import (
 
 // query endpoints supported by the governance Querier
 const (
-	QueryParams    = "params"
-	QueryProposals = "proposals"
-	QueryProposal  = "proposal"
-	QueryDeposits  = "deposits"
-	QueryDeposit   = "deposit"
-	QueryVotes     = "votes"
-	QueryVote      = "vote"
-	QueryTally     = "tally"
-	QueryCycle     = "fundingcycle"
-	QueryCycles    = "fundingcycles"
-	ParamDeposit   = "deposit"
-	ParamVoting    = "voting"
-	ParamTallying  = "tallying"
+	QueryParams       = "params"
+	QueryProposals    = "proposals"
+	QueryProposal     = "proposal"
+	QueryDeposits     = "deposits"
+	QueryDeposit      = "deposit"
+	QueryVotes        = "votes"
+	QueryVote         = "vote"
+	QueryTally        = "tally"
+	QueryCycle        = "fundingcycle"
+	QueryCycles       = "fundingcycles"
+	QueryRanking      = "ranking"
+	QueryMilestones   = "milestones"
+	QueryVotingPower  = "voting_power"
+	QueryCurrentCycle = "currentfundingcycle"
+	QueryCycleReport  = "fundingcyclereport"
+	QueryEligibility  = "eligibility"
+	ParamDeposit      = "deposit"
+	ParamVoting       = "voting"
+	ParamTallying     = "tallying"
+	ParamFunding      = "funding"
 )
 
 func NewQuerier(keeper Keeper) sdk.Querier {
@@ -49,6 +57,18 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryFuncingCycle(ctx, path[1:], req, keeper)
 		case QueryCycles:
 			return queryFuncingCycles(ctx, path[1:], req, keeper)
+		case QueryRanking:
+			return queryRanking(ctx, path[1:], req, keeper)
+		case QueryMilestones:
+			return queryMilestones(ctx, path[1:], req, keeper)
+		case QueryVotingPower:
+			return queryVotingPower(ctx, path[1:], req, keeper)
+		case QueryCurrentCycle:
+			return queryCurrentFundingCycle(ctx, path[1:], req, keeper)
+		case QueryCycleReport:
+			return queryFundingCycleReport(ctx, path[1:], req, keeper)
+		case QueryEligibility:
+			return queryEligibility(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -75,6 +95,12 @@ func queryParams(ctx sdk.Context, path []string, req abci.RequestQuery, keeper K
 			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
 		}
 		return bz, nil
+	case ParamFunding:
+		bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetFundingParams(ctx))
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
 	default:
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("%s is not a valid query request path", req.Path))
 	}
@@ -324,9 +350,57 @@ func queryFuncingCycle(ctx sdk.Context, path []string, req abci.RequestQuery, ke
 	return bz, nil
 }
 
+// creates a new instance of QueryPageParams
+func NewQueryPageParams(page, limit int) QueryPageParams {
+	return QueryPageParams{
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+// Params for paginated queries such as 'custom/gov/fundingcycles'. Page starts
+// at 1 and a zero Limit returns every entry.
+type QueryPageParams struct {
+	Page  int
+	Limit int
+}
+
+// pageBounds returns the bounds of the requested page in a list of the given length
+func (p QueryPageParams) pageBounds(length int) (start, end int) {
+	if p.Limit <= 0 {
+		return 0, length
+	}
+	page := p.Page
+	if page < 1 {
+		page = 1
+	}
+	start = (page - 1) * p.Limit
+	end = start + p.Limit
+	if start > length {
+		start = length
+	}
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
 func queryFuncingCycles(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryPageParams
+	// requests without parameters return every funding cycle
+	if len(req.Data) != 0 {
+		err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+	}
 
 	fundingCycles := keeper.GetAllFundingCycle(ctx)
+	sort.Slice(fundingCycles, func(i, j int) bool {
+		return fundingCycles[i].CycleID < fundingCycles[j].CycleID
+	})
+	start, end := params.pageBounds(len(fundingCycles))
+	fundingCycles = fundingCycles[start:end]
 
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, fundingCycles)
 	if err != nil {
@@ -334,3 +408,117 @@ func queryFuncingCycles(ctx sdk.Context, path []string, req abci.RequestQuery, k
 	}
 	return bz, nil
 }
+
+func queryRanking(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryFuncingCycleParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	ranking, found := keeper.GetRanking(ctx, params.CycleID)
+	if !found {
+		return nil, ErrInvalidCycle(keeper.codespace, fmt.Sprintf("no ranking for funding cycle %d", params.CycleID))
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, ranking)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryMilestones(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryProposalParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	_, ok := keeper.GetProposal(ctx, params.ProposalID)
+	if !ok {
+		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
+	}
+
+	milestones := keeper.GetMilestones(ctx, params.ProposalID)
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, milestones)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryVotingPower(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryProposalParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	_, ok := keeper.GetProposal(ctx, params.ProposalID)
+	if !ok {
+		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
+	}
+
+	votesIterator := keeper.GetVotes(ctx, params.ProposalID)
+	defer votesIterator.Close()
+	council := keeper.getTallyCouncil(ctx, params.ProposalID)
+	powers := keeper.getCouncilVoterPowers(council, collectVotes(keeper, votesIterator))
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, powers)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryCurrentFundingCycle(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	current, errRes := keeper.GetCurrentFundingCycle(ctx)
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, current)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryFundingCycleReport(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryFuncingCycleParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	report, found := keeper.GetFundingCycleReport(ctx, params.CycleID)
+	if !found {
+		return nil, ErrInvalidCycle(keeper.codespace, fmt.Sprintf("funding cycle %d not found", params.CycleID))
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, report)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryEligibility(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryPageParams
+	if len(req.Data) != 0 {
+		err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+	}
+
+	eligible := keeper.GetEligibleProposals(ctx)
+	start, end := params.pageBounds(len(eligible))
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, eligible[start:end])
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
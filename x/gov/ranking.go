@@ -0,0 +1,111 @@
+package gov
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// RankedProposal is the position of a proposal in the funding ranking of a cycle
+type RankedProposal struct {
+	Rank          uint64    `json:"rank"`           //  Position of the proposal, starting at 1
+	ProposalID    uint64    `json:"proposal_id"`    //  ID of the proposal
+	NetVotes      sdk.Int   `json:"net_votes"`      //  Yes minus No council votes
+	Turnout       sdk.Int   `json:"turnout"`        //  Yes, No and Abstain council votes
+	SubmitTime    time.Time `json:"submit_time"`    //  Time the proposal was submitted
+	RequestedFund sdk.Coins `json:"requested_fund"` //  Funds requested for the cycle
+	Funded        bool      `json:"funded"`         //  Whether the proposal was paid out in the cycle
+	FundedAmount  sdk.Coins `json:"funded_amount"`  //  Amount paid out, lower than requested when partially funded
+}
+
+// RankedProposals is a collection of RankedProposal
+type RankedProposals []RankedProposal
+
+// nolint
+func (rs RankedProposals) String() string {
+	out := "Rank - ProposalID - NetVotes - Turnout - RequestedFund\n"
+	for _, p := range rs {
+		out += fmt.Sprintf("%d - %d - %s - %s - %s\n", p.Rank, p.ProposalID, p.NetVotes, p.Turnout, p.RequestedFund)
+	}
+	return strings.TrimSpace(out)
+}
+
+// Ranking is the snapshot of the proposal ranking taken at the end of a funding cycle
+type Ranking struct {
+	CycleID   uint64           `json:"cycle_id"`
+	Proposals []RankedProposal `json:"proposals"`
+}
+
+// NewRanking builds the ranking of a funding cycle from proposals and their
+// tally results, which must already be sorted by RankProposals
+func NewRanking(cycleID uint64, proposals []Proposal, results []TallyResult) Ranking {
+	ranked := make([]RankedProposal, len(proposals))
+	for i, proposal := range proposals {
+		ranked[i] = RankedProposal{
+			Rank:          uint64(i + 1),
+			ProposalID:    proposal.ProposalID,
+			NetVotes:      results[i].NetVotes(),
+			Turnout:       results[i].Turnout(),
+			SubmitTime:    proposal.SubmitTime,
+			RequestedFund: proposal.GetRequestedFund(),
+		}
+	}
+	return Ranking{CycleID: cycleID, Proposals: ranked}
+}
+
+// nolint
+func (r Ranking) String() string {
+	out := fmt.Sprintf("Ranking for funding cycle %d:\n", r.CycleID)
+	out += "Rank - ProposalID - NetVotes - Turnout - RequestedFund - FundedAmount\n"
+	for _, p := range r.Proposals {
+		out += fmt.Sprintf("%d - %d - %s - %s - %s - %s\n",
+			p.Rank, p.ProposalID, p.NetVotes, p.Turnout, p.RequestedFund, p.FundedAmount)
+	}
+	return strings.TrimSpace(out)
+}
+
+// RankProposals sorts proposals, together with their tally results, by funding
+// priority. Proposals are ordered by net council votes (yes - no), then by
+// turnout, then by submit time (earliest first) and finally by proposal ID, so
+// the ordering is total and the same on every node.
+func RankProposals(proposals []Proposal, results []TallyResult) ([]Proposal, []TallyResult) {
+	if len(proposals) != len(results) {
+		panic(fmt.Sprintf("ranking %d proposals with %d tally results", len(proposals), len(results)))
+	}
+
+	order := make([]int, len(proposals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		return rankedBefore(proposals[a], results[a], proposals[b], results[b])
+	})
+
+	sortedProposals := make([]Proposal, len(proposals))
+	sortedResults := make([]TallyResult, len(results))
+	for i, idx := range order {
+		sortedProposals[i] = proposals[idx]
+		sortedResults[i] = results[idx]
+	}
+	return sortedProposals, sortedResults
+}
+
+// rankedBefore reports whether proposal a ranks strictly before proposal b
+func rankedBefore(a Proposal, resultA TallyResult, b Proposal, resultB TallyResult) bool {
+	netA, netB := resultA.NetVotes(), resultB.NetVotes()
+	if !netA.Equal(netB) {
+		return netA.GT(netB)
+	}
+	turnoutA, turnoutB := resultA.Turnout(), resultB.Turnout()
+	if !turnoutA.Equal(turnoutB) {
+		return turnoutA.GT(turnoutB)
+	}
+	if !a.SubmitTime.Equal(b.SubmitTime) {
+		return a.SubmitTime.Before(b.SubmitTime)
+	}
+	return a.ProposalID < b.ProposalID
+}
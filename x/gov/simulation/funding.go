@@ -0,0 +1,165 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ColorPlatform/color-sdk/baseapp"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/auth"
+	"github.com/ColorPlatform/color-sdk/x/gov"
+	"github.com/ColorPlatform/color-sdk/x/simulation"
+)
+
+// minInitialDeposit is the lowest initial deposit accepted with a proposal
+var minInitialDeposit = sdk.NewInt(10000000000)
+
+// SimulateFundingCycle simulates a funding proposal over several funding cycles.
+// The proposal requests part of the treasury budget of a cycle and is submitted
+// with at least the minimum deposit, so it enters its voting period at once. The council
+// members of the proposal vote before the freeze window of the cycle, then the
+// simulation advances to the end of the cycle and checks its funding.
+func SimulateFundingCycle(m auth.AccountKeeper, k gov.Keeper) simulation.Operation {
+	handler := gov.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		cycle, sdkErr := k.GetCurrentFundingCycle(ctx)
+		if sdkErr != nil || cycle.Frozen {
+			return simulation.NoOpMsg(), nil, nil
+		}
+
+		// 1) submit a proposal requesting less than the treasury share of the cycle
+		fundingParams := k.GetFundingParams(ctx)
+		limit := k.GetTreasuryWeeklyIncome(ctx).Mul(fundingParams.TreasuryShare).TruncateInt()
+		if limit.LT(sdk.NewInt(2)) {
+			return simulation.NoOpMsg(), nil, nil
+		}
+		requested := simulation.RandomAmount(r, limit.SubRaw(2)).AddRaw(1)
+		deposit := k.GetDepositParams(ctx).MinDeposit
+		if deposit.AmountOf(sdk.DefaultBondDenom).LT(minInitialDeposit) {
+			deposit = sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, minInitialDeposit))
+		}
+		sender, found := randomAccWithCoins(r, m, ctx, accs, deposit)
+		if !found {
+			return simulation.NoOpMsg(), nil, nil
+		}
+		msg := gov.NewMsgSubmitProposal(
+			simulation.RandStringOfLength(r, 5),
+			simulation.RandStringOfLength(r, 5),
+			gov.ProposalTypeText,
+			sender.Address,
+			deposit,
+			sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, requested)),
+			uint64(simulation.RandIntBetween(r, 2, 7)),
+		)
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+		ok := simulateHandleMsgSubmitProposal(msg, handler, ctx)
+		opMsg = simulation.NewOperationMsg(msg, ok, "")
+		if !ok {
+			return opMsg, nil, nil
+		}
+		proposalID := k.GetLastProposalID(ctx)
+
+		// 2) schedule the votes of the council the proposal is tallied against
+		fops := []simulation.FutureOperation{}
+		snapshot, found := k.GetCouncilSnapshot(ctx, proposalID)
+		votingTime := cycle.FreezeStartTime.Sub(ctx.BlockHeader().Time)
+		if found && votingTime > 0 {
+			for _, member := range snapshot.Members {
+				if r.Intn(4) == 0 {
+					continue
+				}
+				whenVote := ctx.BlockHeader().Time.Add(time.Duration(r.Int63n(int64(votingTime))))
+				fops = append(fops, simulation.FutureOperation{
+					BlockTime: whenVote,
+					Op:        operationSimulateCouncilVote(k, member.Address, proposalID),
+				})
+			}
+		}
+
+		// 3) advance to the end of the cycle, which ends in the block after its
+		// end time, and check its funding in the next block
+		fops = append(fops, simulation.FutureOperation{
+			BlockTime:   cycle.FundingCycle.CycleEndTime.Add(time.Second),
+			Op:          operationCheckFundingCycle(k, cycle.FundingCycle.CycleID),
+			AdvanceTime: true,
+		})
+		return opMsg, fops, nil
+	}
+}
+
+// operationSimulateCouncilVote simulates the vote of a council member on a proposal
+func operationSimulateCouncilVote(k gov.Keeper, voter sdk.AccAddress, proposalID uint64) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		msg := gov.NewMsgVote(voter, proposalID, randomVotingOption(r))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		ctx, write := ctx.CacheContext()
+		ok := gov.NewHandler(k)(ctx, msg).IsOK()
+		if ok {
+			write()
+		}
+
+		opMsg = simulation.NewOperationMsg(msg, ok, "")
+		return opMsg, nil, nil
+	}
+}
+
+// operationCheckFundingCycle checks that a funding cycle ended, that it did not
+// pay out more than its treasury budget, and that its ranking is consistent
+func operationCheckFundingCycle(k gov.Keeper, cycleID uint64) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		opMsg = simulation.NewOperationMsgBasic(gov.RouterKey, "check_funding_cycle", "", true, nil)
+		current, sdkErr := k.GetCurrentCycle(ctx)
+		if sdkErr != nil || current.CycleID <= cycleID {
+			return opMsg, nil, fmt.Errorf("funding cycle %d did not end at %s", cycleID, ctx.BlockHeader().Time)
+		}
+
+		if err = gov.FundingCyclesInvariant(k)(ctx); err != nil {
+			return opMsg, nil, err
+		}
+
+		ranking, found := k.GetRanking(ctx, cycleID)
+		if !found {
+			return opMsg, nil, nil
+		}
+		for i, ranked := range ranking.Proposals {
+			if ranked.Rank != uint64(i+1) {
+				return opMsg, nil, fmt.Errorf("proposal %d ranked %d at position %d of funding cycle %d",
+					ranked.ProposalID, ranked.Rank, i+1, cycleID)
+			}
+			if i > 0 && ranked.NetVotes.GT(ranking.Proposals[i-1].NetVotes) {
+				return opMsg, nil, fmt.Errorf("proposal %d ranked below proposal %d with more net votes in funding cycle %d",
+					ranked.ProposalID, ranking.Proposals[i-1].ProposalID, cycleID)
+			}
+			if ranked.Funded != !ranked.FundedAmount.IsZero() || !ranked.RequestedFund.IsAllGTE(ranked.FundedAmount) {
+				return opMsg, nil, fmt.Errorf("proposal %d funded with %s for a request of %s in funding cycle %d",
+					ranked.ProposalID, ranked.FundedAmount, ranked.RequestedFund, cycleID)
+			}
+		}
+		return opMsg, nil, nil
+	}
+}
+
+// randomAccWithCoins picks a random account holding at least the given coins
+func randomAccWithCoins(r *rand.Rand, m auth.AccountKeeper, ctx sdk.Context,
+	accs []simulation.Account, coins sdk.Coins) (simulation.Account, bool) {
+
+	for _, i := range r.Perm(len(accs)) {
+		acc := m.GetAccount(ctx, accs[i].Address)
+		if acc != nil && acc.GetCoins().IsAllGTE(coins) {
+			return accs[i], true
+		}
+	}
+	return simulation.Account{}, false
+}
@@ -6,9 +6,11 @@ import (
 
 // Governance tags
 var (
-	ActionProposalDropped  = "proposal-dropped"
-	ActionProposalPassed   = "proposal-passed"
-	ActionProposalRejected = "proposal-rejected"
+	ActionProposalDropped   = "proposal-dropped"
+	ActionProposalPassed    = "proposal-passed"
+	ActionProposalRejected  = "proposal-rejected"
+	ActionProposalFailed    = "proposal-failed"
+	ActionMilestoneRejected = "milestone-rejected"
 
 	Action            = sdk.TagAction
 	Proposer          = "proposer"
@@ -17,4 +19,11 @@ var (
 	Depositor         = "depositor"
 	Voter             = "voter"
 	ProposalResult    = "proposal-result"
+	ParamChanged      = "param-changed"
+	UpgradeScheduled  = "upgrade-scheduled"
+	MilestoneIndex    = "milestone-index"
+	Delegator         = "delegator"
+	Proxy             = "proxy"
+	DepositBurned     = "deposit-burned"
+	DepositToPool     = "deposit-to-community-pool"
 )
@@ -4,12 +4,6 @@ import (
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
-// councilMemberGovInfo used for tallying
-type councilMemberGovInfo struct {
-	Address sdk.AccAddress //councilMember Address
-	Power   sdk.Dec        //CouncilMember Power
-}
-
 // CalulcateCouncilPower : calculates total power of council members
 func (keeper Keeper) CalculateCouncilPower(ctx sdk.Context) (sdk.Dec, sdk.Error) {
 
@@ -18,7 +12,7 @@ func (keeper Keeper) CalculateCouncilPower(ctx sdk.Context) (sdk.Dec, sdk.Error)
 	total := sdk.NewDec(0)
 
 	for ; councilmemberIterator.Valid(); councilmemberIterator.Next() {
-		cm := &councilMemberGovInfo{}
+		cm := &CouncilMemberPower{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(councilmemberIterator.Value(), cm)
 		total = total.Add(cm.Power)
 	}
@@ -31,29 +25,46 @@ func (keeper Keeper) CalculateCouncilPower(ctx sdk.Context) (sdk.Dec, sdk.Error)
 func tally(ctx sdk.Context, keeper Keeper,
 	proposal Proposal) (passes bool, tallyResults TallyResult, neutral bool) {
 
-	results := make(map[VoteOption]sdk.Dec)
-	results[OptionYes] = sdk.ZeroDec()
-	results[OptionAbstain] = sdk.ZeroDec()
-	results[OptionNo] = sdk.ZeroDec()
-	totalVotingPower := sdk.ZeroDec()
-
-	// iterate over all the votes
 	votesIterator := keeper.GetVotes(ctx, proposal.ProposalID)
 	defer votesIterator.Close()
+	return tallyVotes(ctx, keeper, keeper.getTallyCouncil(ctx, proposal.ProposalID), votesIterator)
+}
+
+// collectVotes reads the options of an iterator over votes by voter address
+func collectVotes(keeper Keeper, votesIterator sdk.Iterator) map[string]VoteOption {
+	votes := make(map[string]VoteOption)
 	for ; votesIterator.Valid(); votesIterator.Next() {
 		vote := &Vote{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), vote)
+		votes[vote.Voter.String()] = vote.Option
+	}
+	return votes
+}
+
+// tallyVotes weighs the council votes of an iterator with the power of the
+// council snapshot against the tally params
+func tallyVotes(ctx sdk.Context, keeper Keeper, council CouncilSnapshot,
+	votesIterator sdk.Iterator) (passes bool, tallyResults TallyResult, neutral bool) {
+
+	results := make(map[VoteOption]sdk.Dec)
+	results[OptionYes] = sdk.ZeroDec()
+	results[OptionAbstain] = sdk.ZeroDec()
+	results[OptionNo] = sdk.ZeroDec()
+	totalVotingPower := sdk.ZeroDec()
 
-		if cmpower, found := keeper.stk.GetCouncilMemberShares(ctx, vote.Voter); found {
-			results[vote.Option] = results[vote.Option].Add(cmpower)
-			totalVotingPower = totalVotingPower.Add(cmpower)
+	// members who did not vote are counted with the vote of their proxy
+	for _, voterPower := range keeper.getCouncilVoterPowers(council, collectVotes(keeper, votesIterator)) {
+		if voterPower.Option == OptionEmpty {
+			continue
 		}
+		results[voterPower.Option] = results[voterPower.Option].Add(voterPower.Power)
+		totalVotingPower = totalVotingPower.Add(voterPower.Power)
 	}
 
 	tallyParams := keeper.GetTallyParams(ctx)
 	tallyResults = NewTallyResultFromMap(results)
-	totalCouncilPower, err := keeper.CalculateCouncilPower(ctx)
-	if err != nil {
+	totalCouncilPower := council.TotalPower()
+	if !totalCouncilPower.IsPositive() {
 		return false, tallyResults, true
 	}
 
@@ -104,7 +104,7 @@ func TestTallyAllYes(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -156,7 +156,7 @@ func TestTallyAllNo(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -207,7 +207,7 @@ func TestTally66No(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -259,7 +259,7 @@ func TestTally66Yes(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -311,7 +311,7 @@ func TestTallyNeutral(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -363,7 +363,7 @@ func TestTallyAllAbstain(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -415,7 +415,7 @@ func TestTallyQuorum(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -464,7 +464,7 @@ func TestTallyExactQuorumPass(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -515,7 +515,7 @@ func TestTallyExactQuorumFail(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -567,7 +567,7 @@ func TestTallyExactThreshold(t *testing.T) {
 
 	// update blockchain time (add four weeks time)
 	newHeader = ctx.BlockHeader()
-	newHeader.Time = ctx.BlockHeader().Time.Add(FourWeeksHours)
+	newHeader.Time = ctx.BlockHeader().Time.Add(DefaultCycleDuration)
 	newHeader.Height = 2
 	ctx = ctx.WithBlockHeader(newHeader)
 	EndBlocker(ctx, keeper)
@@ -18,6 +18,8 @@ import (
 	"github.com/ColorPlatform/color-sdk/x/mock"
 	"github.com/ColorPlatform/color-sdk/x/params"
 	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
 	abci "github.com/ColorPlatform/prism/abci/types"
 	"github.com/ColorPlatform/prism/crypto"
 	"github.com/ColorPlatform/prism/crypto/ed25519"
@@ -41,6 +43,8 @@ func getMockApp(t *testing.T, numGenAccs int, genState GenesisState, genAccs []a
 	keyGov := sdk.NewKVStoreKey(StoreKey)
 	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
 	keyMinting := sdk.NewKVStoreKey(mint.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyUpgrade := sdk.NewKVStoreKey(upgrade.StoreKey)
 
 	pk := mapp.ParamsKeeper
 	ck := bank.NewBaseKeeper(mapp.AccountKeeper, mapp.ParamsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
@@ -48,16 +52,18 @@ func getMockApp(t *testing.T, numGenAccs int, genState GenesisState, genAccs []a
 	feeKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, mapp.KeyFeeCollection)
 	distrKeeper := distr.NewKeeper(mapp.Cdc, keyDistr, pk.Subspace(distr.DefaultParamspace), ck, &sk, feeKeeper, distr.DefaultCodespace)
 
-	minKeeper := mint.NewKeeper(mapp.Cdc, keyMinting, pk.Subspace(mint.DefaultParamspace), &sk, feeKeeper)
-	keeper = NewKeeper(mapp.Cdc, distrKeeper, minKeeper, keyGov, pk, pk.Subspace("testgov"), ck, sk, sk, DefaultCodespace)
+	supplyKeeper := supply.NewKeeper(mapp.Cdc, keySupply, mapp.AccountKeeper, &sk, distrKeeper, feeKeeper)
+	minKeeper := mint.NewKeeper(mapp.Cdc, keyMinting, pk.Subspace(mint.DefaultParamspace), &sk, feeKeeper, supplyKeeper)
+	upgradeKeeper := upgrade.NewKeeper(mapp.Cdc, keyUpgrade, upgrade.DefaultCodespace)
+	keeper = NewKeeper(mapp.Cdc, distrKeeper, minKeeper, supplyKeeper, upgradeKeeper, keyGov, pk, pk.Subspace("testgov"), ck, sk, sk, DefaultCodespace)
 
 	mapp.Router().AddRoute(RouterKey, NewHandler(keeper))
 	mapp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(keeper))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
-	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk, genState))
+	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk, supplyKeeper, genState))
 
-	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keyGov))
+	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keyGov, keyDistr, keyMinting, keySupply, keyUpgrade))
 
 	valTokens := sdk.TokensFromTendermintPower(10000000000000)
 	if genAccs == nil || len(genAccs) == 0 {
@@ -81,7 +87,8 @@ func getEndBlocker(keeper Keeper) sdk.EndBlocker {
 }
 
 // gov and staking initchainer
-func getInitChainer(mapp *mock.App, keeper Keeper, stakingKeeper staking.Keeper, genState GenesisState) sdk.InitChainer {
+func getInitChainer(mapp *mock.App, keeper Keeper, stakingKeeper staking.Keeper, supplyKeeper supply.Keeper,
+	genState GenesisState) sdk.InitChainer {
 	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 		mapp.InitChainer(ctx, req)
 
@@ -98,6 +105,19 @@ func getInitChainer(mapp *mock.App, keeper Keeper, stakingKeeper staking.Keeper,
 		} else {
 			InitGenesis(ctx, keeper, genState)
 		}
+
+		// the funding cycles read the community tax and the minted provisions
+		distr.InitGenesis(ctx, keeper.distrKeeper, distr.DefaultGenesisState())
+		mint.InitGenesis(ctx, keeper.minKeeper, mint.DefaultGenesisState())
+
+		// the supply starts from the coins of the accounts
+		total := sdk.Coins{}
+		mapp.AccountKeeper.IterateAccounts(ctx, func(acc auth.Account) bool {
+			total = total.Add(acc.GetCoins())
+			return false
+		})
+		supplyKeeper.SetSupply(ctx, supply.NewSupply(total, sdk.Coins{}, sdk.Coins{}))
+
 		return abci.ResponseInitChain{
 			Validators: validators,
 		}
@@ -235,7 +255,6 @@ func CreateTestInputAdvanced1(t *testing.T, isCheckTx bool, initPower int64,
 	pubKeys []crypto.PubKey, privKeys []crypto.PrivKey) {
 	mapp = mock.NewApp()
 
-	bank.RegisterCodec(mapp.Cdc)
 	staking.RegisterCodec(mapp.Cdc)
 	types.RegisterCodec(mapp.Cdc)
 	RegisterCodec(mapp.Cdc)
@@ -244,61 +263,55 @@ func CreateTestInputAdvanced1(t *testing.T, isCheckTx bool, initPower int64,
 	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
 	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
 	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
-	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
-	keyFeeCollection := sdk.NewKVStoreKey(auth.FeeStoreKey)
-	keyParams := sdk.NewKVStoreKey(params.StoreKey)
-	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
 	keyGov := sdk.NewKVStoreKey(StoreKey)
 	keyMinting := sdk.NewKVStoreKey(mint.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyUpgrade := sdk.NewKVStoreKey(upgrade.StoreKey)
 
-	db := dbm.NewMemDB()
-	ms := store.NewCommitMultiStore(db)
-
-	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, nil)
-	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
-
-	err := ms.LoadLatestVersion()
-	require.Nil(t, err)
-
-	pk := params.NewKeeper(mapp.Cdc, keyParams, tkeyParams)
-	ctx = sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, isCheckTx, logm.NewNopLogger())
-	accountKeeper := auth.NewAccountKeeper(mapp.Cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
-	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
+	// the keepers share the stores of the mock app, so that its genesis and blocks reach them
+	pk := mapp.ParamsKeeper
+	bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
 	sk = staking.NewKeeper(mapp.Cdc, keyStaking, tkeyStaking, bankKeeper, pk.Subspace(staking.DefaultParamspace), staking.DefaultCodespace)
-	feeKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, keyFeeCollection)
-	distrKeeper := distr.NewKeeper(mapp.Cdc, keyDistr, pk.Subspace(DefaultParamspace), bankKeeper, &sk, feeKeeper, distr.DefaultCodespace)
-	minKeeper := mint.NewKeeper(mapp.Cdc, keyMinting, pk.Subspace(mint.DefaultParamspace), &sk, feeKeeper)
-
-	keeper = NewKeeper(mapp.Cdc, distrKeeper, minKeeper, keyGov, pk, pk.Subspace("testgov"), bankKeeper, sk, sk, DefaultCodespace)
+	feeKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, mapp.KeyFeeCollection)
+	distrKeeper := distr.NewKeeper(mapp.Cdc, keyDistr, pk.Subspace(distr.DefaultParamspace), bankKeeper, &sk, feeKeeper, distr.DefaultCodespace)
+	supplyKeeper := supply.NewKeeper(mapp.Cdc, keySupply, mapp.AccountKeeper, &sk, distrKeeper, feeKeeper)
+	minKeeper := mint.NewKeeper(mapp.Cdc, keyMinting, pk.Subspace(mint.DefaultParamspace), &sk, feeKeeper, supplyKeeper)
 
-	sk.SetPool(ctx, staking.InitialPool())
-	sk.SetParams(ctx, staking.DefaultParams())
+	upgradeKeeper := upgrade.NewKeeper(mapp.Cdc, keyUpgrade, upgrade.DefaultCodespace)
+	keeper = NewKeeper(mapp.Cdc, distrKeeper, minKeeper, supplyKeeper, upgradeKeeper, keyGov, pk, pk.Subspace("testgov"), bankKeeper, sk, sk, DefaultCodespace)
 
+	// set the distribution hooks on staking
 	sk.SetHooks(distrKeeper.Hooks())
 
-	// set genesis items required for distribution
-	distrKeeper.SetFeePool(ctx, types.InitialFeePool())
-	distrKeeper.SetCommunityTax(ctx, communityTax)
-	distrKeeper.SetBaseProposerReward(ctx, sdk.NewDecWithPrec(1, 2))
-	distrKeeper.SetBonusProposerReward(ctx, sdk.NewDecWithPrec(4, 2))
-	fmt.Println("community tax", distrKeeper.GetCommunityTax(ctx))
-
 	mapp.Router().AddRoute(RouterKey, NewHandler(keeper))
 	mapp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(keeper))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
-	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk, genState))
-
-	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keyGov))
-
-	// fill all the addresses with some coins, set the loose pool tokens simultaneously
+	initChainer := getInitChainer(mapp, keeper, sk, supplyKeeper, genState)
+	mapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		res := initChainer(ctx, req)
+
+		// set genesis items required for distribution
+		distrKeeper.SetCommunityTax(ctx, communityTax)
+		distrKeeper.SetBaseProposerReward(ctx, sdk.NewDecWithPrec(1, 2))
+		distrKeeper.SetBonusProposerReward(ctx, sdk.NewDecWithPrec(4, 2))
+
+		// fill all the addresses with some coins, set the loose pool tokens simultaneously
+		for _, addr := range TestAddrs {
+			coins := sdk.Coins{sdk.NewCoin(sk.GetParams(ctx).BondDenom, initCoins)}
+			_, _, err := bankKeeper.AddCoins(ctx, addr, coins)
+			if err != nil {
+				panic(err)
+			}
+			pool := sk.GetPool(ctx)
+			pool.NotBondedTokens = pool.NotBondedTokens.Add(initCoins)
+			sk.SetPool(ctx, pool)
+			supplyKeeper.Inflate(ctx, coins)
+		}
+		return res
+	})
 
-	// set the distribution hooks on staking
+	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keyGov, keyDistr, keyMinting, keySupply, keyUpgrade))
 
 	valTokens := sdk.TokensFromTendermintPower(10000000000000)
 	if genAccs == nil || len(genAccs) == 0 {
@@ -307,15 +320,8 @@ func CreateTestInputAdvanced1(t *testing.T, isCheckTx bool, initPower int64,
 	}
 	mock.SetGenesis(mapp, genAccs)
 
-	for _, addr := range TestAddrs {
-		pool := sk.GetPool(ctx)
-		_, _, err := bankKeeper.AddCoins(ctx, addr, sdk.Coins{
-			sdk.NewCoin(sk.GetParams(ctx).BondDenom, initCoins),
-		})
-		require.Nil(t, err)
-		pool.NotBondedTokens = pool.NotBondedTokens.Add(initCoins)
-		sk.SetPool(ctx, pool)
-	}
+	// the genesis is committed, the context reads the check state until the next block
+	ctx = mapp.BaseApp.NewContext(true, abci.Header{ChainID: "foochainid"}).WithIsCheckTx(isCheckTx)
 
 	return mapp, ctx, keeper, sk, addrs, pubKeys, privKeys
 }
@@ -402,6 +408,8 @@ func getMockApp1(t *testing.T, isCheckTx bool, initPower int64,
 	keyGov := sdk.NewKVStoreKey(StoreKey)
 	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
 	keyMinting := sdk.NewKVStoreKey(mint.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyUpgrade := sdk.NewKVStoreKey(upgrade.StoreKey)
 	keyParams := sdk.NewKVStoreKey(params.StoreKey)
 	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
 	db := dbm.NewMemDB()
@@ -409,6 +417,7 @@ func getMockApp1(t *testing.T, isCheckTx bool, initPower int64,
 	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, nil)
 	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
@@ -421,8 +430,10 @@ func getMockApp1(t *testing.T, isCheckTx bool, initPower int64,
 	feeKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, mapp.KeyFeeCollection)
 	distrKeeper := distr.NewKeeper(mapp.Cdc, keyDistr, pk.Subspace(distr.DefaultParamspace), ck, &sk, feeKeeper, distr.DefaultCodespace)
 
-	minKeeper := mint.NewKeeper(mapp.Cdc, keyMinting, pk.Subspace(mint.DefaultParamspace), &sk, feeKeeper)
-	keeper = NewKeeper(mapp.Cdc, distrKeeper, minKeeper, keyGov, pk, pk.Subspace("testgov"), ck, sk, sk, DefaultCodespace)
+	supplyKeeper := supply.NewKeeper(mapp.Cdc, keySupply, mapp.AccountKeeper, &sk, distrKeeper, feeKeeper)
+	minKeeper := mint.NewKeeper(mapp.Cdc, keyMinting, pk.Subspace(mint.DefaultParamspace), &sk, feeKeeper, supplyKeeper)
+	upgradeKeeper := upgrade.NewKeeper(mapp.Cdc, keyUpgrade, upgrade.DefaultCodespace)
+	keeper = NewKeeper(mapp.Cdc, distrKeeper, minKeeper, supplyKeeper, upgradeKeeper, keyGov, pk, pk.Subspace("testgov"), ck, sk, sk, DefaultCodespace)
 
 	pk = params.NewKeeper(mapp.Cdc, keyParams, tkeyParams)
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, isCheckTx, logm.NewNopLogger())
@@ -440,9 +451,9 @@ func getMockApp1(t *testing.T, isCheckTx bool, initPower int64,
 	mapp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(keeper))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
-	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk, genState))
+	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk, supplyKeeper, genState))
 
-	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keyGov))
+	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keyGov, keyDistr, keyMinting, keySupply, keyUpgrade))
 
 	valTokens := sdk.TokensFromTendermintPower(10000000000000)
 	if genAccs == nil || len(genAccs) == 0 {
@@ -24,7 +24,7 @@ func getMockApp(t *testing.T) *mock.App {
 	bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper,
 		mapp.ParamsKeeper.Subspace(bank.DefaultParamspace),
 		bank.DefaultCodespace)
-	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, bankKeeper))
+	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, bankKeeper, &testSupplyKeeper{}))
 
 	require.NoError(t, mapp.CompleteSetup(keyIBC))
 	return mapp
@@ -55,21 +55,25 @@ func TestIBCMsgs(t *testing.T) {
 	require.Equal(t, acc, res1)
 
 	packet := IBCPacket{
-		SrcAddr:   addr1,
-		DestAddr:  addr1,
-		Coins:     coins,
-		SrcChain:  sourceChain,
-		DestChain: destChain,
+		SrcAddr:       addr1,
+		DestAddr:      addr1,
+		Coins:         coins,
+		SrcChain:      sourceChain,
+		DestChain:     destChain,
+		TimeoutHeight: 100,
 	}
 
 	transferMsg := MsgIBCTransfer{
 		IBCPacket: packet,
 	}
 
+	// the mock app has no light client of the source chain to verify the proof
 	receiveMsg := MsgIBCReceive{
-		IBCPacket: packet,
-		Relayer:   addr1,
-		Sequence:  0,
+		IBCPacket:   packet,
+		Relayer:     addr1,
+		Sequence:    0,
+		Proof:       constructProof(),
+		ProofHeight: 1,
 	}
 
 	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
@@ -80,9 +84,11 @@ func TestIBCMsgs(t *testing.T) {
 	mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, []sdk.Msg{transferMsg}, []uint64{0}, []uint64{1}, false, false, priv1)
 
 	header = abci.Header{Height: mapp.LastBlockHeight() + 1}
-	mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, []sdk.Msg{receiveMsg}, []uint64{0}, []uint64{2}, true, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, coins)
+	mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, []sdk.Msg{receiveMsg}, []uint64{0}, []uint64{2}, false, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 
+	receiveMsg.Proof = nil
 	header = abci.Header{Height: mapp.LastBlockHeight() + 1}
-	mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, []sdk.Msg{receiveMsg}, []uint64{0}, []uint64{2}, false, false, priv1)
+	mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, []sdk.Msg{receiveMsg}, []uint64{0}, []uint64{3}, false, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 }
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"encoding/hex"
+	"time"
 
 	"github.com/ColorPlatform/color-sdk/client"
 	"github.com/ColorPlatform/color-sdk/client/context"
@@ -16,9 +17,11 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo            = "to"
+	flagAmount        = "amount"
+	flagChain         = "chain"
+	flagTimeoutHeight = "timeout-height"
+	flagTimeout       = "timeout"
 )
 
 // IBCTransferCmd implements the IBC transfer command.
@@ -44,6 +47,8 @@ func IBCTransferCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().Int64(flagTimeoutHeight, 0, "Height of the destination chain from which the coins are refunded, 0 for none")
+	cmd.Flags().Duration(flagTimeout, ibc.DefaultPacketTimeout, "Time after which the coins are refunded, 0 for none")
 
 	return cmd
 }
@@ -62,8 +67,13 @@ func buildMsg(from sdk.AccAddress) (sdk.Msg, error) {
 	}
 	to := sdk.AccAddress(bz)
 
+	var timeoutTime time.Time
+	if timeout := viper.GetDuration(flagTimeout); timeout > 0 {
+		timeoutTime = time.Now().UTC().Add(timeout)
+	}
+
 	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
+		viper.GetString(flagChain), viper.GetInt64(flagTimeoutHeight), timeoutTime)
 
 	msg := ibc.MsgIBCTransfer{
 		IBCPacket: packet,
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ColorPlatform/color-sdk/client/context"
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/x/ibc"
+)
+
+// GetCmdQueryDenomTrace implements the query denom trace command.
+func GetCmdQueryDenomTrace(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "denom-trace [denom]",
+		Short: "Query the origin of a voucher denomination",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(`
+Query the chains the vouchers of a denomination came through and their denomination on the origin chain:
+
+$ colorcli query ibc denom-trace ibc0a1b2c3d4e5f6
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := ibc.NewQueryDenomTraceParams(args[0])
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, ibc.QueryDenomTrace), bz)
+			if err != nil {
+				return err
+			}
+
+			var trace ibc.DenomTrace
+			cdc.MustUnmarshalJSON(res, &trace)
+			return cliCtx.PrintOutput(trace)
+		},
+	}
+}
@@ -1,23 +1,23 @@
 package cli
 
 import (
+	"fmt"
+	"net/http"
 	"os"
-	"time"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
-	"github.com/ColorPlatform/color-sdk/client/utils"
-
-	bam "github.com/ColorPlatform/color-sdk/baseapp"
 	"github.com/ColorPlatform/color-sdk/client/context"
 	"github.com/ColorPlatform/color-sdk/client/keys"
 	"github.com/ColorPlatform/color-sdk/codec"
-	sdk "github.com/ColorPlatform/color-sdk/types"
-	"github.com/ColorPlatform/color-sdk/x/auth"
-	authtxb "github.com/ColorPlatform/color-sdk/x/auth/client/txbuilder"
-	"github.com/ColorPlatform/color-sdk/x/ibc"
+	"github.com/ColorPlatform/color-sdk/x/ibc/relayer"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/ColorPlatform/prism/libs/cli"
 	"github.com/ColorPlatform/prism/libs/log"
 )
 
@@ -27,184 +27,124 @@ const (
 	FlagFromChainNode = "from-chain-node"
 	FlagToChainID     = "to-chain-id"
 	FlagToChainNode   = "to-chain-node"
+	FlagConfig        = "config"
+	FlagStateFile     = "state-file"
+	FlagMetricsAddr   = "metrics-addr"
+	FlagLogFormat     = "log-format"
 )
 
-type relayCommander struct {
-	cdc       *codec.Codec
-	address   sdk.AccAddress
-	decoder   auth.AccountDecoder
-	mainStore string
-	ibcStore  string
-	accStore  string
-
-	logger log.Logger
-}
+const ibcStore = "ibc"
 
 // IBCRelayCmd implements the IBC relay command.
 func IBCRelayCmd(cdc *codec.Codec) *cobra.Command {
-	cmdr := relayCommander{
-		cdc:       cdc,
-		decoder:   context.GetAccountDecoder(cdc),
-		ibcStore:  "ibc",
-		mainStore: bam.MainStoreKey,
-		accStore:  auth.StoreKey,
-
-		logger: log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
-	}
-
 	cmd := &cobra.Command{
-		Use: "relay",
-		Run: cmdr.runIBCRelay,
-	}
-
-	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
-	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
-	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
-	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for this chain")
-
-	cmd.MarkFlagRequired(FlagFromChainID)
-	cmd.MarkFlagRequired(FlagFromChainNode)
-	cmd.MarkFlagRequired(FlagToChainID)
-	cmd.MarkFlagRequired(FlagToChainNode)
-
-	viper.BindPFlag(FlagFromChainID, cmd.Flags().Lookup(FlagFromChainID))
-	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
-	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
-	viper.BindPFlag(FlagToChainNode, cmd.Flags().Lookup(FlagToChainNode))
-
-	return cmd
-}
-
-// nolint: unparam
-func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
-	fromChainID := viper.GetString(FlagFromChainID)
-	fromChainNode := viper.GetString(FlagFromChainNode)
-	toChainID := viper.GetString(FlagToChainID)
-	toChainNode := viper.GetString(FlagToChainNode)
-
-	address := context.NewCLIContext().GetFromAddress()
-	c.address = address
-
-	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
-}
-
-// This is nolinted as someone is in the process of refactoring this to remove the goto
-func (c relayCommander) loop(fromChainID, fromChainNode, toChainID, toChainNode string) {
-	cliCtx := context.NewCLIContext()
-
-	name := cliCtx.GetFromName()
-	passphrase, err := keys.ReadPassphraseFromStdin(name)
-	if err != nil {
-		panic(err)
-	}
-
-	ingressKey := ibc.IngressSequenceKey(fromChainID)
-	lengthKey := ibc.EgressLengthKey(toChainID)
-
-OUTER:
-	for {
-		time.Sleep(5 * time.Second)
-
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
-		if err != nil {
-			panic(err)
-		}
-
-		var processed uint64
-		if processedbz == nil {
-			processed = 0
-		} else if err = c.cdc.UnmarshalBinaryLengthPrefixed(processedbz, &processed); err != nil {
-			panic(err)
-		}
-
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
-		if err != nil {
-			c.logger.Error("error querying outgoing packet list length", "err", err)
-			continue OUTER // TODO replace with continue (I think it should just to the correct place where OUTER is now)
-		}
-
-		var egressLength uint64
-		if egressLengthbz == nil {
-			egressLength = 0
-		} else if err = c.cdc.UnmarshalBinaryLengthPrefixed(egressLengthbz, &egressLength); err != nil {
-			panic(err)
-		}
-
-		if egressLength > processed {
-			c.logger.Info("Detected IBC packet", "number", egressLength-1)
-		}
-
-		seq := c.getSequence(toChainNode)
-
-		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
+		Use:   "relay",
+		Short: "Relay IBC packets, acknowledgements and timeouts between chains",
+		Long: `Relay IBC packets and acknowledgements between the chain pairs of a configuration
+file, or between the two chains given by flags. The packets which expired before
+they were relayed are timed out on their source chain instead. The relayed
+sequences are saved in the state file so that a restarted relayer resumes where
+it stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := relayConfig()
 			if err != nil {
-				c.logger.Error("error querying egress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
+				return err
 			}
 
-			err = c.broadcastTx(toChainNode, c.refine(egressbz, i, seq, passphrase))
-
-			seq++
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+			if viper.GetString(FlagLogFormat) == "json" {
+				logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+			}
+			logger = logger.With("module", "ibc-relayer")
+
+			metrics := relayer.NopMetrics()
+			if addr := viper.GetString(FlagMetricsAddr); addr != "" {
+				metrics = relayer.PrometheusMetrics("colorcli")
+				go func() {
+					if err := http.ListenAndServe(addr, promhttp.Handler()); err != nil {
+						logger.Error("Metrics server stopped", "err", err)
+					}
+				}()
+			}
 
+			cliCtx := context.NewCLIContext()
+			passphrase, err := keys.ReadPassphraseFromStdin(cliCtx.GetFromName())
 			if err != nil {
-				c.logger.Error("error broadcasting ingress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
+				return err
 			}
 
-			c.logger.Info("Relayed IBC packet", "number", i)
-		}
-	}
-}
+			chains := make([]relayer.Chain, len(config.Chains))
+			for i, chain := range config.Chains {
+				chains[i] = relayer.NewRPCChain(cdc, chain.ChainID, chain.Node, ibcStore,
+					cliCtx.GetFromName(), passphrase, cliCtx.GetFromAddress())
+			}
 
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCLIContext().WithNodeURI(node).QueryStore(key, storeName)
-}
+			state, err := relayer.LoadState(config.StateFile)
+			if err != nil {
+				return err
+			}
 
-// nolint: unparam
-func (c relayCommander) broadcastTx(node string, tx []byte) error {
-	_, err := context.NewCLIContext().WithNodeURI(node).BroadcastTx(tx)
-	return err
-}
+			r, err := relayer.NewRelayer(cdc, config, chains, state, logger, metrics)
+			if err != nil {
+				return err
+			}
 
-func (c relayCommander) getSequence(node string) uint64 {
-	res, err := query(node, auth.AddressStoreKey(c.address), c.accStore)
-	if err != nil {
-		panic(err)
+			quit := make(chan struct{})
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigs
+				close(quit)
+			}()
+
+			r.Run(quit)
+			return nil
+		},
 	}
 
-	if nil != res {
-		account, err := c.decoder(res)
-		if err != nil {
-			panic(err)
-		}
+	cmd.Flags().String(FlagConfig, "", "Relayer configuration file with the chains and the paths to relay, replaces the chain flags")
+	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
+	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
+	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
+	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for this chain")
+	cmd.Flags().String(FlagStateFile, "", "File saving the relayed sequences, defaults to relayer/state.json in the home directory")
+	cmd.Flags().String(FlagMetricsAddr, "", "Address to serve the Prometheus metrics on, none if empty")
+	cmd.Flags().String(FlagLogFormat, "plain", "Log format (plain|json)")
 
-		return account.GetSequence()
-	}
+	viper.BindPFlag(FlagConfig, cmd.Flags().Lookup(FlagConfig))
+	viper.BindPFlag(FlagFromChainID, cmd.Flags().Lookup(FlagFromChainID))
+	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
+	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
+	viper.BindPFlag(FlagToChainNode, cmd.Flags().Lookup(FlagToChainNode))
+	viper.BindPFlag(FlagStateFile, cmd.Flags().Lookup(FlagStateFile))
+	viper.BindPFlag(FlagMetricsAddr, cmd.Flags().Lookup(FlagMetricsAddr))
+	viper.BindPFlag(FlagLogFormat, cmd.Flags().Lookup(FlagLogFormat))
 
-	return 0
+	return cmd
 }
 
-func (c relayCommander) refine(bz []byte, ibcSeq, accSeq uint64, passphrase string) []byte {
-	var packet ibc.IBCPacket
-	if err := c.cdc.UnmarshalBinaryLengthPrefixed(bz, &packet); err != nil {
-		panic(err)
+// relayConfig reads the configuration file, or builds a configuration with a
+// single path from the chain flags
+func relayConfig() (relayer.Config, error) {
+	if file := viper.GetString(FlagConfig); file != "" {
+		return relayer.LoadConfig(file)
 	}
 
-	msg := ibc.MsgIBCReceive{
-		IBCPacket: packet,
-		Relayer:   c.address,
-		Sequence:  ibcSeq,
+	fromChainID := viper.GetString(FlagFromChainID)
+	toChainID := viper.GetString(FlagToChainID)
+	if fromChainID == "" || toChainID == "" {
+		return relayer.Config{}, fmt.Errorf("--%s or both --%s and --%s are required", FlagConfig, FlagFromChainID, FlagToChainID)
 	}
 
-	txBldr := authtxb.NewTxBuilderFromCLI().WithSequence(accSeq).WithTxEncoder(utils.GetTxEncoder(c.cdc))
-	cliCtx := context.NewCLIContext()
-
-	name := cliCtx.GetFromName()
-	res, err := txBldr.BuildAndSign(name, passphrase, []sdk.Msg{msg})
-	if err != nil {
-		panic(err)
+	config := relayer.DefaultConfig()
+	config.StateFile = viper.GetString(FlagStateFile)
+	if config.StateFile == "" {
+		config.StateFile = filepath.Join(viper.GetString(cli.HomeFlag), "relayer", "state.json")
 	}
-
-	return res
+	config.Chains = []relayer.ChainConfig{
+		{ChainID: fromChainID, Node: viper.GetString(FlagFromChainNode)},
+		{ChainID: toChainID, Node: viper.GetString(FlagToChainNode)},
+	}
+	config.Paths = []relayer.Path{{ChainA: fromChainID, ChainB: toChainID}}
+	return config, config.ValidateBasic()
 }
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ColorPlatform/color-sdk/client"
+	"github.com/ColorPlatform/color-sdk/client/context"
+	"github.com/ColorPlatform/color-sdk/client/utils"
+	"github.com/ColorPlatform/color-sdk/codec"
+	authtxb "github.com/ColorPlatform/color-sdk/x/auth/client/txbuilder"
+	"github.com/ColorPlatform/color-sdk/x/ibc/relayer"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const flagDestNode = "dest-node"
+
+// IBCTimeoutCmd implements the IBC timeout command.
+func IBCTimeoutCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeout [dest-chain-id] [sequence]",
+		Short: "Refund an IBC packet the destination chain did not receive before its timeout",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(`
+Refund a packet sent to another chain that expired before it was received. The
+absence of the packet on the destination chain is proven against its latest
+header, which also updates the light client of the destination chain:
+
+$ colorcli tx ibc timeout chain-b 3 --dest-node tcp://localhost:36657 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			sequence, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			// the transaction of the sender posts the messages, the chains are only queried
+			from := cliCtx.GetFromAddress()
+			src := relayer.NewRPCChain(cdc, viper.GetString(client.FlagChainID), cliCtx.NodeURI, ibcStore, "", "", from)
+			dest := relayer.NewRPCChain(cdc, args[0], viper.GetString(flagDestNode), ibcStore, "", "", from)
+
+			msgs, err := relayer.TimeoutMsgs(cdc, src, dest, sequence)
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, msgs, false)
+		},
+	}
+
+	cmd.Flags().String(flagDestNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for the destination chain")
+	viper.BindPFlag(flagDestNode, cmd.Flags().Lookup(flagDestNode))
+
+	return cmd
+}
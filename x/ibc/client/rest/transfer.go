@@ -2,6 +2,7 @@ package rest
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/ColorPlatform/color-sdk/client/context"
 	clientrest "github.com/ColorPlatform/color-sdk/client/rest"
@@ -17,11 +18,14 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
 	r.HandleFunc("/ibc/{destchain}/{address}/send", TransferRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+	r.HandleFunc("/ibc/denom_traces/{denom}", denomTraceHandlerFn(cdc, cliCtx)).Methods("GET")
 }
 
 type transferReq struct {
-	BaseReq rest.BaseReq `json:"base_req"`
-	Amount  sdk.Coins    `json:"amount"`
+	BaseReq       rest.BaseReq `json:"base_req"`
+	Amount        sdk.Coins    `json:"amount"`
+	TimeoutHeight int64        `json:"timeout_height"`
+	TimeoutTime   time.Time    `json:"timeout_time"`
 }
 
 // TransferRequestHandler - http request handler to transfer coins to a address
@@ -54,7 +58,13 @@ func TransferRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.
 			return
 		}
 
-		packet := ibc.NewIBCPacket(from, to, req.Amount, req.BaseReq.ChainID, destChainID)
+		// packets sent without a timeout are refunded after the default one
+		if req.TimeoutHeight == 0 && req.TimeoutTime.IsZero() {
+			req.TimeoutTime = time.Now().UTC().Add(ibc.DefaultPacketTimeout)
+		}
+
+		packet := ibc.NewIBCPacket(from, to, req.Amount, req.BaseReq.ChainID, destChainID,
+			req.TimeoutHeight, req.TimeoutTime)
 		msg := ibc.MsgIBCTransfer{IBCPacket: packet}
 
 		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
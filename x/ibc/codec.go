@@ -8,4 +8,7 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgIBCTransfer{}, "cosmos-sdk/MsgIBCTransfer", nil)
 	cdc.RegisterConcrete(MsgIBCReceive{}, "cosmos-sdk/MsgIBCReceive", nil)
+	cdc.RegisterConcrete(MsgIBCAck{}, "cosmos-sdk/MsgIBCAck", nil)
+	cdc.RegisterConcrete(MsgIBCTimeout{}, "cosmos-sdk/MsgIBCTimeout", nil)
+	cdc.RegisterConcrete(MsgUpdateClient{}, "cosmos-sdk/MsgUpdateClient", nil)
 }
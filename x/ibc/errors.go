@@ -11,6 +11,16 @@ const (
 	// IBC errors reserve 200 - 299.
 	CodeInvalidSequence sdk.CodeType = 200
 	CodeIdenticalChains sdk.CodeType = 201
+	CodeInvalidTimeout  sdk.CodeType = 202
+	CodePacketTimeout   sdk.CodeType = 203
+	CodeNotTimedOut     sdk.CodeType = 204
+	CodeNoCommitment    sdk.CodeType = 205
+	CodeInvalidClient   sdk.CodeType = 206
+	CodeClientNotFound  sdk.CodeType = 207
+	CodeClientExists    sdk.CodeType = 208
+	CodeInvalidProof    sdk.CodeType = 209
+	CodeWrongChain      sdk.CodeType = 210
+	CodeInvalidDenom    sdk.CodeType = 211
 	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
 )
 
@@ -20,6 +30,26 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeInvalidTimeout:
+		return "IBC packet must have a timeout height or a timeout time"
+	case CodePacketTimeout:
+		return "IBC packet timed out"
+	case CodeNotTimedOut:
+		return "IBC packet has not timed out"
+	case CodeNoCommitment:
+		return "IBC packet does not match any pending packet commitment"
+	case CodeInvalidClient:
+		return "invalid IBC light client"
+	case CodeClientNotFound:
+		return "IBC light client not found"
+	case CodeClientExists:
+		return "IBC light client already exists"
+	case CodeInvalidProof:
+		return "invalid IBC proof"
+	case CodeWrongChain:
+		return "IBC packet is not sent to this chain"
+	case CodeInvalidDenom:
+		return "invalid IBC denom trace"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +62,36 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrInvalidTimeout(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, msg)
+}
+func ErrPacketTimeout(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodePacketTimeout, "")
+}
+func ErrNotTimedOut(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeNotTimedOut, msg)
+}
+func ErrNoCommitment(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNoCommitment, "")
+}
+func ErrInvalidClient(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidClient, msg)
+}
+func ErrClientNotFound(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeClientNotFound, msg)
+}
+func ErrClientExists(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeClientExists, "")
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrWrongChain(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeWrongChain, "")
+}
+func ErrInvalidDenomTrace(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidDenom, msg)
+}
 
 // -------------------------
 // Helpers
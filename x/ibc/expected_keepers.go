@@ -7,3 +7,12 @@ type BankKeeper interface {
 	AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
 	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
 }
+
+// expected supply keeper
+type SupplyKeeper interface {
+	Inflate(ctx sdk.Context, amount sdk.Coins)
+	Lock(ctx sdk.Context, amount sdk.Coins)
+	Unlock(ctx sdk.Context, amount sdk.Coins)
+	Burn(ctx sdk.Context, amount sdk.Coins)
+	Unburn(ctx sdk.Context, amount sdk.Coins)
+}
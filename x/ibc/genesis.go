@@ -0,0 +1,55 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// GenesisState - ibc genesis state
+type GenesisState struct {
+	Clients []ConsensusState `json:"clients"` // trusted consensus states of the counterparty chains
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(clients []ConsensusState) GenesisState {
+	return GenesisState{
+		Clients: clients,
+	}
+}
+
+// DefaultGenesisState creates a default GenesisState object
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(nil)
+}
+
+// new ibc genesis. The light clients of the counterparty chains are created
+// from their trusted consensus states.
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) {
+	for _, cs := range data.Clients {
+		if err := ibcm.createClient(ctx, cs); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and mapper. Each
+// light client is exported with its latest consensus state.
+func ExportGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	return NewGenesisState(ibcm.GetLatestConsensusStates(ctx))
+}
+
+// ValidateGenesis checks the consensus states of the light clients
+func ValidateGenesis(data GenesisState) error {
+	chainIDs := make(map[string]bool)
+	for _, cs := range data.Clients {
+		if err := cs.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid ibc genesis: %s", err.Error())
+		}
+		if chainIDs[cs.ChainID] {
+			return fmt.Errorf("invalid ibc genesis: duplicate light client of chain %s", cs.ChainID)
+		}
+		chainIDs[cs.ChainID] = true
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis(t *testing.T) {
+	chainA := setupTestInput("chain-a")
+	chainB := setupTestInput("chain-b")
+	chainC := setupTestInput("chain-c")
+
+	headerB := chainB.commit(t)
+	headerC := chainC.commit(t)
+	genState := NewGenesisState([]ConsensusState{chainB.consensusState(headerB), chainC.consensusState(headerC)})
+
+	require.NoError(t, ValidateGenesis(DefaultGenesisState()))
+	require.NoError(t, ValidateGenesis(genState))
+	require.Error(t, ValidateGenesis(NewGenesisState(append(genState.Clients, chainB.consensusState(headerB)))))
+	invalid := chainB.consensusState(headerB)
+	invalid.AppHash = nil
+	require.Error(t, ValidateGenesis(NewGenesisState([]ConsensusState{invalid})))
+
+	// the genesis creates the light clients, which the relayers update
+	InitGenesis(chainA.ctx, chainA.ibcm, genState)
+	requireGenesis(t, genState, ExportGenesis(chainA.ctx, chainA.ibcm))
+
+	headerB = chainB.commit(t)
+	require.Nil(t, chainA.ibcm.UpdateClient(chainA.ctx, headerB, chainB.validators(), chainB.validators()))
+	exported := ExportGenesis(chainA.ctx, chainA.ibcm)
+	requireGenesis(t, NewGenesisState([]ConsensusState{chainB.consensusState(headerB), chainC.consensusState(headerC)}), exported)
+	require.NoError(t, ValidateGenesis(exported))
+
+	// a chain restarting from the exported genesis trusts the latest headers
+	restarted := setupTestInput("chain-a")
+	InitGenesis(restarted.ctx, restarted.ibcm, exported)
+	requireGenesis(t, exported, ExportGenesis(restarted.ctx, restarted.ibcm))
+	require.Panics(t, func() { InitGenesis(restarted.ctx, restarted.ibcm, exported) })
+}
+
+// the genesis states are compared as they are serialized, the stored times are UTC
+func requireGenesis(t *testing.T, expected, actual GenesisState) {
+	require.Equal(t, string(msgCdc.MustMarshalJSON(expected)), string(msgCdc.MustMarshalJSON(actual)))
+}
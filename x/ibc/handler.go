@@ -1,16 +1,25 @@
 package ibc
 
 import (
+	"bytes"
+	"fmt"
+
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
-func NewHandler(ibcm Mapper, ck BankKeeper) sdk.Handler {
+func NewHandler(ibcm Mapper, ck BankKeeper, sk SupplyKeeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case MsgIBCTransfer:
-			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
+			return handleIBCTransferMsg(ctx, ibcm, ck, sk, msg)
 		case MsgIBCReceive:
-			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+			return handleIBCReceiveMsg(ctx, ibcm, ck, sk, msg)
+		case MsgIBCAck:
+			return handleIBCAckMsg(ctx, ibcm, ck, sk, msg)
+		case MsgIBCTimeout:
+			return handleIBCTimeoutMsg(ctx, ibcm, ck, sk, msg)
+		case MsgUpdateClient:
+			return handleUpdateClientMsg(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + msg.Type()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -18,9 +27,12 @@ func NewHandler(ibcm Mapper, ck BankKeeper) sdk.Handler {
 	}
 }
 
-// MsgIBCTransfer deducts coins from the account and creates an egress IBC packet.
-func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, msg MsgIBCTransfer) sdk.Result {
+// MsgIBCTransfer deducts coins from the account and creates an egress IBC packet carrying the traces of its vouchers.
+// Vouchers going back to the chain they come from are burned, other coins are escrowed for the destination
+// chain and locked in the supply.
+func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, sk SupplyKeeper, msg MsgIBCTransfer) sdk.Result {
 	packet := msg.IBCPacket
+	packet.DenomTraces = ibcm.denomTraces(ctx, packet.Coins)
 
 	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
 	if err != nil {
@@ -32,25 +44,187 @@ func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, msg MsgIB
 		return err.Result()
 	}
 
+	escrowed, returned := packet.splitCoins()
+	ibcm.setEscrow(ctx, packet.DestChain, ibcm.GetEscrow(ctx, packet.DestChain).Add(escrowed))
+	sk.Lock(ctx, escrowed)
+	sk.Burn(ctx, returned)
+
 	return sdk.Result{}
 }
 
-// MsgIBCReceive adds coins to the destination address and creates an ingress IBC packet.
-func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, msg MsgIBCReceive) sdk.Result {
+// MsgIBCReceive adds coins to the destination address, records them in the supply and creates an ingress IBC packet.
+// A packet received after its timeout is not credited, its failed acknowledgement refunds the sender.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, sk SupplyKeeper, msg MsgIBCReceive) sdk.Result {
 	packet := msg.IBCPacket
 
+	if packet.DestChain != ctx.ChainID() {
+		return ErrWrongChain(ibcm.codespace).Result()
+	}
+
 	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
 	if msg.Sequence != seq {
 		return ErrInvalidSequence(ibcm.codespace).Result()
 	}
 
-	// XXX Check that packet.Coins is valid and positive (nonzero)
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	// the packet must be committed by the source chain
+	_, err := ibcm.verifyProof(ctx, packet.SrcChain, msg.ProofHeight, msg.Proof,
+		PacketCommitmentKey(packet.DestChain, seq), CommitPacket(packet))
 	if err != nil {
 		return err.Result()
 	}
 
+	ack := receivePacket(ctx, ibcm, ck, sk, packet)
+	ibcm.SetAcknowledgement(ctx, packet.SrcChain, seq, ack)
 	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
 
+	return sdk.Result{Log: ack.Log}
+}
+
+// Coins coming back to this chain are released from the escrow of the source chain and unlocked, other
+// coins are credited as vouchers of the source chain and inflate the supply.
+func receivePacket(ctx sdk.Context, ibcm Mapper, ck BankKeeper, sk SupplyKeeper, packet IBCPacket) IBCAcknowledgement {
+	if packet.TimedOut(ctx.BlockHeight(), ctx.BlockHeader().Time) {
+		return NewIBCAcknowledgement(false, ErrPacketTimeout(ibcm.codespace).Error())
+	}
+
+	coins, released, traces, err := receivedCoins(ctx, ibcm, packet)
+	if err != nil {
+		return NewIBCAcknowledgement(false, err.Error())
+	}
+
+	_, _, err = ck.AddCoins(ctx, packet.DestAddr, coins)
+	if err != nil {
+		return NewIBCAcknowledgement(false, err.Error())
+	}
+	ibcm.setEscrow(ctx, packet.SrcChain, ibcm.GetEscrow(ctx, packet.SrcChain).Sub(released))
+	for _, trace := range traces {
+		ibcm.setDenomTrace(ctx, trace)
+	}
+	sk.Unlock(ctx, released)
+	sk.Inflate(ctx, coins.Sub(released))
+
+	return NewIBCAcknowledgement(true, "")
+}
+
+// receivedCoins returns the coins credited on this chain for a packet, the coins they release from the
+// escrow of the source chain and the traces of the vouchers they create, without changing the state
+func receivedCoins(ctx sdk.Context, ibcm Mapper, packet IBCPacket) (coins, released sdk.Coins, traces []DenomTrace, err sdk.Error) {
+	coins, released = sdk.Coins{}, sdk.Coins{}
+	for _, coin := range packet.Coins {
+		trace := packet.denomTrace(coin.Denom)
+
+		if chainID, denom := trace.unwrap(); trace.Path != "" && chainID == ctx.ChainID() {
+			released = released.Add(sdk.Coins{sdk.NewCoin(denom, coin.Amount)})
+			continue
+		}
+
+		trace = trace.wrap(packet.SrcChain)
+		voucherDenom := trace.VoucherDenom()
+		if stored, found := ibcm.GetDenomTrace(ctx, voucherDenom); found && stored != trace {
+			return nil, nil, nil, ErrInvalidDenomTrace(ibcm.codespace,
+				fmt.Sprintf("voucher denom %s is already used by %s/%s", voucherDenom, stored.Path, stored.BaseDenom))
+		}
+		coins = coins.Add(sdk.Coins{sdk.NewCoin(voucherDenom, coin.Amount)})
+		traces = append(traces, trace)
+	}
+
+	if !ibcm.GetEscrow(ctx, packet.SrcChain).IsAllGTE(released) {
+		return nil, nil, nil, ErrInvalidDenomTrace(ibcm.codespace,
+			fmt.Sprintf("%s releases more coins than escrowed for %s", released, packet.SrcChain))
+	}
+	return coins.Add(released), released, traces, nil
+}
+
+// MsgIBCAck clears the commitment to an egress IBC packet and refunds the sender when the packet failed.
+func handleIBCAckMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, sk SupplyKeeper, msg MsgIBCAck) sdk.Result {
+	packet := msg.IBCPacket
+
+	// the acknowledgement must be written by the destination chain
+	_, err := ibcm.verifyProof(ctx, packet.DestChain, msg.ProofHeight, msg.Proof,
+		AcknowledgementKey(packet.SrcChain, msg.Sequence), marshalBinaryPanic(ibcm.cdc, msg.Acknowledgement))
+	if err != nil {
+		return err.Result()
+	}
+
+	err = clearPacketCommitment(ctx, ibcm, packet, msg.Sequence)
+	if err != nil {
+		return err.Result()
+	}
+
+	if !msg.Acknowledgement.Success {
+		err = refundPacket(ctx, ibcm, ck, sk, packet)
+		if err != nil {
+			return err.Result()
+		}
+	}
+
+	return sdk.Result{}
+}
+
+// MsgIBCTimeout clears the commitment to an egress IBC packet the destination chain did not receive
+// before its timeout and refunds the sender.
+func handleIBCTimeoutMsg(ctx sdk.Context, ibcm Mapper, ck BankKeeper, sk SupplyKeeper, msg MsgIBCTimeout) sdk.Result {
+	packet := msg.IBCPacket
+
+	if msg.NextSequenceRecv > msg.Sequence {
+		return ErrNotTimedOut(ibcm.codespace, "IBC packet was received by the destination chain").Result()
+	}
+
+	// the ingress sequence of the destination chain is absent until it receives a packet
+	var nextSequenceRecv []byte
+	if msg.NextSequenceRecv > 0 {
+		nextSequenceRecv = marshalBinaryPanic(ibcm.cdc, msg.NextSequenceRecv)
+	}
+	cs, err := ibcm.verifyProof(ctx, packet.DestChain, msg.ProofHeight, msg.Proof,
+		IngressSequenceKey(packet.SrcChain), nextSequenceRecv)
+	if err != nil {
+		return err.Result()
+	}
+	if !packet.TimedOut(cs.Height, cs.Time) {
+		return ErrNotTimedOut(ibcm.codespace, "").Result()
+	}
+
+	err = clearPacketCommitment(ctx, ibcm, packet, msg.Sequence)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = refundPacket(ctx, ibcm, ck, sk, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// MsgUpdateClient updates the light client of a counterparty chain with a new header.
+func handleUpdateClientMsg(ctx sdk.Context, ibcm Mapper, msg MsgUpdateClient) sdk.Result {
+	err := ibcm.UpdateClient(ctx, msg.Header, msg.Validators, msg.NextValidators)
+	if err != nil {
+		return err.Result()
+	}
 	return sdk.Result{}
 }
+
+func clearPacketCommitment(ctx sdk.Context, ibcm Mapper, packet IBCPacket, sequence uint64) sdk.Error {
+	commitment := ibcm.GetPacketCommitment(ctx, packet.DestChain, sequence)
+	if commitment == nil || !bytes.Equal(commitment, CommitPacket(packet)) {
+		return ErrNoCommitment(ibcm.codespace)
+	}
+	ibcm.deletePacketCommitment(ctx, packet.DestChain, sequence)
+	return nil
+}
+
+// refunds the sender of an egress IBC packet, the coins it escrowed are unlocked and the vouchers
+// it burned are unburned. The coins locked for other chains are left untouched.
+func refundPacket(ctx sdk.Context, ibcm Mapper, ck BankKeeper, sk SupplyKeeper, packet IBCPacket) sdk.Error {
+	_, _, err := ck.AddCoins(ctx, packet.SrcAddr, packet.Coins)
+	if err != nil {
+		return err
+	}
+	escrowed, returned := packet.splitCoins()
+	ibcm.setEscrow(ctx, packet.DestChain, ibcm.GetEscrow(ctx, packet.DestChain).Sub(escrowed))
+	sk.Unlock(ctx, escrowed)
+	sk.Unburn(ctx, returned)
+	return nil
+}
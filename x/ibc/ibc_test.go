@@ -1,14 +1,18 @@
 package ibc
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/ColorPlatform/prism/abci/types"
 	"github.com/ColorPlatform/prism/crypto/ed25519"
+	"github.com/ColorPlatform/prism/crypto/merkle"
 	dbm "github.com/ColorPlatform/prism/libs/db"
 	"github.com/ColorPlatform/prism/libs/log"
+	tmtypes "github.com/ColorPlatform/prism/types"
 
 	"github.com/ColorPlatform/color-sdk/codec"
 	"github.com/ColorPlatform/color-sdk/store"
@@ -20,15 +24,21 @@ import (
 
 // AccountKeeper(/Keeper) and IBCMapper should use different StoreKey later
 
+// testInput is a chain with a single validator, whose state can be committed
+// and proven to the other chains
 type testInput struct {
 	cdc    *codec.Codec
 	ctx    sdk.Context
+	ms     sdk.CommitMultiStore
 	ak     auth.AccountKeeper
 	bk     bank.BaseKeeper
 	ibcKey *sdk.KVStoreKey
+	ibcm   Mapper
+	sk     *testSupplyKeeper
+	pv     *tmtypes.MockPV
 }
 
-func setupTestInput() testInput {
+func setupTestInput(chainID string) testInput {
 	db := dbm.NewMemDB()
 	cdc := makeCodec()
 
@@ -39,11 +49,11 @@ func setupTestInput() testInput {
 	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	ms := store.NewCommitMultiStore(db)
-	ms.MountStoreWithDB(ibcKey, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(authCapKey, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(fckCapKey, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(ibcKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(authCapKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(fckCapKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
 	ms.LoadLatestVersion()
 
 	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
@@ -51,11 +61,87 @@ func setupTestInput() testInput {
 		cdc, authCapKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount,
 	)
 	bk := bank.NewBaseKeeper(ak, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
-	ctx := sdk.NewContext(ms, abci.Header{ChainID: "test-chain-id"}, false, log.NewNopLogger())
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: chainID, Height: 1, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
 
 	ak.SetParams(ctx, auth.DefaultParams())
 
-	return testInput{cdc: cdc, ctx: ctx, ak: ak, bk: bk, ibcKey: ibcKey}
+	return testInput{cdc: cdc, ctx: ctx, ms: ms, ak: ak, bk: bk, ibcKey: ibcKey,
+		ibcm: NewMapper(cdc, ibcKey, DefaultCodespace), sk: &testSupplyKeeper{}, pv: tmtypes.NewMockPV()}
+}
+
+func (input testInput) handler() sdk.Handler {
+	return NewHandler(input.ibcm, input.bk, input.sk)
+}
+
+func (input testInput) validators() *tmtypes.ValidatorSet {
+	return tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(input.pv.GetPubKey(), 10, 0, 0)})
+}
+
+// commit commits the state of the chain, moves to the next block and returns
+// its header, which holds the app hash of the committed state
+func (input *testInput) commit(t *testing.T) tmtypes.SignedHeader {
+	cid := input.ms.Commit()
+	header := input.ctx.BlockHeader()
+	header.Height = cid.Version + 1
+	header.Time = header.Time.Add(5 * time.Second)
+	input.ctx = input.ctx.WithBlockHeader(header).WithBlockHeight(header.Height)
+
+	vals := input.validators()
+	return signHeader(t, input.ctx.ChainID(), header.Height, header.Time, cid.Hash, vals, vals, input.pv)
+}
+
+// trust creates the light client of a counterparty chain from the genesis of this chain
+func (input testInput) trust(cs ConsensusState) {
+	InitGenesis(input.ctx, input.ibcm, NewGenesisState([]ConsensusState{cs}))
+}
+
+// consensusState returns the consensus state of a header of this chain
+func (input testInput) consensusState(header tmtypes.SignedHeader) ConsensusState {
+	return NewConsensusState(header.ChainID, header.Height, header.Time, header.AppHash, input.validators())
+}
+
+// prove proves a key of the IBC store in the last committed state
+func (input testInput) prove(t *testing.T, key []byte) *merkle.Proof {
+	res := input.ms.(sdk.Queryable).Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", input.ibcKey.Name()),
+		Data:   key,
+		Height: input.ms.LastCommitID().Version,
+		Prove:  true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	return res.Proof
+}
+
+// signHeader returns a header signed by the given validators
+func signHeader(t *testing.T, chainID string, height int64, blockTime time.Time, appHash []byte,
+	vals, nextVals *tmtypes.ValidatorSet, pvs ...*tmtypes.MockPV) tmtypes.SignedHeader {
+
+	header := &tmtypes.Header{
+		ChainID:            chainID,
+		Height:             height,
+		Time:               blockTime,
+		AppHash:            appHash,
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: nextVals.Hash(),
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.CommitSig, vals.Size())
+	for _, pv := range pvs {
+		idx, _ := vals.GetByAddress(pv.GetPubKey().Address())
+		vote := &tmtypes.Vote{
+			Type:             tmtypes.PrecommitType,
+			Height:           height,
+			BlockID:          blockID,
+			Timestamp:        blockTime,
+			ValidatorAddress: pv.GetPubKey().Address(),
+			ValidatorIndex:   idx,
+		}
+		require.NoError(t, pv.SignVote(chainID, vote))
+		precommits[idx] = vote.CommitSig()
+	}
+
+	return tmtypes.SignedHeader{Header: header, Commit: tmtypes.NewCommit(blockID, precommits)}
 }
 
 func makeCodec() *codec.Codec {
@@ -66,6 +152,9 @@ func makeCodec() *codec.Codec {
 	cdc.RegisterConcrete(bank.MsgSend{}, "test/ibc/Send", nil)
 	cdc.RegisterConcrete(MsgIBCTransfer{}, "test/ibc/MsgIBCTransfer", nil)
 	cdc.RegisterConcrete(MsgIBCReceive{}, "test/ibc/MsgIBCReceive", nil)
+	cdc.RegisterConcrete(MsgIBCAck{}, "test/ibc/MsgIBCAck", nil)
+	cdc.RegisterConcrete(MsgIBCTimeout{}, "test/ibc/MsgIBCTimeout", nil)
+	cdc.RegisterConcrete(MsgUpdateClient{}, "test/ibc/MsgUpdateClient", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
@@ -87,74 +176,405 @@ func getCoins(ck bank.Keeper, ctx sdk.Context, addr sdk.AccAddress) (sdk.Coins,
 	return coins, err
 }
 
+// egressPacket returns a packet posted by this chain
+func (input testInput) egressPacket(t *testing.T, destChain string, sequence uint64) IBCPacket {
+	bz := input.ctx.KVStore(input.ibcKey).Get(EgressKey(destChain, sequence))
+	require.NotNil(t, bz)
+	var packet IBCPacket
+	unmarshalBinaryPanic(input.cdc, bz, &packet)
+	return packet
+}
+
+// relay commits the state of the source chain, updates its client on the
+// destination chain and receives a packet with the proof of its commitment
+func relay(t *testing.T, from, to *testInput, sequence uint64) sdk.Result {
+	header := from.commit(t)
+	if _, found := to.ibcm.GetLatestConsensusState(to.ctx, from.ctx.ChainID()); found {
+		require.Nil(t, to.ibcm.UpdateClient(to.ctx, header, from.validators(), from.validators()))
+	} else {
+		to.trust(from.consensusState(header))
+	}
+
+	packet := from.egressPacket(t, to.ctx.ChainID(), sequence)
+	proof := from.prove(t, PacketCommitmentKey(to.ctx.ChainID(), sequence))
+	return to.handler()(to.ctx, MsgIBCReceive{packet, newAddress(), sequence, proof, header.Height})
+}
+
+// supply keeper recording the inflated, locked and burned coins
+type testSupplyKeeper struct {
+	inflated sdk.Coins
+	locked   sdk.Coins
+	burned   sdk.Coins
+}
+
+func (sk *testSupplyKeeper) Inflate(_ sdk.Context, amount sdk.Coins) {
+	sk.inflated = sk.inflated.Add(amount)
+}
+
+func (sk *testSupplyKeeper) Lock(_ sdk.Context, amount sdk.Coins) {
+	sk.locked = sk.locked.Add(amount)
+}
+
+// coins above the locked amount are new to the chain
+func (sk *testSupplyKeeper) Unlock(_ sdk.Context, amount sdk.Coins) {
+	for _, coin := range amount {
+		released := sdk.MinInt(sk.locked.AmountOf(coin.Denom), coin.Amount)
+		sk.locked = sk.locked.Sub(sdk.Coins{sdk.NewCoin(coin.Denom, released)})
+		sk.inflated = sk.inflated.Add(sdk.Coins{sdk.NewCoin(coin.Denom, coin.Amount.Sub(released))})
+	}
+}
+
+func (sk *testSupplyKeeper) Burn(_ sdk.Context, amount sdk.Coins) {
+	sk.burned = sk.burned.Add(amount)
+}
+
+func (sk *testSupplyKeeper) Unburn(_ sdk.Context, amount sdk.Coins) {
+	sk.burned = sk.burned.Sub(amount)
+}
+
 func TestIBC(t *testing.T) {
-	input := setupTestInput()
-	ctx := input.ctx
+	chainA := setupTestInput("chain-a")
+	chainB := setupTestInput("chain-b")
+	hA, hB := chainA.handler(), chainB.handler()
 
 	src := newAddress()
 	dest := newAddress()
-	chainid := "ibcchain"
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
 
-	coins, _, err := input.bk.AddCoins(ctx, src, mycoins)
+	coins, _, err := chainA.bk.AddCoins(chainA.ctx, src, mycoins)
 	require.Nil(t, err)
 	require.Equal(t, mycoins, coins)
 
-	ibcm := NewMapper(input.cdc, input.ibcKey, DefaultCodespace)
-	h := NewHandler(ibcm, input.bk)
-	packet := IBCPacket{
-		SrcAddr:   src,
-		DestAddr:  dest,
-		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
-	}
-
-	store := ctx.KVStore(input.ibcKey)
+	packet := NewIBCPacket(src, dest, mycoins, "chain-a", "chain-b", 100, time.Time{})
 
-	var msg sdk.Msg
-	var res sdk.Result
-	var egl uint64
-	var igs uint64
-
-	egl = ibcm.getEgressLength(store, chainid)
+	store := chainA.ctx.KVStore(chainA.ibcKey)
+	egl := chainA.ibcm.getEgressLength(store, "chain-b")
 	require.Equal(t, egl, uint64(0))
 
-	msg = MsgIBCTransfer{
-		IBCPacket: packet,
-	}
-	res = h(ctx, msg)
+	res := hA(chainA.ctx, MsgIBCTransfer{packet})
 	require.True(t, res.IsOK())
 
-	coins, err = getCoins(input.bk, ctx, src)
+	coins, err = getCoins(chainA.bk, chainA.ctx, src)
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
+	require.Equal(t, mycoins, chainA.sk.locked)
+	require.Equal(t, CommitPacket(packet), chainA.ibcm.GetPacketCommitment(chainA.ctx, "chain-b", 0))
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = chainA.ibcm.getEgressLength(store, "chain-b")
 	require.Equal(t, egl, uint64(1))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
-	require.Equal(t, igs, uint64(0))
+	// chain B trusts the header of chain A committing the packet
+	headerA := chainA.commit(t)
+	chainB.trust(chainA.consensusState(headerA))
+	proof := chainA.prove(t, PacketCommitmentKey("chain-b", 0))
 
-	msg = MsgIBCReceive{
-		IBCPacket: packet,
-		Relayer:   src,
-		Sequence:  0,
-	}
-	res = h(ctx, msg)
-	require.True(t, res.IsOK())
+	igs := chainB.ibcm.GetIngressSequence(chainB.ctx, "chain-a")
+	require.Equal(t, igs, uint64(0))
 
-	coins, err = getCoins(input.bk, ctx, dest)
+	// the proof must match the packet and a known header
+	forged := packet
+	forged.Coins = mycoins.Add(mycoins)
+	res = hB(chainB.ctx, MsgIBCReceive{forged, src, 0, proof, headerA.Height})
+	require.Equal(t, CodeInvalidProof, res.Code)
+	res = hB(chainB.ctx, MsgIBCReceive{packet, src, 0, proof, headerA.Height + 1})
+	require.Equal(t, CodeClientNotFound, res.Code)
+	res = hA(chainA.ctx, MsgIBCReceive{packet, src, 0, proof, headerA.Height})
+	require.Equal(t, CodeWrongChain, res.Code)
+
+	msg := MsgIBCReceive{packet, src, 0, proof, headerA.Height}
+	res = hB(chainB.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// the coins of chain A are credited as vouchers
+	vouchers := sdk.Coins{sdk.NewInt64Coin(NewDenomTrace("chain-a", "mycoin").VoucherDenom(), 10)}
+	coins, err = getCoins(chainB.bk, chainB.ctx, dest)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	require.Equal(t, vouchers, coins)
+	require.Equal(t, mycoins, chainA.ibcm.GetEscrow(chainA.ctx, "chain-b"))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = chainB.ibcm.GetIngressSequence(chainB.ctx, "chain-a")
 	require.Equal(t, igs, uint64(1))
 
-	res = h(ctx, msg)
+	ack, found := chainB.ibcm.GetAcknowledgement(chainB.ctx, "chain-a", 0)
+	require.True(t, found)
+	require.True(t, ack.Success)
+
+	res = hB(chainB.ctx, msg)
 	require.False(t, res.IsOK())
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = chainB.ibcm.GetIngressSequence(chainB.ctx, "chain-a")
 	require.Equal(t, igs, uint64(1))
+
+	// the successful acknowledgement only clears the commitment on chain A
+	headerB := chainB.commit(t)
+	chainA.trust(chainB.consensusState(headerB))
+	proof = chainB.prove(t, AcknowledgementKey("chain-a", 0))
+
+	res = hA(chainA.ctx, MsgIBCAck{packet, NewIBCAcknowledgement(false, ""), src, 0, proof, headerB.Height})
+	require.Equal(t, CodeInvalidProof, res.Code)
+	res = hA(chainA.ctx, MsgIBCAck{packet, ack, src, 0, proof, headerB.Height})
+	require.True(t, res.IsOK())
+	require.Nil(t, chainA.ibcm.GetPacketCommitment(chainA.ctx, "chain-b", 0))
+	coins, err = getCoins(chainA.bk, chainA.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, zero, coins)
+	require.Equal(t, mycoins, chainA.sk.locked)
+
+	res = hA(chainA.ctx, MsgIBCAck{packet, ack, src, 0, proof, headerB.Height})
+	require.Equal(t, CodeNoCommitment, res.Code)
+}
+
+func TestIBCTimeout(t *testing.T) {
+	chainA := setupTestInput("chain-a")
+	chainB := setupTestInput("chain-b")
+	hA, hB := chainA.handler(), chainB.handler()
+
+	src := newAddress()
+	dest := newAddress()
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+
+	_, _, err := chainA.bk.AddCoins(chainA.ctx, src, mycoins.Add(mycoins))
+	require.Nil(t, err)
+
+	// both packets are refused from the height 3 of chain B
+	packet := NewIBCPacket(src, dest, mycoins, "chain-a", "chain-b", 3, time.Time{})
+	res := hA(chainA.ctx, MsgIBCTransfer{packet})
+	require.True(t, res.IsOK())
+	res = hA(chainA.ctx, MsgIBCTransfer{packet})
+	require.True(t, res.IsOK())
+	require.Equal(t, mycoins.Add(mycoins), chainA.sk.locked)
+
+	headerA := chainA.commit(t)
+	chainB.trust(chainA.consensusState(headerA))
+	headerB := chainB.commit(t)
+	chainA.trust(chainB.consensusState(headerB))
+
+	// the packets have not timed out at the height 2 of chain B
+	proof := chainB.prove(t, IngressSequenceKey("chain-a"))
+	res = hA(chainA.ctx, MsgIBCTimeout{packet, src, 0, 0, proof, headerB.Height})
+	require.Equal(t, CodeNotTimedOut, res.Code)
+
+	// chain B refuses the first packet at the height 3
+	headerB = chainB.commit(t)
+	require.Equal(t, int64(3), chainB.ctx.BlockHeight())
+	res = hB(chainB.ctx, MsgIBCReceive{packet, src, 0, chainA.prove(t, PacketCommitmentKey("chain-b", 0)), headerA.Height})
+	require.True(t, res.IsOK())
+	coins, err := getCoins(chainB.bk, chainB.ctx, dest)
+	require.Nil(t, err)
+	require.True(t, coins.Empty())
+	ack, found := chainB.ibcm.GetAcknowledgement(chainB.ctx, "chain-a", 0)
+	require.True(t, found)
+	require.False(t, ack.Success)
+
+	headerB = chainB.commit(t)
+	require.Nil(t, chainA.ibcm.UpdateClient(chainA.ctx, headerB, chainB.validators(), chainB.validators()))
+
+	// the failed acknowledgement refunds the first packet
+	proof = chainB.prove(t, AcknowledgementKey("chain-a", 0))
+	res = hA(chainA.ctx, MsgIBCAck{packet, NewIBCAcknowledgement(true, ""), src, 0, proof, headerB.Height})
+	require.Equal(t, CodeInvalidProof, res.Code)
+	res = hA(chainA.ctx, MsgIBCAck{packet, ack, src, 0, proof, headerB.Height})
+	require.True(t, res.IsOK())
+	coins, err = getCoins(chainA.bk, chainA.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	require.Equal(t, mycoins, chainA.sk.locked)
+	require.Equal(t, mycoins, chainA.ibcm.GetEscrow(chainA.ctx, "chain-b"))
+
+	// the second packet is timed out, chain B did not receive it
+	proof = chainB.prove(t, IngressSequenceKey("chain-a"))
+	res = hA(chainA.ctx, MsgIBCTimeout{packet, src, 1, 2, proof, headerB.Height})
+	require.Equal(t, CodeNotTimedOut, res.Code)
+	res = hA(chainA.ctx, MsgIBCTimeout{packet, src, 1, 0, proof, headerB.Height})
+	require.Equal(t, CodeInvalidProof, res.Code)
+	res = hA(chainA.ctx, MsgIBCTimeout{packet, src, 1, 1, proof, headerB.Height})
+	require.True(t, res.IsOK())
+	require.Nil(t, chainA.ibcm.GetPacketCommitment(chainA.ctx, "chain-b", 1))
+	coins, err = getCoins(chainA.bk, chainA.ctx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins.Add(mycoins), coins)
+	require.True(t, chainA.sk.locked.Empty())
+	require.True(t, chainA.ibcm.GetEscrow(chainA.ctx, "chain-b").Empty())
+
+	res = hA(chainA.ctx, MsgIBCTimeout{packet, src, 1, 1, proof, headerB.Height})
+	require.Equal(t, CodeNoCommitment, res.Code)
+}
+
+func TestIBCVouchers(t *testing.T) {
+	chainA := setupTestInput("chain-a")
+	chainB := setupTestInput("chain-b")
+	hA, hB := chainA.handler(), chainB.handler()
+
+	addrA := newAddress()
+	addrB := newAddress()
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	voucherA := NewDenomTrace("chain-a", "mycoin").VoucherDenom()
+	voucherB := NewDenomTrace("chain-b", "mycoin").VoucherDenom()
+
+	_, _, err := chainA.bk.AddCoins(chainA.ctx, addrA, mycoins)
+	require.Nil(t, err)
+	_, _, err = chainB.bk.AddCoins(chainB.ctx, addrB, mycoins)
+	require.Nil(t, err)
+
+	// chain B mints vouchers for the coins escrowed by chain A
+	res := hA(chainA.ctx, MsgIBCTransfer{NewIBCPacket(addrA, addrB, mycoins, "chain-a", "chain-b", 100, time.Time{})})
+	require.True(t, res.IsOK(), res.Log)
+	res = relay(t, &chainA, &chainB, 0)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err := getCoins(chainB.bk, chainB.ctx, addrB)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(voucherA, 10)}.Add(mycoins), coins)
+
+	trace, found := chainB.ibcm.GetDenomTrace(chainB.ctx, voucherA)
+	require.True(t, found)
+	require.Equal(t, NewDenomTrace("chain-a", "mycoin"), trace)
+
+	// the vouchers going back to chain A carry their trace and are burned, the
+	// native coins of chain B with the same denom are escrowed
+	back := sdk.Coins{sdk.NewInt64Coin(voucherA, 4)}.Add(sdk.Coins{sdk.NewInt64Coin("mycoin", 5)})
+	res = hB(chainB.ctx, MsgIBCTransfer{NewIBCPacket(addrB, addrA, back, "chain-b", "chain-a", 100, time.Time{})})
+	require.True(t, res.IsOK(), res.Log)
+	packet := chainB.egressPacket(t, "chain-a", 0)
+	require.Equal(t, []DenomTrace{NewDenomTrace("chain-a", "mycoin")}, packet.DenomTraces)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(voucherA, 4)}, chainB.sk.burned)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 5)}, chainB.sk.locked)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 5)}, chainB.ibcm.GetEscrow(chainB.ctx, "chain-a"))
+
+	// chain A releases its escrowed coins and mints vouchers for the coins of chain B
+	res = relay(t, &chainB, &chainA, 0)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(chainA.bk, chainA.ctx, addrA)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 4)}.Add(sdk.Coins{sdk.NewInt64Coin(voucherB, 5)}), coins)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 6)}, chainA.ibcm.GetEscrow(chainA.ctx, "chain-b"))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("mycoin", 6)}, chainA.sk.locked)
+
+	// a packet cannot release more coins than escrowed for its source chain
+	forged := NewIBCPacket(addrB, addrA, sdk.Coins{sdk.NewInt64Coin(voucherA, 7)}, "chain-b", "chain-a", 100, time.Time{})
+	forged.DenomTraces = []DenomTrace{NewDenomTrace("chain-a", "mycoin")}
+	_, _, _, err = receivedCoins(chainA.ctx, chainA.ibcm, forged)
+	require.Equal(t, CodeInvalidDenom, err.Code())
+
+	// the trace of a voucher can be queried
+	querier := NewQuerier(chainA.ibcm)
+	bz, err2 := chainA.cdc.MarshalJSON(NewQueryDenomTraceParams(voucherB))
+	require.NoError(t, err2)
+	res2, err := querier(chainA.ctx, []string{QueryDenomTrace}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	require.NoError(t, chainA.cdc.UnmarshalJSON(res2, &trace))
+	require.Equal(t, NewDenomTrace("chain-b", "mycoin"), trace)
+
+	bz, err2 = chainA.cdc.MarshalJSON(NewQueryDenomTraceParams(voucherA))
+	require.NoError(t, err2)
+	_, err = querier(chainA.ctx, []string{QueryDenomTrace}, abci.RequestQuery{Data: bz})
+	require.Equal(t, CodeInvalidDenom, err.Code())
+}
+
+func TestIBCRefundVouchers(t *testing.T) {
+	chainA := setupTestInput("chain-a")
+	chainB := setupTestInput("chain-b")
+	hB := chainB.handler()
+
+	addrA := newAddress()
+	addrB := newAddress()
+	voucherA := NewDenomTrace("chain-a", "mycoin").VoucherDenom()
+	vouchers := func(amt int64) sdk.Coins { return sdk.Coins{sdk.NewInt64Coin(voucherA, amt)} }
+
+	_, _, err := chainA.bk.AddCoins(chainA.ctx, addrA, sdk.Coins{sdk.NewInt64Coin("mycoin", 12)})
+	require.Nil(t, err)
+	res := chainA.handler()(chainA.ctx, MsgIBCTransfer{NewIBCPacket(addrA, addrB, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)},
+		"chain-a", "chain-b", 100, time.Time{})})
+	require.True(t, res.IsOK(), res.Log)
+	res = relay(t, &chainA, &chainB, 0)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, vouchers(10), chainB.sk.inflated)
+
+	// chain B escrows some vouchers for chain C and sends others back to chain A
+	res = hB(chainB.ctx, MsgIBCTransfer{NewIBCPacket(addrB, addrA, vouchers(3), "chain-b", "chain-c", 100, time.Time{})})
+	require.True(t, res.IsOK(), res.Log)
+	res = hB(chainB.ctx, MsgIBCTransfer{NewIBCPacket(addrB, addrA, vouchers(4), "chain-b", "chain-a",
+		chainA.ctx.BlockHeight()+1, time.Time{})})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, vouchers(3), chainB.sk.locked)
+	require.Equal(t, vouchers(4), chainB.sk.burned)
+
+	// more vouchers of chain A inflate the supply of chain B instead of releasing the escrow of chain C
+	res = chainA.handler()(chainA.ctx, MsgIBCTransfer{NewIBCPacket(addrA, addrB, sdk.Coins{sdk.NewInt64Coin("mycoin", 2)},
+		"chain-a", "chain-b", 100, time.Time{})})
+	require.True(t, res.IsOK(), res.Log)
+	res = relay(t, &chainA, &chainB, 1)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, vouchers(12), chainB.sk.inflated)
+	require.Equal(t, vouchers(3), chainB.sk.locked)
+
+	// the timed out vouchers are unburned, the escrow of chain C stays locked
+	packet := chainB.egressPacket(t, "chain-a", 0)
+	header := chainA.commit(t)
+	require.Nil(t, chainB.ibcm.UpdateClient(chainB.ctx, header, chainA.validators(), chainA.validators()))
+	proof := chainA.prove(t, IngressSequenceKey("chain-b"))
+	res = hB(chainB.ctx, MsgIBCTimeout{packet, addrB, 0, 0, proof, header.Height})
+	require.True(t, res.IsOK(), res.Log)
+
+	coins, err := getCoins(chainB.bk, chainB.ctx, addrB)
+	require.Nil(t, err)
+	require.Equal(t, vouchers(9), coins)
+	require.True(t, chainB.sk.burned.Empty())
+	require.Equal(t, vouchers(3), chainB.sk.locked)
+	require.Equal(t, vouchers(3), chainB.ibcm.GetEscrow(chainB.ctx, "chain-c"))
+	require.True(t, chainB.ibcm.GetEscrow(chainB.ctx, "chain-a").Empty())
+}
+
+func TestUpdateClient(t *testing.T) {
+	chainA := setupTestInput("chain-a")
+	chainB := setupTestInput("chain-b")
+	hA := chainA.handler()
+	relayer := newAddress()
+	vals := chainB.validators()
+
+	header := chainB.commit(t)
+	res := hA(chainA.ctx, MsgUpdateClient{header, vals, vals, relayer})
+	require.Equal(t, CodeClientNotFound, res.Code)
+
+	chainA.trust(chainB.consensusState(header))
+	require.Panics(t, func() { chainA.trust(chainB.consensusState(header)) })
+	err := chainA.ibcm.createClient(chainA.ctx, chainB.consensusState(header))
+	require.Equal(t, CodeClientExists, err.Code())
+
+	// headers can skip heights
+	chainB.commit(t)
+	header = chainB.commit(t)
+	res = hA(chainA.ctx, MsgUpdateClient{header, vals, vals, relayer})
+	require.True(t, res.IsOK(), res.Log)
+
+	cs, found := chainA.ibcm.GetLatestConsensusState(chainA.ctx, "chain-b")
+	require.True(t, found)
+	require.Equal(t, header.Height, cs.Height)
+	require.Equal(t, []byte(header.AppHash), cs.AppHash)
+	_, found = chainA.ibcm.GetConsensusState(chainA.ctx, "chain-b", header.Height-2)
+	require.True(t, found)
+
+	res = hA(chainA.ctx, MsgUpdateClient{header, vals, vals, relayer})
+	require.Equal(t, CodeInvalidClient, res.Code)
+
+	// a header signed by validators the client does not trust is refused
+	pv := tmtypes.NewMockPV()
+	otherVals := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(pv.GetPubKey(), 10, 0, 0)})
+	forged := signHeader(t, "chain-b", header.Height+1, header.Time, header.AppHash, otherVals, otherVals, pv)
+	res = hA(chainA.ctx, MsgUpdateClient{forged, otherVals, otherVals, relayer})
+	require.Equal(t, CodeInvalidClient, res.Code)
+
+	// the trusted validators can hand over to new ones
+	handover := tmtypes.NewValidatorSet([]*tmtypes.Validator{
+		tmtypes.NewValidator(chainB.pv.GetPubKey(), 10, 0, 0), tmtypes.NewValidator(pv.GetPubKey(), 1, 0, 0),
+	})
+	next := signHeader(t, "chain-b", header.Height+1, header.Time, header.AppHash, handover, otherVals, chainB.pv, pv)
+	res = hA(chainA.ctx, MsgUpdateClient{next, handover, otherVals, relayer})
+	require.True(t, res.IsOK(), res.Log)
+
+	res = hA(chainA.ctx, MsgUpdateClient{forged, otherVals, otherVals, relayer})
+	require.Equal(t, CodeInvalidClient, res.Code)
+	forged = signHeader(t, "chain-b", header.Height+2, header.Time, header.AppHash, otherVals, otherVals, pv)
+	res = hA(chainA.ctx, MsgUpdateClient{forged, otherVals, otherVals, relayer})
+	require.True(t, res.IsOK(), res.Log)
 }
@@ -2,8 +2,13 @@ package ibc
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/ColorPlatform/prism/crypto/merkle"
+	tmtypes "github.com/ColorPlatform/prism/types"
 
 	codec "github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/store/rootmulti"
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
@@ -39,6 +44,7 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	}
 
 	store.Set(EgressKey(packet.DestChain, index), bz)
+	store.Set(PacketCommitmentKey(packet.DestChain, index), CommitPacket(packet))
 	bz, err = ibcm.cdc.MarshalBinaryLengthPrefixed(index + 1)
 	if err != nil {
 		panic(err)
@@ -48,6 +54,36 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	return nil
 }
 
+// GetPacketCommitment returns the commitment to an outgoing packet which is
+// neither acknowledged nor timed out, nil otherwise.
+func (ibcm Mapper) GetPacketCommitment(ctx sdk.Context, destChain string, sequence uint64) []byte {
+	store := ctx.KVStore(ibcm.key)
+	return store.Get(PacketCommitmentKey(destChain, sequence))
+}
+
+// Deletes the commitment to an outgoing packet once it is acknowledged or timed out.
+func (ibcm Mapper) deletePacketCommitment(ctx sdk.Context, destChain string, sequence uint64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Delete(PacketCommitmentKey(destChain, sequence))
+}
+
+// GetAcknowledgement returns the acknowledgement written for an incoming packet
+func (ibcm Mapper) GetAcknowledgement(ctx sdk.Context, srcChain string, sequence uint64) (ack IBCAcknowledgement, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(AcknowledgementKey(srcChain, sequence))
+	if bz == nil {
+		return ack, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &ack)
+	return ack, true
+}
+
+// SetAcknowledgement stores the acknowledgement of an incoming packet
+func (ibcm Mapper) SetAcknowledgement(ctx sdk.Context, srcChain string, sequence uint64, ack IBCAcknowledgement) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(AcknowledgementKey(srcChain, sequence), marshalBinaryPanic(ibcm.cdc, ack))
+}
+
 // XXX: In the future every module is able to register it's own handler for
 // handling it's own IBC packets. The "ibc" handler will only route the packets
 // to the appropriate callbacks.
@@ -57,6 +93,174 @@ func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error
 	return nil
 }
 
+// Stores the first trusted consensus state of a counterparty chain. The trust
+// cannot come from a relayer, clients are created from the genesis.
+func (ibcm Mapper) createClient(ctx sdk.Context, cs ConsensusState) sdk.Error {
+	if err := cs.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, found := ibcm.GetLatestConsensusState(ctx, cs.ChainID); found {
+		return ErrClientExists(ibcm.codespace)
+	}
+	ibcm.setConsensusState(ctx, cs)
+	return nil
+}
+
+// UpdateClient verifies a header of a counterparty chain against the latest
+// trusted consensus state and stores the consensus state of the header.
+func (ibcm Mapper) UpdateClient(ctx sdk.Context, header tmtypes.SignedHeader,
+	validators, nextValidators *tmtypes.ValidatorSet) sdk.Error {
+
+	latest, found := ibcm.GetLatestConsensusState(ctx, header.ChainID)
+	if !found {
+		return ErrClientNotFound(ibcm.codespace, fmt.Sprintf("no light client of chain %s", header.ChainID))
+	}
+	if header.Height <= latest.Height {
+		return ErrInvalidClient(ibcm.codespace,
+			fmt.Sprintf("header height %d is not above the latest height %d", header.Height, latest.Height))
+	}
+
+	// over 2/3 of the trusted validators and of the new ones must have signed the header
+	err := latest.NextValidators.VerifyFutureCommit(validators, header.ChainID, header.Commit.BlockID,
+		header.Height, header.Commit)
+	if err != nil {
+		return ErrInvalidClient(ibcm.codespace, err.Error())
+	}
+
+	ibcm.setConsensusState(ctx, NewConsensusState(header.ChainID, header.Height, header.Time,
+		header.AppHash, nextValidators))
+	return nil
+}
+
+// GetLatestConsensusState returns the consensus state of the latest header of a counterparty chain
+func (ibcm Mapper) GetLatestConsensusState(ctx sdk.Context, chainID string) (cs ConsensusState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ClientKey(chainID))
+	if bz == nil {
+		return cs, false
+	}
+	var height int64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &height)
+	return ibcm.GetConsensusState(ctx, chainID, height)
+}
+
+// GetLatestConsensusStates returns the consensus states of the latest headers of all the counterparty chains
+func (ibcm Mapper) GetLatestConsensusStates(ctx sdk.Context) (states []ConsensusState) {
+	store := ctx.KVStore(ibcm.key)
+	iter := sdk.KVStorePrefixIterator(store, ClientKey(""))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		// the consensus states are stored under the client key of their chain
+		chainID := strings.TrimPrefix(string(iter.Key()), string(ClientKey("")))
+		if strings.Contains(chainID, "/") {
+			continue
+		}
+		var height int64
+		unmarshalBinaryPanic(ibcm.cdc, iter.Value(), &height)
+		cs, _ := ibcm.GetConsensusState(ctx, chainID, height)
+		states = append(states, cs)
+	}
+	return states
+}
+
+// GetConsensusState returns the consensus state of a counterparty chain at the height of one of its headers
+func (ibcm Mapper) GetConsensusState(ctx sdk.Context, chainID string, height int64) (cs ConsensusState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ConsensusStateKey(chainID, height))
+	if bz == nil {
+		return cs, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &cs)
+	return cs, true
+}
+
+// Stores a consensus state and makes it the latest one of its chain.
+func (ibcm Mapper) setConsensusState(ctx sdk.Context, cs ConsensusState) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ConsensusStateKey(cs.ChainID, cs.Height), marshalBinaryPanic(ibcm.cdc, cs))
+	store.Set(ClientKey(cs.ChainID), marshalBinaryPanic(ibcm.cdc, cs.Height))
+}
+
+// Verifies the proof of a key of the IBC store of a counterparty chain against
+// the app hash of its header at the given height. A nil value proves the absence of the key.
+func (ibcm Mapper) verifyProof(ctx sdk.Context, chainID string, height int64, proof *merkle.Proof,
+	key, value []byte) (ConsensusState, sdk.Error) {
+
+	cs, found := ibcm.GetConsensusState(ctx, chainID, height)
+	if !found {
+		return cs, ErrClientNotFound(ibcm.codespace,
+			fmt.Sprintf("no consensus state of chain %s at height %d", chainID, height))
+	}
+
+	// the counterparty chains store the IBC state under the same store name
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(ibcm.key.Name()), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(key, merkle.KeyEncodingURL)
+
+	var err error
+	prt := rootmulti.DefaultProofRuntime()
+	if value == nil {
+		err = prt.VerifyAbsence(proof, cs.AppHash, kp.String())
+	} else {
+		err = prt.VerifyValue(proof, cs.AppHash, kp.String(), value)
+	}
+	if err != nil {
+		return cs, ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	return cs, nil
+}
+
+// GetDenomTrace returns the origin of a voucher denomination
+func (ibcm Mapper) GetDenomTrace(ctx sdk.Context, voucherDenom string) (trace DenomTrace, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(DenomTraceKey(voucherDenom))
+	if bz == nil {
+		return trace, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &trace)
+	return trace, true
+}
+
+// Stores the origin of a voucher denomination.
+func (ibcm Mapper) setDenomTrace(ctx sdk.Context, trace DenomTrace) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(DenomTraceKey(trace.VoucherDenom()), marshalBinaryPanic(ibcm.cdc, trace))
+}
+
+// Returns the traces of the coins that are vouchers on this chain.
+func (ibcm Mapper) denomTraces(ctx sdk.Context, coins sdk.Coins) []DenomTrace {
+	var traces []DenomTrace
+	for _, coin := range coins {
+		if trace, found := ibcm.GetDenomTrace(ctx, coin.Denom); found {
+			traces = append(traces, trace)
+		}
+	}
+	return traces
+}
+
+// GetEscrow returns the coins escrowed for the vouchers of a counterparty chain,
+// the most that chain can send back
+func (ibcm Mapper) GetEscrow(ctx sdk.Context, chainID string) sdk.Coins {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(EscrowKey(chainID))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	var escrow sdk.Coins
+	unmarshalBinaryPanic(ibcm.cdc, bz, &escrow)
+	return escrow
+}
+
+// Stores the coins escrowed for a counterparty chain.
+func (ibcm Mapper) setEscrow(ctx sdk.Context, chainID string, escrow sdk.Coins) {
+	store := ctx.KVStore(ibcm.key)
+	if escrow.IsZero() {
+		store.Delete(EscrowKey(chainID))
+		return
+	}
+	store.Set(EscrowKey(chainID), marshalBinaryPanic(ibcm.cdc, escrow))
+}
+
 // --------------------------
 // Functions for accessing the underlying KVStore.
 
@@ -128,3 +332,33 @@ func EgressLengthKey(destChain string) []byte {
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
 }
+
+// Stores the commitment to a pending outgoing IBC packet under "commitments/chain_id/index".
+func PacketCommitmentKey(destChain string, index uint64) []byte {
+	return []byte(fmt.Sprintf("commitments/%s/%d", destChain, index))
+}
+
+// Stores the acknowledgement of an incoming IBC packet under "acks/chain_id/index".
+func AcknowledgementKey(srcChain string, index uint64) []byte {
+	return []byte(fmt.Sprintf("acks/%s/%d", srcChain, index))
+}
+
+// Stores the latest height of the light client of a chain under "clients/chain_id".
+func ClientKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("clients/%s", chainID))
+}
+
+// Stores the consensus state of a chain at a height under "clients/chain_id/height".
+func ConsensusStateKey(chainID string, height int64) []byte {
+	return []byte(fmt.Sprintf("clients/%s/%d", chainID, height))
+}
+
+// Stores the origin of a voucher denomination under "denom_traces/denom".
+func DenomTraceKey(voucherDenom string) []byte {
+	return []byte(fmt.Sprintf("denom_traces/%s", voucherDenom))
+}
+
+// Stores the coins escrowed for a chain under "escrow/chain_id".
+func EscrowKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("escrow/%s", chainID))
+}
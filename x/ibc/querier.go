@@ -0,0 +1,60 @@
+package ibc
+
+import (
+	"fmt"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// QuerierRoute is the querier route for the ibc module
+const QuerierRoute = "ibc"
+
+// query endpoints supported by the ibc Querier
+const (
+	QueryDenomTrace = "denom_trace"
+)
+
+func NewQuerier(ibcm Mapper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryDenomTrace:
+			return queryDenomTrace(ctx, req, ibcm)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown ibc query endpoint: %s", path[0]))
+		}
+	}
+}
+
+// Params for query 'custom/ibc/denom_trace'
+type QueryDenomTraceParams struct {
+	Denom string
+}
+
+// creates a new instance of QueryDenomTraceParams
+func NewQueryDenomTraceParams(denom string) QueryDenomTraceParams {
+	return QueryDenomTraceParams{
+		Denom: denom,
+	}
+}
+
+func queryDenomTrace(ctx sdk.Context, req abci.RequestQuery, ibcm Mapper) ([]byte, sdk.Error) {
+	var params QueryDenomTraceParams
+	err := ibcm.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	trace, found := ibcm.GetDenomTrace(ctx, params.Denom)
+	if !found {
+		return nil, ErrInvalidDenomTrace(ibcm.codespace, fmt.Sprintf("no denom trace for %s", params.Denom))
+	}
+
+	bz, err := codec.MarshalJSONIndent(ibcm.cdc, trace)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,154 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ColorPlatform/prism/crypto/merkle"
+	rpcclient "github.com/ColorPlatform/prism/rpc/client"
+	tmtypes "github.com/ColorPlatform/prism/types"
+
+	"github.com/ColorPlatform/color-sdk/client"
+	"github.com/ColorPlatform/color-sdk/client/context"
+	"github.com/ColorPlatform/color-sdk/client/utils"
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	authtxb "github.com/ColorPlatform/color-sdk/x/auth/client/txbuilder"
+)
+
+// Chain is a chain the relayer reads packets from and posts messages to
+type Chain interface {
+	ChainID() string
+
+	// Address returns the account of the relayer on the chain
+	Address() sdk.AccAddress
+
+	// QueryIBC returns the value of a key of the IBC store in the latest state
+	QueryIBC(key []byte) ([]byte, error)
+
+	// QueryIBCWithProof returns the value of a key of the IBC store in the
+	// state committed at a height, along with its proof
+	QueryIBCWithProof(key []byte, height int64) ([]byte, *merkle.Proof, error)
+
+	// LatestHeader returns the latest signed header of the chain whose next
+	// validators are known, along with its validators and next validators
+	LatestHeader() (tmtypes.SignedHeader, *tmtypes.ValidatorSet, *tmtypes.ValidatorSet, error)
+
+	// Broadcast signs the messages in a single transaction and returns once
+	// the transaction is committed
+	Broadcast(msgs []sdk.Msg) error
+}
+
+// rpcChain is a chain reached through the RPC interface of one of its nodes
+type rpcChain struct {
+	cdc        *codec.Codec
+	chainID    string
+	node       string
+	ibcStore   string
+	name       string
+	passphrase string
+	address    sdk.AccAddress
+}
+
+var _ Chain = rpcChain{}
+
+// NewRPCChain returns a chain reached through a node, whose transactions are
+// signed by the given key
+func NewRPCChain(cdc *codec.Codec, chainID, node, ibcStore, name, passphrase string,
+	address sdk.AccAddress) Chain {
+
+	return rpcChain{
+		cdc:        cdc,
+		chainID:    chainID,
+		node:       node,
+		ibcStore:   ibcStore,
+		name:       name,
+		passphrase: passphrase,
+		address:    address,
+	}
+}
+
+func (c rpcChain) ChainID() string         { return c.chainID }
+func (c rpcChain) Address() sdk.AccAddress { return c.address }
+
+func (c rpcChain) cliCtx() context.CLIContext {
+	return context.NewCLIContext().
+		WithCodec(c.cdc).
+		WithAccountDecoder(c.cdc).
+		WithNodeURI(c.node).
+		WithTrustNode(true)
+}
+
+func (c rpcChain) QueryIBC(key []byte) ([]byte, error) {
+	return c.cliCtx().QueryStore(key, c.ibcStore)
+}
+
+func (c rpcChain) QueryIBCWithProof(key []byte, height int64) ([]byte, *merkle.Proof, error) {
+	opts := rpcclient.ABCIQueryOptions{Height: height, Prove: true}
+	res, err := rpcclient.NewHTTP(c.node, "/websocket").ABCIQueryWithOptions(fmt.Sprintf("/store/%s/key", c.ibcStore), key, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !res.Response.IsOK() {
+		return nil, nil, errors.New(res.Response.Log)
+	}
+	return res.Response.Value, res.Response.Proof, nil
+}
+
+// LatestHeader returns the header below the latest block, the validators of
+// the height above it are only known once the latest block is committed
+func (c rpcChain) LatestHeader() (tmtypes.SignedHeader, *tmtypes.ValidatorSet, *tmtypes.ValidatorSet, error) {
+	node := rpcclient.NewHTTP(c.node, "/websocket")
+	status, err := node.Status()
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, nil, err
+	}
+	height := status.SyncInfo.LatestBlockHeight - 1
+	nextHeight := height + 1
+
+	commit, err := node.Commit(&height)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, nil, err
+	}
+	vals, err := node.Validators(&height)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, nil, err
+	}
+	nextVals, err := node.Validators(&nextHeight)
+	if err != nil {
+		return tmtypes.SignedHeader{}, nil, nil, err
+	}
+
+	return commit.SignedHeader, tmtypes.NewValidatorSet(vals.Validators), tmtypes.NewValidatorSet(nextVals.Validators), nil
+}
+
+// Broadcast signs the messages with the account number and sequence of the
+// relayer account on the chain and waits for the transaction to be committed
+func (c rpcChain) Broadcast(msgs []sdk.Msg) error {
+	cliCtx := c.cliCtx()
+
+	account, err := cliCtx.GetAccount(c.address)
+	if err != nil {
+		return err
+	}
+
+	txBldr := authtxb.NewTxBuilderFromCLI().
+		WithTxEncoder(utils.GetTxEncoder(c.cdc)).
+		WithChainID(c.chainID).
+		WithAccountNumber(account.GetAccountNumber()).
+		WithSequence(account.GetSequence())
+
+	txBytes, err := txBldr.BuildAndSign(c.name, c.passphrase, msgs)
+	if err != nil {
+		return err
+	}
+
+	res, err := cliCtx.WithBroadcastMode(client.BroadcastBlock).BroadcastTx(txBytes)
+	if err != nil {
+		return err
+	}
+	if res.Code != uint32(sdk.CodeOK) {
+		return fmt.Errorf("transaction %s failed: %s", res.TxHash, res.RawLog)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package relayer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config is the configuration of the relayer, read from a TOML file:
+//
+//   state_file = "/home/user/.colorcli/relayer/state.json"
+//   poll_interval = "5s"
+//   batch_size = 20
+//   max_backoff = "5m"
+//
+//   [[chains]]
+//   chain_id = "chain-a"
+//   node = "tcp://localhost:26657"
+//
+//   [[chains]]
+//   chain_id = "chain-b"
+//   node = "tcp://localhost:36657"
+//
+//   [[paths]]
+//   chain_a = "chain-a"
+//   chain_b = "chain-b"
+type Config struct {
+	StateFile    string        `mapstructure:"state_file"`
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	MaxBackoff   time.Duration `mapstructure:"max_backoff"`
+	Chains       []ChainConfig `mapstructure:"chains"`
+	Paths        []Path        `mapstructure:"paths"`
+}
+
+// ChainConfig is a chain and the node the relayer connects to
+type ChainConfig struct {
+	ChainID string `mapstructure:"chain_id"`
+	Node    string `mapstructure:"node"`
+}
+
+// Path is a pair of chains the relayer relays packets and acknowledgements
+// between, in both directions
+type Path struct {
+	ChainA string `mapstructure:"chain_a"`
+	ChainB string `mapstructure:"chain_b"`
+}
+
+func (p Path) String() string {
+	return fmt.Sprintf("%s<->%s", p.ChainA, p.ChainB)
+}
+
+// DefaultConfig returns the default configuration, without chains
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+		MaxBackoff:   5 * time.Minute,
+	}
+}
+
+// LoadConfig reads the configuration of the relayer from a file, the options
+// absent from the file keep their default value
+func LoadConfig(file string) (Config, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return Config{}, err
+	}
+
+	config := DefaultConfig()
+	if err := v.Unmarshal(&config); err != nil {
+		return Config{}, err
+	}
+	return config, config.ValidateBasic()
+}
+
+// ValidateBasic checks the options and that every path is between configured chains
+func (c Config) ValidateBasic() error {
+	if c.StateFile == "" {
+		return fmt.Errorf("state_file is required")
+	}
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("poll_interval must be positive, got %s", c.PollInterval)
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive, got %d", c.BatchSize)
+	}
+	if c.MaxBackoff < c.PollInterval {
+		return fmt.Errorf("max_backoff cannot be less than poll_interval")
+	}
+
+	chains := make(map[string]bool)
+	for _, chain := range c.Chains {
+		if chain.ChainID == "" || chain.Node == "" {
+			return fmt.Errorf("chains require a chain_id and a node")
+		}
+		if chains[chain.ChainID] {
+			return fmt.Errorf("chain %s is configured twice", chain.ChainID)
+		}
+		chains[chain.ChainID] = true
+	}
+
+	if len(c.Paths) == 0 {
+		return fmt.Errorf("at least one path is required")
+	}
+	for _, path := range c.Paths {
+		if !chains[path.ChainA] || !chains[path.ChainB] {
+			return fmt.Errorf("path %s is between unknown chains", path)
+		}
+		if path.ChainA == path.ChainB {
+			return fmt.Errorf("path %s is between the same chain", path)
+		}
+	}
+	return nil
+}
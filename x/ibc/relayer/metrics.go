@@ -0,0 +1,85 @@
+package relayer
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by the relayer
+	MetricsSubsystem = "ibc_relayer"
+)
+
+// Metrics contains metrics exposed by the relayer, labelled with the source
+// and destination chains.
+type Metrics struct {
+	// Number of packets relayed.
+	PacketsRelayed metrics.Counter
+	// Number of acknowledgements relayed.
+	AcksRelayed metrics.Counter
+	// Number of packet timeouts relayed.
+	TimeoutsRelayed metrics.Counter
+	// Number of light client updates posted.
+	ClientUpdates metrics.Counter
+	// Number of failed relay attempts.
+	Failures metrics.Counter
+	// Number of packets sent by the source chain and not received yet.
+	PendingPackets metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+func PrometheusMetrics(namespace string) *Metrics {
+	labels := []string{"src", "dest"}
+	return &Metrics{
+		PacketsRelayed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "packets_relayed",
+			Help:      "Number of packets relayed.",
+		}, labels),
+		AcksRelayed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "acks_relayed",
+			Help:      "Number of acknowledgements relayed.",
+		}, labels),
+		TimeoutsRelayed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "timeouts_relayed",
+			Help:      "Number of packet timeouts relayed.",
+		}, labels),
+		ClientUpdates: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "client_updates",
+			Help:      "Number of light client updates posted.",
+		}, labels),
+		Failures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "failures",
+			Help:      "Number of failed relay attempts.",
+		}, labels),
+		PendingPackets: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pending_packets",
+			Help:      "Number of packets sent by the source chain and not received yet.",
+		}, labels),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		PacketsRelayed:  discard.NewCounter(),
+		AcksRelayed:     discard.NewCounter(),
+		TimeoutsRelayed: discard.NewCounter(),
+		ClientUpdates:   discard.NewCounter(),
+		Failures:        discard.NewCounter(),
+		PendingPackets:  discard.NewGauge(),
+	}
+}
@@ -0,0 +1,460 @@
+package relayer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ColorPlatform/prism/libs/log"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/ibc"
+)
+
+// Relayer relays the IBC packets and their acknowledgements along the paths of
+// its configuration, and the timeouts of the packets that expired before they
+// were relayed. Each relay attempt posts at most one transaction per
+// destination chain, batching the light client update with the packets.
+type Relayer struct {
+	cdc     *codec.Codec
+	config  Config
+	chains  map[string]Chain
+	state   *State
+	logger  log.Logger
+	metrics *Metrics
+}
+
+// NewRelayer returns a relayer for the paths of the configuration, between the given chains
+func NewRelayer(cdc *codec.Codec, config Config, chains []Chain, state *State,
+	logger log.Logger, metrics *Metrics) (*Relayer, error) {
+
+	r := &Relayer{
+		cdc:     cdc,
+		config:  config,
+		chains:  make(map[string]Chain),
+		state:   state,
+		logger:  logger,
+		metrics: metrics,
+	}
+	for _, chain := range chains {
+		r.chains[chain.ChainID()] = chain
+	}
+	for _, path := range config.Paths {
+		if r.chains[path.ChainA] == nil || r.chains[path.ChainB] == nil {
+			return nil, fmt.Errorf("no chain for path %s", path)
+		}
+	}
+	return r, nil
+}
+
+// backoff delays the relay attempts along a path after failures
+type backoff struct {
+	delay   time.Duration
+	retryAt time.Time
+}
+
+func (b *backoff) fail(now time.Time, min, max time.Duration) {
+	b.delay *= 2
+	if b.delay < min {
+		b.delay = min
+	}
+	if b.delay > max {
+		b.delay = max
+	}
+	b.retryAt = now.Add(b.delay)
+}
+
+// Run relays along every path at each poll interval until quit is closed. A
+// path whose relay failed is retried after a delay doubling with each failure.
+func (r *Relayer) Run(quit <-chan struct{}) {
+	backoffs := make(map[Path]*backoff)
+	for _, path := range r.config.Paths {
+		backoffs[path] = &backoff{}
+	}
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, path := range r.config.Paths {
+			b := backoffs[path]
+			if now.Before(b.retryAt) {
+				continue
+			}
+
+			if err := r.RelayPath(path); err != nil {
+				b.fail(now, r.config.PollInterval, r.config.MaxBackoff)
+				r.logger.Error("Failed to relay", "path", path, "err", err, "retry_in", b.delay)
+				continue
+			}
+			*b = backoff{}
+		}
+
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPath relays the pending packets, acknowledgements and timeouts of a path
+// in both directions and returns the first error
+func (r *Relayer) RelayPath(path Path) error {
+	chainA, chainB := r.chains[path.ChainA], r.chains[path.ChainB]
+	errAB := r.relay(chainA, chainB)
+	errBA := r.relay(chainB, chainA)
+	if errAB != nil {
+		return errAB
+	}
+	return errBA
+}
+
+// relay times out the expired packets of the source chain, relays the other
+// packets to the destination chain, then their acknowledgements back to the
+// source chain
+func (r *Relayer) relay(src, dest Chain) error {
+	seqs := r.state.Get(src.ChainID(), dest.ChainID())
+	logger := r.logger.With("src", src.ChainID(), "dest", dest.ChainID())
+	labels := []string{"src", src.ChainID(), "dest", dest.ChainID()}
+
+	processed, err := querySequence(r.cdc, dest, ibc.IngressSequenceKey(src.ChainID()))
+	if err != nil {
+		return err
+	}
+	egressLength, err := querySequence(r.cdc, src, ibc.EgressLengthKey(dest.ChainID()))
+	if err != nil {
+		return err
+	}
+	r.metrics.PendingPackets.With(labels...).Set(float64(egressLength - processed))
+
+	// the destination chain is the authority on the packets it received
+	if seqs.Packets != processed {
+		logger.Info("Resuming from the ingress sequence of the destination chain", "state", seqs.Packets, "sequence", processed)
+		seqs.Packets = processed
+	}
+
+	if egressLength > processed {
+		timedOut, err := r.relayTimeouts(src, dest, processed, egressLength)
+		if err != nil {
+			return err
+		}
+		if timedOut > 0 {
+			r.metrics.TimeoutsRelayed.With(labels...).Add(float64(timedOut))
+			logger.Info("Relayed IBC timeouts", "sequence", processed, "count", timedOut)
+		}
+
+		received, err := r.relayPackets(src, dest, processed, egressLength)
+		if err != nil {
+			return err
+		}
+		if received > 0 {
+			r.metrics.PacketsRelayed.With(labels...).Add(float64(received))
+			logger.Info("Relayed IBC packets", "sequence", processed, "count", received)
+			processed += received
+		}
+	}
+	seqs.Packets = processed
+
+	if seqs.Acks < processed {
+		acked, count, err := r.relayAcks(src, dest, seqs.Acks, processed)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			r.metrics.AcksRelayed.With(labels...).Add(float64(count))
+			logger.Info("Relayed IBC acknowledgements", "sequence", seqs.Acks, "count", count)
+		}
+		seqs.Acks = acked
+	}
+
+	return r.state.Set(src.ChainID(), dest.ChainID(), seqs)
+}
+
+// relayTimeouts posts to the source chain the timeouts of a batch of the packets
+// from the given sequence that expired before the destination chain received
+// them, and returns the number of packets timed out
+func (r *Relayer) relayTimeouts(src, dest Chain, from, to uint64) (uint64, error) {
+	msgs, cs, err := updateClient(r.cdc, dest, src)
+	if err != nil {
+		return 0, err
+	}
+	updates := len(msgs)
+
+	timeouts, err := timeoutMsgs(r.cdc, src, dest, cs, from, to, r.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	msgs = append(msgs, timeouts...)
+
+	timedOut := uint64(len(timeouts))
+	if err = r.broadcast(dest, src, msgs, updates, timedOut > 0); err != nil {
+		return 0, err
+	}
+	return timedOut, nil
+}
+
+// relayPackets posts to the destination chain a batch of packets of the source
+// chain from the given sequence and returns the number of packets posted. The
+// packets are received in order, so relaying stops at a timed out packet.
+func (r *Relayer) relayPackets(src, dest Chain, from, to uint64) (uint64, error) {
+	msgs, cs, err := updateClient(r.cdc, src, dest)
+	if err != nil {
+		return 0, err
+	}
+	updates := len(msgs)
+	proofHeight := cs.Height
+
+	provable := true
+	for seq := from; seq < to && len(msgs)-updates < r.config.BatchSize; seq++ {
+		commitment, proof, err := src.QueryIBCWithProof(ibc.PacketCommitmentKey(dest.ChainID(), seq), proofHeight-1)
+		if err != nil {
+			return 0, err
+		}
+		if commitment == nil {
+			commitment, err = src.QueryIBC(ibc.PacketCommitmentKey(dest.ChainID(), seq))
+			if err != nil {
+				return 0, err
+			}
+			if commitment == nil {
+				// the destination chain can no longer receive the packets of the source chain
+				r.logger.Debug("IBC packet timed out", "src", src.ChainID(), "dest", dest.ChainID(), "sequence", seq)
+				break
+			}
+
+			// the packet is not in the state proven by the light client yet
+			r.logger.Debug("IBC packet not provable yet", "src", src.ChainID(), "dest", dest.ChainID(), "sequence", seq)
+			provable = false
+			break
+		}
+
+		packet, err := queryPacket(r.cdc, src, dest.ChainID(), seq)
+		if err != nil {
+			return 0, err
+		}
+		msgs = append(msgs, ibc.MsgIBCReceive{
+			IBCPacket:   packet,
+			Relayer:     dest.Address(),
+			Sequence:    seq,
+			Proof:       proof,
+			ProofHeight: proofHeight,
+		})
+	}
+
+	received := uint64(len(msgs) - updates)
+	if err = r.broadcast(src, dest, msgs, updates, received > 0 || !provable); err != nil {
+		return 0, err
+	}
+	return received, nil
+}
+
+// relayAcks posts to the source chain a batch of acknowledgements of the
+// destination chain from the given sequence and returns the next sequence to
+// acknowledge along with the number of acknowledgements posted
+func (r *Relayer) relayAcks(src, dest Chain, from, to uint64) (uint64, uint64, error) {
+	msgs, cs, err := updateClient(r.cdc, dest, src)
+	if err != nil {
+		return from, 0, err
+	}
+	updates := len(msgs)
+	proofHeight := cs.Height
+
+	provable := true
+	seq := from
+	for ; seq < to && len(msgs)-updates < r.config.BatchSize; seq++ {
+		commitment, err := src.QueryIBC(ibc.PacketCommitmentKey(dest.ChainID(), seq))
+		if err != nil {
+			return from, 0, err
+		}
+		if commitment == nil {
+			// already acknowledged or timed out
+			continue
+		}
+
+		ackbz, proof, err := dest.QueryIBCWithProof(ibc.AcknowledgementKey(src.ChainID(), seq), proofHeight-1)
+		if err != nil {
+			return from, 0, err
+		}
+		if ackbz == nil {
+			r.logger.Debug("IBC acknowledgement not provable yet", "src", src.ChainID(), "dest", dest.ChainID(), "sequence", seq)
+			provable = false
+			break
+		}
+		var ack ibc.IBCAcknowledgement
+		if err = r.cdc.UnmarshalBinaryLengthPrefixed(ackbz, &ack); err != nil {
+			return from, 0, err
+		}
+
+		packet, err := queryPacket(r.cdc, src, dest.ChainID(), seq)
+		if err != nil {
+			return from, 0, err
+		}
+		msgs = append(msgs, ibc.MsgIBCAck{
+			IBCPacket:       packet,
+			Acknowledgement: ack,
+			Relayer:         src.Address(),
+			Sequence:        seq,
+			Proof:           proof,
+			ProofHeight:     proofHeight,
+		})
+	}
+
+	acked := uint64(len(msgs) - updates)
+	if err = r.broadcast(dest, src, msgs, updates, acked > 0 || !provable); err != nil {
+		return from, 0, err
+	}
+	return seq, acked, nil
+}
+
+// broadcast posts the messages to the destination chain. A light client update
+// alone is only posted when it is needed to prove the next message.
+func (r *Relayer) broadcast(src, dest Chain, msgs []sdk.Msg, updates int, needed bool) error {
+	if len(msgs) == 0 || !needed {
+		return nil
+	}
+	if err := dest.Broadcast(msgs); err != nil {
+		return err
+	}
+	if updates > 0 {
+		r.metrics.ClientUpdates.With("src", src.ChainID(), "dest", dest.ChainID()).Add(float64(updates))
+		r.logger.Info("Updated IBC light client", "src", src.ChainID(), "dest", dest.ChainID())
+	}
+	return nil
+}
+
+// TimeoutMsgs returns the messages timing out a packet of the source chain the
+// destination chain did not receive before it expired: the update of the light
+// client of the destination chain on the source chain, unless it is up to date,
+// and the timeout proving the packet was not received.
+func TimeoutMsgs(cdc *codec.Codec, src, dest Chain, sequence uint64) ([]sdk.Msg, error) {
+	msgs, cs, err := updateClient(cdc, dest, src)
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts, err := timeoutMsgs(cdc, src, dest, cs, sequence, sequence+1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(timeouts) == 0 {
+		return nil, fmt.Errorf("IBC packet %d from %s to %s is not pending or has not expired at height %d of %s",
+			sequence, src.ChainID(), dest.ChainID(), cs.Height, dest.ChainID())
+	}
+	return append(msgs, timeouts...), nil
+}
+
+// timeoutMsgs returns the timeouts of up to limit packets of the source chain,
+// from the given sequence, that the destination chain did not receive before
+// they expired at the header of its consensus state. The packets are proven not
+// received by the ingress sequence of the destination chain at that header.
+func timeoutMsgs(cdc *codec.Codec, src, dest Chain, cs ibc.ConsensusState, from, to uint64,
+	limit int) ([]sdk.Msg, error) {
+
+	bz, proof, err := dest.QueryIBCWithProof(ibc.IngressSequenceKey(src.ChainID()), cs.Height-1)
+	if err != nil {
+		return nil, err
+	}
+	var nextSequenceRecv uint64
+	if bz != nil {
+		if err = cdc.UnmarshalBinaryLengthPrefixed(bz, &nextSequenceRecv); err != nil {
+			return nil, err
+		}
+	}
+	if from < nextSequenceRecv {
+		from = nextSequenceRecv
+	}
+
+	var msgs []sdk.Msg
+	for seq := from; seq < to && len(msgs) < limit; seq++ {
+		commitment, err := src.QueryIBC(ibc.PacketCommitmentKey(dest.ChainID(), seq))
+		if err != nil {
+			return nil, err
+		}
+		if commitment == nil {
+			// already timed out
+			continue
+		}
+
+		packet, err := queryPacket(cdc, src, dest.ChainID(), seq)
+		if err != nil {
+			return nil, err
+		}
+		if !packet.TimedOut(cs.Height, cs.Time) {
+			continue
+		}
+		msgs = append(msgs, ibc.MsgIBCTimeout{
+			IBCPacket:        packet,
+			Relayer:          src.Address(),
+			Sequence:         seq,
+			NextSequenceRecv: nextSequenceRecv,
+			Proof:            proof,
+			ProofHeight:      cs.Height,
+		})
+	}
+	return msgs, nil
+}
+
+// updateClient returns the message updating the light client of the source
+// chain on the destination chain to the latest header of the source chain,
+// unless the client is up to date, and the consensus state of the client
+// header. The proofs against that header are queried at the height below.
+func updateClient(cdc *codec.Codec, src, dest Chain) ([]sdk.Msg, ibc.ConsensusState, error) {
+	var cs ibc.ConsensusState
+	latestbz, err := dest.QueryIBC(ibc.ClientKey(src.ChainID()))
+	if err != nil {
+		return nil, cs, err
+	}
+	if latestbz == nil {
+		return nil, cs, fmt.Errorf("no light client of %s on %s", src.ChainID(), dest.ChainID())
+	}
+	var latest int64
+	if err = cdc.UnmarshalBinaryLengthPrefixed(latestbz, &latest); err != nil {
+		return nil, cs, err
+	}
+
+	header, vals, nextVals, err := src.LatestHeader()
+	if err != nil {
+		return nil, cs, err
+	}
+	if header.Height <= latest {
+		csbz, err := dest.QueryIBC(ibc.ConsensusStateKey(src.ChainID(), latest))
+		if err != nil {
+			return nil, cs, err
+		}
+		err = cdc.UnmarshalBinaryLengthPrefixed(csbz, &cs)
+		return nil, cs, err
+	}
+
+	msg := ibc.MsgUpdateClient{
+		Header:         header,
+		Validators:     vals,
+		NextValidators: nextVals,
+		Signer:         dest.Address(),
+	}
+	cs = ibc.NewConsensusState(header.ChainID, header.Height, header.Time, header.AppHash, nextVals)
+	return []sdk.Msg{msg}, cs, nil
+}
+
+func querySequence(cdc *codec.Codec, chain Chain, key []byte) (uint64, error) {
+	bz, err := chain.QueryIBC(key)
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	var seq uint64
+	err = cdc.UnmarshalBinaryLengthPrefixed(bz, &seq)
+	return seq, err
+}
+
+func queryPacket(cdc *codec.Codec, src Chain, destChainID string, seq uint64) (ibc.IBCPacket, error) {
+	var packet ibc.IBCPacket
+	bz, err := src.QueryIBC(ibc.EgressKey(destChainID, seq))
+	if err != nil {
+		return packet, err
+	}
+	if bz == nil {
+		return packet, fmt.Errorf("no IBC packet %d from %s to %s", seq, src.ChainID(), destChainID)
+	}
+	err = cdc.UnmarshalBinaryLengthPrefixed(bz, &packet)
+	return packet, err
+}
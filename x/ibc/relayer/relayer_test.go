@@ -0,0 +1,425 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	"github.com/ColorPlatform/prism/crypto"
+	"github.com/ColorPlatform/prism/crypto/merkle"
+	"github.com/ColorPlatform/prism/crypto/secp256k1"
+	"github.com/ColorPlatform/prism/libs/log"
+	tmtypes "github.com/ColorPlatform/prism/types"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/auth"
+	"github.com/ColorPlatform/color-sdk/x/bank"
+	"github.com/ColorPlatform/color-sdk/x/ibc"
+	"github.com/ColorPlatform/color-sdk/x/mock"
+)
+
+// testChain is an in-process mock app with a single validator, committing a
+// block for each transaction
+type testChain struct {
+	t       *testing.T
+	app     *mock.App
+	chainID string
+	ibcm    ibc.Mapper
+	bk      bank.Keeper
+	pv      *tmtypes.MockPV
+	relayer crypto.PrivKey
+
+	height   int64
+	time     time.Time
+	txs      int
+	failures int // number of broadcasts to refuse
+}
+
+var _ Chain = &testChain{}
+
+// supply keeper ignoring the coins locked and unlocked by the ibc module
+type testSupplyKeeper struct{}
+
+func (testSupplyKeeper) Inflate(sdk.Context, sdk.Coins) {}
+func (testSupplyKeeper) Lock(sdk.Context, sdk.Coins)    {}
+func (testSupplyKeeper) Unlock(sdk.Context, sdk.Coins)  {}
+func (testSupplyKeeper) Burn(sdk.Context, sdk.Coins)    {}
+func (testSupplyKeeper) Unburn(sdk.Context, sdk.Coins)  {}
+
+func newTestChain(t *testing.T, chainID string, relayer crypto.PrivKey, accs ...auth.Account) *testChain {
+	mapp := mock.NewApp()
+
+	ibc.RegisterCodec(mapp.Cdc)
+	keyIBC := sdk.NewKVStoreKey("ibc")
+	ibcm := ibc.NewMapper(mapp.Cdc, keyIBC, ibc.DefaultCodespace)
+	bk := bank.NewBaseKeeper(mapp.AccountKeeper,
+		mapp.ParamsKeeper.Subspace(bank.DefaultParamspace),
+		bank.DefaultCodespace)
+	mapp.Router().AddRoute("ibc", ibc.NewHandler(ibcm, bk, testSupplyKeeper{}))
+	require.NoError(t, mapp.CompleteSetup(keyIBC))
+
+	accs = append(accs, &auth.BaseAccount{Address: sdk.AccAddress(relayer.PubKey().Address())})
+	mock.SetGenesis(mapp, accs)
+
+	return &testChain{
+		t:       t,
+		app:     mapp,
+		chainID: chainID,
+		ibcm:    ibcm,
+		bk:      bk,
+		pv:      tmtypes.NewMockPV(),
+		relayer: relayer,
+		height:  mapp.LastBlockHeight(),
+		time:    time.Now().UTC(),
+	}
+}
+
+func (c *testChain) ChainID() string         { return c.chainID }
+func (c *testChain) Address() sdk.AccAddress { return sdk.AccAddress(c.relayer.PubKey().Address()) }
+
+func (c *testChain) validators() *tmtypes.ValidatorSet {
+	return tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(c.pv.GetPubKey(), 10, 0, 0)})
+}
+
+// block runs a block and commits it
+func (c *testChain) block(deliver func(ctx sdk.Context)) {
+	c.height++
+	c.time = c.time.Add(5 * time.Second)
+	header := abci.Header{ChainID: c.chainID, Height: c.height, Time: c.time}
+
+	c.app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	deliver(c.app.NewContext(false, header))
+	c.app.EndBlock(abci.RequestEndBlock{})
+	c.app.Commit()
+}
+
+// deliver commits a block with a transaction signed by the given key
+func (c *testChain) deliver(priv crypto.PrivKey, msgs []sdk.Msg) sdk.Result {
+	ctx := c.app.NewContext(true, abci.Header{})
+	acc := c.app.AccountKeeper.GetAccount(ctx, sdk.AccAddress(priv.PubKey().Address()))
+	require.NotNil(c.t, acc)
+
+	fee := auth.NewStdFee(200000, sdk.Coins{})
+	sig, err := priv.Sign(auth.StdSignBytes(c.chainID, acc.GetAccountNumber(), acc.GetSequence(), fee, msgs, ""))
+	require.NoError(c.t, err)
+	tx := auth.NewStdTx(msgs, fee, []auth.StdSignature{{PubKey: priv.PubKey(), Signature: sig}}, "")
+
+	var res sdk.Result
+	c.block(func(sdk.Context) {
+		res = c.app.Deliver(tx)
+	})
+	return res
+}
+
+func (c *testChain) Broadcast(msgs []sdk.Msg) error {
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("connection refused")
+	}
+	c.txs++
+	if res := c.deliver(c.relayer, msgs); !res.IsOK() {
+		return errors.New(res.Log)
+	}
+	return nil
+}
+
+func (c *testChain) QueryIBC(key []byte) ([]byte, error) {
+	value, _, err := c.QueryIBCWithProof(key, 0)
+	return value, err
+}
+
+func (c *testChain) QueryIBCWithProof(key []byte, height int64) ([]byte, *merkle.Proof, error) {
+	res := c.app.Query(abci.RequestQuery{Path: "/store/ibc/key", Data: key, Height: height, Prove: height > 0})
+	if !res.IsOK() {
+		return nil, nil, errors.New(res.Log)
+	}
+	return res.Value, res.Proof, nil
+}
+
+// LatestHeader returns the header of the next block, which holds the app hash
+// of the last committed state
+func (c *testChain) LatestHeader() (tmtypes.SignedHeader, *tmtypes.ValidatorSet, *tmtypes.ValidatorSet, error) {
+	vals := c.validators()
+	header := &tmtypes.Header{
+		ChainID:            c.chainID,
+		Height:             c.height + 1,
+		Time:               c.time,
+		AppHash:            c.app.LastCommitID().Hash,
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+	vote := &tmtypes.Vote{
+		Type:             tmtypes.PrecommitType,
+		Height:           header.Height,
+		BlockID:          blockID,
+		Timestamp:        header.Time,
+		ValidatorAddress: c.pv.GetPubKey().Address(),
+	}
+	require.NoError(c.t, c.pv.SignVote(c.chainID, vote))
+	commit := tmtypes.NewCommit(blockID, []*tmtypes.CommitSig{vote.CommitSig()})
+	return tmtypes.SignedHeader{Header: header, Commit: commit}, vals, vals, nil
+}
+
+// trust creates the light client of a counterparty chain
+func (c *testChain) trust(counterparty *testChain) {
+	header, _, nextVals, _ := counterparty.LatestHeader()
+	c.block(func(ctx sdk.Context) {
+		cs := ibc.NewConsensusState(header.ChainID, header.Height, header.Time, header.AppHash, nextVals)
+		ibc.InitGenesis(ctx, c.ibcm, ibc.NewGenesisState([]ibc.ConsensusState{cs}))
+	})
+}
+
+func (c *testChain) balance(addr sdk.AccAddress) sdk.Coins {
+	return c.bk.GetCoins(c.app.NewContext(true, abci.Header{}), addr)
+}
+
+func setupRelayer(t *testing.T, batchSize int) (r *Relayer, chainA, chainB *testChain, stateFile string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "relayer")
+	require.NoError(t, err)
+
+	relayerKey := secp256k1.GenPrivKey()
+	chainA = newTestChain(t, "chain-a", relayerKey)
+	chainB = newTestChain(t, "chain-b", relayerKey)
+	chainA.trust(chainB)
+	chainB.trust(chainA)
+
+	config := DefaultConfig()
+	config.StateFile = filepath.Join(dir, "state.json")
+	config.BatchSize = batchSize
+	config.Chains = []ChainConfig{{"chain-a", "a"}, {"chain-b", "b"}}
+	config.Paths = []Path{{"chain-a", "chain-b"}}
+	require.NoError(t, config.ValidateBasic())
+
+	r = newRelayer(t, config, chainA, chainB)
+	return r, chainA, chainB, config.StateFile, func() { os.RemoveAll(dir) }
+}
+
+// newRelayer returns a relayer resuming from the state file of the configuration
+func newRelayer(t *testing.T, config Config, chainA, chainB *testChain) *Relayer {
+	state, err := LoadState(config.StateFile)
+	require.NoError(t, err)
+	r, err := NewRelayer(chainA.app.Cdc, config, []Chain{chainA, chainB}, state, log.NewNopLogger(), NopMetrics())
+	require.NoError(t, err)
+	return r
+}
+
+func TestRelayer(t *testing.T) {
+	r, chainA, chainB, stateFile, cleanup := setupRelayer(t, 2)
+	defer cleanup()
+	path := Path{"chain-a", "chain-b"}
+
+	user := secp256k1.GenPrivKey()
+	src := sdk.AccAddress(user.PubKey().Address())
+	dest := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	chainA.block(func(ctx sdk.Context) {
+		_, _, err := chainA.bk.AddCoins(ctx, src, sdk.Coins{sdk.NewInt64Coin("mycoin", 30)})
+		require.Nil(t, err)
+	})
+
+	// three packets wait on chain A
+	coins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	for i := 0; i < 3; i++ {
+		packet := ibc.NewIBCPacket(src, dest, coins, "chain-a", "chain-b", 0, chainB.time.Add(time.Hour))
+		res := chainA.deliver(user, []sdk.Msg{ibc.MsgIBCTransfer{IBCPacket: packet}})
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// the first batch holds the client update and two packets, their
+	// acknowledgements go back in a single transaction
+	require.NoError(t, r.RelayPath(path))
+	require.Equal(t, 1, chainB.txs)
+	require.Equal(t, 1, chainA.txs)
+	voucher := ibc.NewDenomTrace("chain-a", "mycoin").VoucherDenom()
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(voucher, 20)}, chainB.balance(dest))
+	require.Equal(t, Sequences{Packets: 2, Acks: 2}, r.state.Get("chain-a", "chain-b"))
+	for seq := uint64(0); seq < 2; seq++ {
+		commitment, _ := chainA.QueryIBC(ibc.PacketCommitmentKey("chain-b", seq))
+		require.Nil(t, commitment)
+	}
+
+	// a restarted relayer resumes from the state file
+	bz, err := ioutil.ReadFile(stateFile)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"chain-a/chain-b"`)
+	r = newRelayer(t, r.config, chainA, chainB)
+	require.Equal(t, Sequences{Packets: 2, Acks: 2}, r.state.Get("chain-a", "chain-b"))
+
+	// failed broadcasts are retried at the next attempt
+	chainB.failures = 1
+	require.Error(t, r.RelayPath(path))
+	require.Equal(t, Sequences{Packets: 2, Acks: 2}, r.state.Get("chain-a", "chain-b"))
+
+	quit := make(chan struct{})
+	close(quit)
+	r.Run(quit)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(voucher, 30)}, chainB.balance(dest))
+	require.Equal(t, Sequences{Packets: 3, Acks: 3}, r.state.Get("chain-a", "chain-b"))
+	commitment, _ := chainA.QueryIBC(ibc.PacketCommitmentKey("chain-b", 2))
+	require.Nil(t, commitment)
+
+	// nothing is left to relay
+	txsA, txsB := chainA.txs, chainB.txs
+	require.NoError(t, r.RelayPath(path))
+	require.Equal(t, txsA, chainA.txs)
+	require.Equal(t, txsB, chainB.txs)
+}
+
+func TestRelayerTimeout(t *testing.T) {
+	r, chainA, chainB, _, cleanup := setupRelayer(t, 2)
+	defer cleanup()
+	path := Path{"chain-a", "chain-b"}
+
+	user := secp256k1.GenPrivKey()
+	src := sdk.AccAddress(user.PubKey().Address())
+	dest := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	chainA.block(func(ctx sdk.Context) {
+		_, _, err := chainA.bk.AddCoins(ctx, src, coins.Add(coins))
+		require.Nil(t, err)
+	})
+
+	// the first packet expires at the next block of chain B, the second one later
+	packet := ibc.NewIBCPacket(src, dest, coins, "chain-a", "chain-b", chainB.height+1, time.Time{})
+	require.True(t, chainA.deliver(user, []sdk.Msg{ibc.MsgIBCTransfer{IBCPacket: packet}}).IsOK())
+	packet = ibc.NewIBCPacket(src, dest, coins, "chain-a", "chain-b", 0, chainB.time.Add(time.Minute))
+	require.True(t, chainA.deliver(user, []sdk.Msg{ibc.MsgIBCTransfer{IBCPacket: packet}}).IsOK())
+
+	// the expired packet is timed out on chain A, the packets after it can no
+	// longer be received by chain B
+	require.NoError(t, r.RelayPath(path))
+	require.Equal(t, 1, chainA.txs)
+	require.Equal(t, 0, chainB.txs)
+	require.Equal(t, coins, chainA.balance(src))
+	commitment, _ := chainA.QueryIBC(ibc.PacketCommitmentKey("chain-b", 0))
+	require.Nil(t, commitment)
+	commitment, _ = chainA.QueryIBC(ibc.PacketCommitmentKey("chain-b", 1))
+	require.NotNil(t, commitment)
+	require.Equal(t, Sequences{}, r.state.Get("chain-a", "chain-b"))
+
+	require.NoError(t, r.RelayPath(path))
+	require.Equal(t, 1, chainA.txs)
+	require.Equal(t, 0, chainB.txs)
+
+	// the second packet is timed out once it expires on chain B
+	for chainB.time.Before(packet.TimeoutTime) {
+		chainB.block(func(sdk.Context) {})
+	}
+	require.NoError(t, r.RelayPath(path))
+	require.Equal(t, 2, chainA.txs)
+	require.Equal(t, coins.Add(coins), chainA.balance(src))
+	require.True(t, chainB.balance(dest).Empty())
+
+	// a single packet can be timed out by its sender
+	packet = ibc.NewIBCPacket(src, dest, coins, "chain-a", "chain-b", chainB.height+1, time.Time{})
+	require.True(t, chainA.deliver(user, []sdk.Msg{ibc.MsgIBCTransfer{IBCPacket: packet}}).IsOK())
+	_, err := TimeoutMsgs(chainA.app.Cdc, chainA, chainB, 1)
+	require.Error(t, err)
+	chainB.block(func(sdk.Context) {})
+	msgs, err := TimeoutMsgs(chainA.app.Cdc, chainA, chainB, 2)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.IsType(t, ibc.MsgUpdateClient{}, msgs[0])
+	require.IsType(t, ibc.MsgIBCTimeout{}, msgs[1])
+	require.NoError(t, chainA.Broadcast(msgs))
+	require.Equal(t, coins.Add(coins), chainA.balance(src))
+}
+
+func TestRelayerWithoutClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "relayer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	relayerKey := secp256k1.GenPrivKey()
+	chainA := newTestChain(t, "chain-a", relayerKey)
+	chainB := newTestChain(t, "chain-b", relayerKey)
+
+	config := DefaultConfig()
+	config.StateFile = filepath.Join(dir, "state.json")
+	config.Paths = []Path{{"chain-a", "chain-b"}}
+	r := newRelayer(t, config, chainA, chainB)
+
+	src := sdk.AccAddress(relayerKey.PubKey().Address())
+	chainA.block(func(ctx sdk.Context) {
+		_, _, err := chainA.bk.AddCoins(ctx, src, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)})
+		require.Nil(t, err)
+	})
+	packet := ibc.NewIBCPacket(src, src, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}, "chain-a", "chain-b", 100, time.Time{})
+	require.True(t, chainA.deliver(relayerKey, []sdk.Msg{ibc.MsgIBCTransfer{IBCPacket: packet}}).IsOK())
+
+	// the expired packets are timed out first, with the light client of chain B on chain A
+	err = r.RelayPath(config.Paths[0])
+	require.EqualError(t, err, "no light client of chain-b on chain-a")
+	chainA.trust(chainB)
+	err = r.RelayPath(config.Paths[0])
+	require.EqualError(t, err, "no light client of chain-a on chain-b")
+
+	_, err = NewRelayer(chainA.app.Cdc, config, []Chain{chainA}, r.state, log.NewNopLogger(), NopMetrics())
+	require.Error(t, err)
+}
+
+func TestBackoff(t *testing.T) {
+	now := time.Now()
+	b := &backoff{}
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		b.fail(now, time.Second, 5*time.Second)
+		require.Equal(t, delay, b.delay)
+		require.Equal(t, now.Add(delay), b.retryAt)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "relayer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "relayer.toml")
+	writeConfig := func(paths string) {
+		content := fmt.Sprintf(`
+state_file = "%s"
+poll_interval = "2s"
+
+[[chains]]
+chain_id = "chain-a"
+node = "tcp://localhost:26657"
+
+[[chains]]
+chain_id = "chain-b"
+node = "tcp://localhost:36657"
+
+%s
+`, filepath.Join(dir, "state.json"), paths)
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+	}
+
+	writeConfig(`
+[[paths]]
+chain_a = "chain-a"
+chain_b = "chain-b"
+`)
+	config, err := LoadConfig(file)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, config.PollInterval)
+	require.Equal(t, DefaultConfig().BatchSize, config.BatchSize)
+	require.Equal(t, []ChainConfig{{"chain-a", "tcp://localhost:26657"}, {"chain-b", "tcp://localhost:36657"}}, config.Chains)
+	require.Equal(t, []Path{{"chain-a", "chain-b"}}, config.Paths)
+
+	writeConfig(`
+[[paths]]
+chain_a = "chain-a"
+chain_b = "chain-c"
+`)
+	_, err = LoadConfig(file)
+	require.Error(t, err)
+
+	writeConfig("")
+	_, err = LoadConfig(file)
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package relayer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sequences are the progress of the relayer in one direction of a path
+type Sequences struct {
+	// next packet of the source chain to relay to the destination chain
+	Packets uint64 `json:"packets"`
+	// next acknowledgement of the destination chain to relay back to the source chain
+	Acks uint64 `json:"acks"`
+}
+
+// State is the progress of the relayer, persisted in a file so that a
+// restarted relayer resumes where it stopped
+type State struct {
+	mtx  sync.Mutex
+	file string
+
+	Sequences map[string]Sequences `json:"sequences"` // indexed by "src/dest"
+}
+
+// LoadState reads the state of the relayer from a file, a missing file is an empty state
+func LoadState(file string) (*State, error) {
+	state := &State{file: file, Sequences: make(map[string]Sequences)}
+
+	bz, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(bz, state); err != nil {
+		return nil, err
+	}
+	if state.Sequences == nil {
+		state.Sequences = make(map[string]Sequences)
+	}
+	return state, nil
+}
+
+func directionKey(srcChainID, destChainID string) string {
+	return srcChainID + "/" + destChainID
+}
+
+// Get returns the progress of the relayer from a chain to another
+func (s *State) Get(srcChainID, destChainID string) Sequences {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.Sequences[directionKey(srcChainID, destChainID)]
+}
+
+// Set records the progress of the relayer from a chain to another and saves the state
+func (s *State) Set(srcChainID, destChainID string, seqs Sequences) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.Sequences[directionKey(srcChainID, destChainID)] = seqs
+	return s.save()
+}
+
+// save writes the state to a temporary file then renames it, so that a crash
+// never leaves a partially written state
+func (s *State) save() error {
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.file), 0700); err != nil {
+		return err
+	}
+	tmp := s.file + ".tmp"
+	if err = ioutil.WriteFile(tmp, bz, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.file)
+}
@@ -1,7 +1,16 @@
 package ibc
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ColorPlatform/prism/crypto/merkle"
+	"github.com/ColorPlatform/prism/crypto/tmhash"
+	tmtypes "github.com/ColorPlatform/prism/types"
 
 	codec "github.com/ColorPlatform/color-sdk/codec"
 	sdk "github.com/ColorPlatform/color-sdk/types"
@@ -13,6 +22,63 @@ var (
 
 func init() {
 	msgCdc = codec.New()
+	codec.RegisterCrypto(msgCdc)
+}
+
+// DefaultPacketTimeout is the time after which a packet sent without an
+// explicit timeout is refused by the destination chain
+const DefaultPacketTimeout = time.Hour
+
+// ------------------------------
+// DenomTrace
+
+// VoucherPrefix starts the denominations of the vouchers of coins received from other chains
+const VoucherPrefix = "ibc"
+
+// DenomTrace is the origin of a voucher denomination: the chains its coins came
+// through, the latest first, and their denomination on the origin chain
+type DenomTrace struct {
+	Path      string `json:"path"`       // chain IDs separated by "/"
+	BaseDenom string `json:"base_denom"` // denomination on the origin chain
+}
+
+func NewDenomTrace(path, baseDenom string) DenomTrace {
+	return DenomTrace{
+		Path:      path,
+		BaseDenom: baseDenom,
+	}
+}
+
+// VoucherDenom returns the denomination of the vouchers of the trace. The
+// denominations cannot hold the trace itself, so they hash it.
+func (dt DenomTrace) VoucherDenom() string {
+	hash := tmhash.Sum([]byte(dt.Path + "/" + dt.BaseDenom))
+	return VoucherPrefix + hex.EncodeToString(hash)[:13]
+}
+
+// unwrap removes the chain the coins came through last from the trace and
+// returns the denomination of the coins on that chain
+func (dt DenomTrace) unwrap() (chainID, denom string) {
+	hops := strings.SplitN(dt.Path, "/", 2)
+	if len(hops) == 1 {
+		return hops[0], dt.BaseDenom
+	}
+	return hops[0], NewDenomTrace(hops[1], dt.BaseDenom).VoucherDenom()
+}
+
+// wrap adds the chain the coins come from to a trace
+func (dt DenomTrace) wrap(chainID string) DenomTrace {
+	if dt.Path == "" {
+		return NewDenomTrace(chainID, dt.BaseDenom)
+	}
+	return NewDenomTrace(chainID+"/"+dt.Path, dt.BaseDenom)
+}
+
+func (dt DenomTrace) String() string {
+	return fmt.Sprintf(`Denom Trace:
+  Voucher Denom:  %s
+  Path:           %s
+  Base Denom:     %s`, dt.VoucherDenom(), dt.Path, dt.BaseDenom)
 }
 
 // ------------------------------
@@ -22,22 +88,27 @@ func init() {
 // IBCPacket defines a piece of data that can be send between two separate
 // blockchains.
 type IBCPacket struct {
-	SrcAddr   sdk.AccAddress `json:"src_addr"`
-	DestAddr  sdk.AccAddress `json:"dest_addr"`
-	Coins     sdk.Coins      `json:"coins"`
-	SrcChain  string         `json:"src_chain"`
-	DestChain string         `json:"dest_chain"`
+	SrcAddr       sdk.AccAddress `json:"src_addr"`
+	DestAddr      sdk.AccAddress `json:"dest_addr"`
+	Coins         sdk.Coins      `json:"coins"`
+	SrcChain      string         `json:"src_chain"`
+	DestChain     string         `json:"dest_chain"`
+	TimeoutHeight int64          `json:"timeout_height"` // height of the destination chain from which the packet is refused, 0 for none
+	TimeoutTime   time.Time      `json:"timeout_time"`   // block time of the destination chain from which the packet is refused, zero for none
+	DenomTraces   []DenomTrace   `json:"denom_traces"`   // traces of the coins that are vouchers on the source chain
 }
 
 func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+	srcChain string, destChain string, timeoutHeight int64, timeoutTime time.Time) IBCPacket {
 
 	return IBCPacket{
-		SrcAddr:   srcAddr,
-		DestAddr:  destAddr,
-		Coins:     coins,
-		SrcChain:  srcChain,
-		DestChain: destChain,
+		SrcAddr:       srcAddr,
+		DestAddr:      destAddr,
+		Coins:         coins,
+		SrcChain:      srcChain,
+		DestChain:     destChain,
+		TimeoutHeight: timeoutHeight,
+		TimeoutTime:   timeoutTime,
 	}
 }
 
@@ -58,9 +129,82 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 	if !p.Coins.IsValid() {
 		return sdk.ErrInvalidCoins("")
 	}
+	for _, trace := range p.DenomTraces {
+		if trace.Path == "" || trace.BaseDenom == "" {
+			return ErrInvalidDenomTrace(DefaultCodespace, "denom trace path and base denom cannot be empty").TraceSDK("")
+		}
+		if p.Coins.AmountOf(trace.VoucherDenom()).IsZero() {
+			return ErrInvalidDenomTrace(DefaultCodespace,
+				fmt.Sprintf("denom trace %s/%s does not match any coin", trace.Path, trace.BaseDenom)).TraceSDK("")
+		}
+	}
+	if p.TimeoutHeight < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, "timeout height cannot be negative").TraceSDK("")
+	}
+	if p.TimeoutHeight == 0 && p.TimeoutTime.IsZero() {
+		return ErrInvalidTimeout(DefaultCodespace, "").TraceSDK("")
+	}
 	return nil
 }
 
+// denomTrace returns the trace of a coin of the packet, coins without trace are
+// native to the source chain
+func (p IBCPacket) denomTrace(denom string) DenomTrace {
+	for _, trace := range p.DenomTraces {
+		if trace.VoucherDenom() == denom {
+			return trace
+		}
+	}
+	return NewDenomTrace("", denom)
+}
+
+// splitCoins splits the coins of the packet between the coins escrowed by the
+// source chain and the vouchers going back to the destination chain they come from
+func (p IBCPacket) splitCoins() (escrowed, returned sdk.Coins) {
+	escrowed, returned = sdk.Coins{}, sdk.Coins{}
+	for _, coin := range p.Coins {
+		trace := p.denomTrace(coin.Denom)
+		if chainID, _ := trace.unwrap(); trace.Path != "" && chainID == p.DestChain {
+			returned = returned.Add(sdk.Coins{coin})
+		} else {
+			escrowed = escrowed.Add(sdk.Coins{coin})
+		}
+	}
+	return escrowed, returned
+}
+
+// TimedOut returns true if the destination chain must refuse the packet at the given height and block time
+func (p IBCPacket) TimedOut(height int64, blockTime time.Time) bool {
+	if p.TimeoutHeight > 0 && height >= p.TimeoutHeight {
+		return true
+	}
+	return !p.TimeoutTime.IsZero() && !blockTime.Before(p.TimeoutTime)
+}
+
+// CommitPacket returns the commitment to a packet stored by the source chain
+// until the packet is acknowledged or timed out
+func CommitPacket(p IBCPacket) []byte {
+	return tmhash.Sum(p.GetSignBytes())
+}
+
+// ------------------------------
+// IBCAcknowledgement
+
+// nolint - TODO rename to Acknowledgement as IBCAcknowledgement stutters (golint)
+// IBCAcknowledgement is written by the destination chain for every received
+// packet. The source chain refunds the sender of a packet that failed.
+type IBCAcknowledgement struct {
+	Success bool   `json:"success"`
+	Log     string `json:"log"`
+}
+
+func NewIBCAcknowledgement(success bool, log string) IBCAcknowledgement {
+	return IBCAcknowledgement{
+		Success: success,
+		Log:     log,
+	}
+}
+
 // ----------------------------------
 // MsgIBCTransfer
 
@@ -92,17 +236,27 @@ func (msg MsgIBCTransfer) ValidateBasic() sdk.Error {
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // MsgIBCReceive defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. Proof proves the packet commitment against the app
+// hash of the source chain header at ProofHeight.
 type MsgIBCReceive struct {
 	IBCPacket
-	Relayer  sdk.AccAddress
-	Sequence uint64
+	Relayer     sdk.AccAddress
+	Sequence    uint64
+	Proof       *merkle.Proof
+	ProofHeight int64
 }
 
 // nolint
-func (msg MsgIBCReceive) Route() string            { return "ibc" }
-func (msg MsgIBCReceive) Type() string             { return "receive" }
-func (msg MsgIBCReceive) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+func (msg MsgIBCReceive) Route() string { return "ibc" }
+func (msg MsgIBCReceive) Type() string  { return "receive" }
+
+// validate ibc receive message
+func (msg MsgIBCReceive) ValidateBasic() sdk.Error {
+	if err := validateProof(msg.Proof, msg.ProofHeight); err != nil {
+		return err
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg MsgIBCReceive) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
@@ -110,16 +264,231 @@ func (msg MsgIBCReceive) GetSigners() []sdk.AccAddress { return []sdk.AccAddress
 // get the sign bytes for ibc receive message
 func (msg MsgIBCReceive) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(struct {
-		IBCPacket json.RawMessage
-		Relayer   sdk.AccAddress
-		Sequence  uint64
+		IBCPacket   json.RawMessage
+		Relayer     sdk.AccAddress
+		Sequence    uint64
+		Proof       *merkle.Proof
+		ProofHeight int64
 	}{
-		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
-		Relayer:   msg.Relayer,
-		Sequence:  msg.Sequence,
+		IBCPacket:   json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:     msg.Relayer,
+		Sequence:    msg.Sequence,
+		Proof:       msg.Proof,
+		ProofHeight: msg.ProofHeight,
 	})
 	if err != nil {
 		panic(err)
 	}
 	return sdk.MustSortJSON(b)
 }
+
+// ----------------------------------
+// MsgIBCAck
+
+// nolint - TODO rename to AckMsg as folks will reference with ibc.AckMsg
+// MsgIBCAck defines the message that a relayer uses to post the acknowledgement
+// of a packet back to the source chain. Proof proves the acknowledgement against
+// the app hash of the destination chain header at ProofHeight.
+type MsgIBCAck struct {
+	IBCPacket
+	Acknowledgement IBCAcknowledgement
+	Relayer         sdk.AccAddress
+	Sequence        uint64
+	Proof           *merkle.Proof
+	ProofHeight     int64
+}
+
+// nolint
+func (msg MsgIBCAck) Route() string { return "ibc" }
+func (msg MsgIBCAck) Type() string  { return "acknowledge" }
+
+// validate ibc acknowledgement message
+func (msg MsgIBCAck) ValidateBasic() sdk.Error {
+	if err := validateProof(msg.Proof, msg.ProofHeight); err != nil {
+		return err
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg MsgIBCAck) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc acknowledgement message
+func (msg MsgIBCAck) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket       json.RawMessage
+		Acknowledgement IBCAcknowledgement
+		Relayer         sdk.AccAddress
+		Sequence        uint64
+		Proof           *merkle.Proof
+		ProofHeight     int64
+	}{
+		IBCPacket:       json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Acknowledgement: msg.Acknowledgement,
+		Relayer:         msg.Relayer,
+		Sequence:        msg.Sequence,
+		Proof:           msg.Proof,
+		ProofHeight:     msg.ProofHeight,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// ----------------------------------
+// MsgIBCTimeout
+
+// nolint - TODO rename to TimeoutMsg as folks will reference with ibc.TimeoutMsg
+// MsgIBCTimeout defines the message that a relayer uses to refund a packet the
+// destination chain did not receive before its timeout. Proof proves that
+// NextSequenceRecv is the ingress sequence of the destination chain against the
+// app hash of its header at ProofHeight.
+type MsgIBCTimeout struct {
+	IBCPacket
+	Relayer          sdk.AccAddress
+	Sequence         uint64
+	NextSequenceRecv uint64
+	Proof            *merkle.Proof
+	ProofHeight      int64
+}
+
+// nolint
+func (msg MsgIBCTimeout) Route() string { return "ibc" }
+func (msg MsgIBCTimeout) Type() string  { return "timeout" }
+
+// validate ibc timeout message
+func (msg MsgIBCTimeout) ValidateBasic() sdk.Error {
+	if err := validateProof(msg.Proof, msg.ProofHeight); err != nil {
+		return err
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg MsgIBCTimeout) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc timeout message
+func (msg MsgIBCTimeout) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket        json.RawMessage
+		Relayer          sdk.AccAddress
+		Sequence         uint64
+		NextSequenceRecv uint64
+		Proof            *merkle.Proof
+		ProofHeight      int64
+	}{
+		IBCPacket:        json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:          msg.Relayer,
+		Sequence:         msg.Sequence,
+		NextSequenceRecv: msg.NextSequenceRecv,
+		Proof:            msg.Proof,
+		ProofHeight:      msg.ProofHeight,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func validateProof(proof *merkle.Proof, proofHeight int64) sdk.Error {
+	if proof == nil || len(proof.Ops) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "missing proof").TraceSDK("")
+	}
+	if proofHeight <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof height must be positive").TraceSDK("")
+	}
+	return nil
+}
+
+// ------------------------------
+// ConsensusState
+
+// ConsensusState is the light client state of a counterparty chain at the
+// height of one of its headers
+type ConsensusState struct {
+	ChainID        string                `json:"chain_id"`
+	Height         int64                 `json:"height"`
+	Time           time.Time             `json:"time"`
+	AppHash        []byte                `json:"app_hash"`        // root of the state after the block before Height
+	NextValidators *tmtypes.ValidatorSet `json:"next_validators"` // validators trusted to sign the next headers
+}
+
+func NewConsensusState(chainID string, height int64, blockTime time.Time, appHash []byte,
+	nextValidators *tmtypes.ValidatorSet) ConsensusState {
+
+	return ConsensusState{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           blockTime,
+		AppHash:        appHash,
+		NextValidators: nextValidators,
+	}
+}
+
+// validate the consensus state
+func (cs ConsensusState) ValidateBasic() sdk.Error {
+	if cs.ChainID == "" {
+		return ErrInvalidClient(DefaultCodespace, "chain ID cannot be empty")
+	}
+	if cs.Height <= 0 {
+		return ErrInvalidClient(DefaultCodespace, "height must be positive")
+	}
+	if len(cs.AppHash) == 0 {
+		return ErrInvalidClient(DefaultCodespace, "app hash cannot be empty")
+	}
+	if cs.NextValidators == nil || cs.NextValidators.Size() == 0 {
+		return ErrInvalidClient(DefaultCodespace, "next validators cannot be empty")
+	}
+	return nil
+}
+
+// ----------------------------------
+// MsgUpdateClient
+
+// nolint - TODO rename to UpdateClientMsg as folks will reference with ibc.UpdateClientMsg
+// MsgUpdateClient defines the message that a relayer uses to post a header of
+// a counterparty chain. The header must be signed by the Validators set, of which
+// over 2/3 of the voting power is trusted by the latest consensus state.
+type MsgUpdateClient struct {
+	Header         tmtypes.SignedHeader
+	Validators     *tmtypes.ValidatorSet
+	NextValidators *tmtypes.ValidatorSet
+	Signer         sdk.AccAddress
+}
+
+// nolint
+func (msg MsgUpdateClient) Route() string { return "ibc" }
+func (msg MsgUpdateClient) Type() string  { return "update_client" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg MsgUpdateClient) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// get the sign bytes for ibc update client message
+func (msg MsgUpdateClient) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc update client message
+func (msg MsgUpdateClient) ValidateBasic() sdk.Error {
+	if msg.Header.Header == nil {
+		return ErrInvalidClient(DefaultCodespace, "missing header")
+	}
+	if err := msg.Header.ValidateBasic(msg.Header.ChainID); err != nil {
+		return ErrInvalidClient(DefaultCodespace, err.Error())
+	}
+	if msg.Validators == nil || !bytes.Equal(msg.Validators.Hash(), msg.Header.ValidatorsHash) {
+		return ErrInvalidClient(DefaultCodespace, "validators do not match the header")
+	}
+	if msg.NextValidators == nil || !bytes.Equal(msg.NextValidators.Hash(), msg.Header.NextValidatorsHash) {
+		return ErrInvalidClient(DefaultCodespace, "next validators do not match the header")
+	}
+	if msg.Signer.Empty() {
+		return sdk.ErrInvalidAddress("missing signer")
+	}
+	return nil
+}
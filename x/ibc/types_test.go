@@ -2,9 +2,13 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ColorPlatform/prism/crypto/merkle"
+	tmtypes "github.com/ColorPlatform/prism/types"
+
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
@@ -12,12 +16,19 @@ import (
 // IBCPacket Tests
 
 func TestIBCPacketValidation(t *testing.T) {
+	srcAddr := sdk.AccAddress([]byte("source"))
+	destAddr := sdk.AccAddress([]byte("destination"))
+	coins := sdk.Coins{sdk.NewInt64Coin("atom", 10)}
+
 	cases := []struct {
 		valid  bool
 		packet IBCPacket
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{true, NewIBCPacket(srcAddr, destAddr, coins, "source-chain", "dest-chain", 10, time.Time{})},
+		{false, NewIBCPacket(srcAddr, destAddr, coins, "source-chain", "dest-chain", 0, time.Time{})},
+		{false, NewIBCPacket(srcAddr, destAddr, coins, "source-chain", "dest-chain", -1, time.Now())},
 	}
 
 	for i, tc := range cases {
@@ -30,6 +41,42 @@ func TestIBCPacketValidation(t *testing.T) {
 	}
 }
 
+func TestIBCPacketDenomTraces(t *testing.T) {
+	srcAddr := sdk.AccAddress([]byte("source"))
+	destAddr := sdk.AccAddress([]byte("destination"))
+	trace := NewDenomTrace("source-chain/origin-chain", "atom")
+	coins := sdk.Coins{sdk.NewInt64Coin(trace.VoucherDenom(), 10)}
+
+	packet := NewIBCPacket(srcAddr, destAddr, coins, "source-chain", "dest-chain", 10, time.Time{})
+	packet.DenomTraces = []DenomTrace{trace}
+	require.Nil(t, packet.ValidateBasic())
+
+	packet.DenomTraces = []DenomTrace{NewDenomTrace("origin-chain", "atom")}
+	require.NotNil(t, packet.ValidateBasic())
+	packet.DenomTraces = []DenomTrace{NewDenomTrace("", "atom")}
+	require.NotNil(t, packet.ValidateBasic())
+
+	// vouchers unwrap to the denom of the chain they came through last
+	chainID, denom := trace.unwrap()
+	require.Equal(t, "source-chain", chainID)
+	require.Equal(t, NewDenomTrace("origin-chain", "atom").VoucherDenom(), denom)
+	chainID, denom = NewDenomTrace("origin-chain", "atom").unwrap()
+	require.Equal(t, "origin-chain", chainID)
+	require.Equal(t, "atom", denom)
+	require.Equal(t, trace, NewDenomTrace("origin-chain", "atom").wrap("source-chain"))
+
+	// voucher denoms are valid coin denoms
+	require.Len(t, trace.VoucherDenom(), 16)
+	require.True(t, coins.IsValid())
+
+	// vouchers sent back to the chain they came from are not escrowed
+	packet.DenomTraces = []DenomTrace{trace}
+	packet.DestChain = "source-chain"
+	escrowed, returned := packet.splitCoins()
+	require.True(t, escrowed.Empty())
+	require.Equal(t, coins, returned)
+}
+
 // -------------------------------
 // MsgIBCTransfer Tests
 
@@ -67,7 +114,7 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 
 func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := MsgIBCReceive{packet, sdk.AccAddress([]byte("relayer")), 0}
+	msg := MsgIBCReceive{packet, sdk.AccAddress([]byte("relayer")), 0, constructProof(), 1}
 
 	require.Equal(t, msg.Route(), "ibc")
 }
@@ -80,8 +127,91 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 		valid bool
 		msg   MsgIBCReceive
 	}{
-		{true, MsgIBCReceive{validPacket, sdk.AccAddress([]byte("relayer")), 0}},
-		{false, MsgIBCReceive{invalidPacket, sdk.AccAddress([]byte("relayer")), 0}},
+		{true, MsgIBCReceive{validPacket, sdk.AccAddress([]byte("relayer")), 0, constructProof(), 1}},
+		{false, MsgIBCReceive{invalidPacket, sdk.AccAddress([]byte("relayer")), 0, constructProof(), 1}},
+		{false, MsgIBCReceive{validPacket, sdk.AccAddress([]byte("relayer")), 0, nil, 1}},
+		{false, MsgIBCReceive{validPacket, sdk.AccAddress([]byte("relayer")), 0, constructProof(), 0}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+func TestIBCPacketTimedOut(t *testing.T) {
+	timeoutTime := time.Unix(1000, 0)
+	packet := constructIBCPacket(true)
+
+	packet.TimeoutHeight, packet.TimeoutTime = 10, time.Time{}
+	require.False(t, packet.TimedOut(9, timeoutTime))
+	require.True(t, packet.TimedOut(10, time.Time{}))
+
+	packet.TimeoutHeight, packet.TimeoutTime = 0, timeoutTime
+	require.False(t, packet.TimedOut(100, timeoutTime.Add(-time.Second)))
+	require.True(t, packet.TimedOut(1, timeoutTime))
+}
+
+// -------------------------------
+// MsgIBCAck Tests
+
+func TestIBCAckMsg(t *testing.T) {
+	packet := constructIBCPacket(true)
+	msg := MsgIBCAck{packet, NewIBCAcknowledgement(true, ""), sdk.AccAddress([]byte("relayer")), 0, constructProof(), 1}
+
+	require.Equal(t, msg.Route(), "ibc")
+	require.Equal(t, []sdk.AccAddress{sdk.AccAddress([]byte("relayer"))}, msg.GetSigners())
+	require.Nil(t, msg.ValidateBasic())
+
+	msg.IBCPacket = constructIBCPacket(false)
+	require.NotNil(t, msg.ValidateBasic())
+
+	msg.IBCPacket, msg.Proof = packet, nil
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// -------------------------------
+// MsgIBCTimeout Tests
+
+func TestIBCTimeoutMsg(t *testing.T) {
+	packet := constructIBCPacket(true)
+	msg := MsgIBCTimeout{packet, sdk.AccAddress([]byte("relayer")), 0, 0, constructProof(), 1}
+
+	require.Equal(t, msg.Route(), "ibc")
+	require.Equal(t, []sdk.AccAddress{sdk.AccAddress([]byte("relayer"))}, msg.GetSigners())
+	require.Nil(t, msg.ValidateBasic())
+
+	msg.IBCPacket = constructIBCPacket(false)
+	require.NotNil(t, msg.ValidateBasic())
+
+	msg.IBCPacket, msg.Proof = packet, nil
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+// -------------------------------
+// MsgUpdateClient Tests
+
+func TestUpdateClientMsgValidation(t *testing.T) {
+	pv := tmtypes.NewMockPV()
+	vals := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(pv.GetPubKey(), 10, 0, 0)})
+	otherVals := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(pv.GetPubKey(), 5, 0, 0)})
+	header := signHeader(t, "chain-b", 2, time.Now(), []byte("apphash"), vals, vals, pv)
+	relayer := sdk.AccAddress([]byte("relayer"))
+
+	cases := []struct {
+		valid bool
+		msg   MsgUpdateClient
+	}{
+		{true, MsgUpdateClient{header, vals, vals, relayer}},
+		{false, MsgUpdateClient{tmtypes.SignedHeader{}, vals, vals, relayer}},
+		{false, MsgUpdateClient{header, otherVals, vals, relayer}},
+		{false, MsgUpdateClient{header, vals, otherVals, relayer}},
+		{false, MsgUpdateClient{header, vals, nil, relayer}},
+		{false, MsgUpdateClient{header, vals, vals, nil}},
 	}
 
 	for i, tc := range cases {
@@ -97,6 +227,10 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 // -------------------------------
 // Helpers
 
+func constructProof() *merkle.Proof {
+	return &merkle.Proof{Ops: []merkle.ProofOp{{Type: "test", Key: []byte("key")}}}
+}
+
 func constructIBCPacket(valid bool) IBCPacket {
 	srcAddr := sdk.AccAddress([]byte("source"))
 	destAddr := sdk.AccAddress([]byte("destination"))
@@ -105,7 +239,7 @@ func constructIBCPacket(valid bool) IBCPacket {
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain, 100, time.Time{})
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain, 100, time.Time{})
 }
@@ -13,22 +13,27 @@ func BeginBlocker(ctx sdk.Context, k Keeper) {
 	minter := k.GetMinter(ctx)
 	params := k.GetParams(ctx)
 
-	updateWeeklySupply(params,&minter,ctx.BlockHeader().Time)
+	// a minter the genesis did not anchor starts minting with the first block
+	minter = minter.Anchor(params, ctx.BlockHeader().Time, ctx.BlockHeader().Time)
+
+	updateWeeklySupply(params, &minter, ctx.BlockHeader().Time)
 	k.SetMinter(ctx, minter)
 
 	// mint coins, add to collected fees, update supply
-	mintedCoin := minter.BlockProvision(params,ctx.BlockHeader().Time)
+	mintedCoin := minter.BlockProvision(params, ctx.BlockHeader().Time)
 	k.fck.AddCollectedFees(ctx, sdk.Coins{mintedCoin})
 	k.sk.InflateSupply(ctx, mintedCoin.Amount)
+	k.spk.Inflate(ctx, sdk.Coins{mintedCoin})
 
-	minter.BlockTime= ctx.BlockHeader().Time
-	k.SetMinter(ctx,minter)
+	minter.BlockTime = ctx.BlockHeader().Time
+	k.SetMinter(ctx, minter)
 }
 
 // function to check  block height and time and update timestamps if needed.
-func updateWeeklySupply(params Params,minter *Minter, currentTime time.Time) {
-	  if currentTime.After(minter.DeflationTime) {
-		minter.DeflationTime = minter.DeflationTime.Add(time.Second* deflationtime)
+func updateWeeklySupply(params Params, minter *Minter, currentTime time.Time) {
+	if currentTime.After(minter.DeflationTime) {
+		minter.DeflationTime = minter.DeflationTime.Add(params.DeflationInterval)
+		minter.Deflation = params.DeflationRate
 		minter.WeeklyProvisions, minter.MintingSpeed = minter.NewWeeklySupply(params)
-	 }
+	}
 }
@@ -0,0 +1,30 @@
+package mint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+func TestBeginBlockerProvisionCap(t *testing.T) {
+	input := newTestInput(t)
+	params := input.mintKeeper.GetParams(input.ctx)
+	genesisTime := input.ctx.BlockHeader().Time
+
+	// the first block anchors the minter and mints nothing
+	BeginBlocker(input.ctx, input.mintKeeper)
+	minter := input.mintKeeper.GetMinter(input.ctx)
+	require.True(t, genesisTime.Equal(minter.BlockTime))
+	require.True(t, genesisTime.Add(params.DeflationInterval).Equal(minter.DeflationTime))
+	require.True(t, input.fck.GetCollectedFees(input.ctx).IsZero())
+
+	// a block after a long halt only mints for MaxElapsedTime
+	ctx := input.ctx.WithBlockTime(genesisTime.Add(params.MaxElapsedTime * 10))
+	BeginBlocker(ctx, input.mintKeeper)
+	expProvisions := minter.BlockProvision(params, genesisTime.Add(params.MaxElapsedTime))
+	require.False(t, expProvisions.IsZero())
+	require.Equal(t, sdk.Coins{expProvisions}, input.fck.GetCollectedFees(ctx))
+	require.True(t, ctx.BlockHeader().Time.Equal(input.mintKeeper.GetMinter(ctx).BlockTime))
+}
@@ -8,8 +8,11 @@ import (
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	"github.com/ColorPlatform/color-sdk/x/mint"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+const flagPeriods = "periods"
+
 // GetCmdQueryParams implements a command to return the current minting
 // parameters.
 func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
@@ -113,3 +116,37 @@ func GetCmdQueryMintingSpeed(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdQuerySchedule implements a command to project the minting schedule.
+func GetCmdQuerySchedule(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Project the provisions of the next deflation periods",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := mint.NewQueryScheduleParams(uint64(viper.GetInt64(flagPeriods)))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", mint.QuerierRoute, mint.QuerySchedule)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var schedule mint.Schedule
+			if err := cdc.UnmarshalJSON(res, &schedule); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(schedule)
+		},
+	}
+
+	cmd.Flags().Uint64(flagPeriods, mint.DefaultSchedulePeriods, "number of deflation periods to project after the running one")
+	return cmd
+}
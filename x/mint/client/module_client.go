@@ -32,6 +32,7 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 			cli.GetCmdQueryInflation(mc.cdc),
 			cli.GetCmdQueryAnnualProvisions(mc.cdc),
 			cli.GetCmdQueryMintingSpeed(mc.cdc),
+			cli.GetCmdQuerySchedule(mc.cdc),
 		)...,
 	)
 
@@ -31,6 +31,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		"/minting/minting-speed",
 		queryMintingSpeedHandlerFn(cdc, cliCtx),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/minting/schedule",
+		queryScheduleHandlerFn(cdc, cliCtx),
+	).Methods("GET")
 }
 
 func queryParamsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
@@ -87,4 +92,32 @@ func queryMintingSpeedHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) htt
 
 		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
-}
\ No newline at end of file
+}
+
+func queryScheduleHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mint.NewQueryScheduleParams(mint.DefaultSchedulePeriods)
+		if periods := r.URL.Query().Get("periods"); periods != "" {
+			var ok bool
+			params.Periods, ok = rest.ParseUint64OrReturnBadRequest(w, periods)
+			if !ok {
+				return
+			}
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", mint.QuerierRoute, mint.QuerySchedule)
+		res, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -15,3 +15,8 @@ type StakingKeeper interface {
 type FeeCollectionKeeper interface {
 	AddCollectedFees(sdk.Context, sdk.Coins) sdk.Coins
 }
+
+// expected supply keeper
+type SupplyKeeper interface {
+	Inflate(ctx sdk.Context, amount sdk.Coins)
+}
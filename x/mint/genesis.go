@@ -1,13 +1,17 @@
 package mint
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
 // GenesisState - minter state
 type GenesisState struct {
-	Minter Minter `json:"minter"` // minter object
-	Params Params `json:"params"` // inflation params
+	Minter          Minter    `json:"minter"`           // minter object
+	Params          Params    `json:"params"`           // inflation params
+	DeflationAnchor time.Time `json:"deflation_anchor"` // start of the first deflation period, the genesis time if empty
 }
 
 // NewGenesisState creates a new GenesisState object
@@ -26,9 +30,15 @@ func DefaultGenesisState() GenesisState {
 	}
 }
 
-// new mint genesis
+// new mint genesis. The timestamps of a new minter are derived from the
+// genesis time so that every node starts from the same state.
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
-	keeper.SetMinter(ctx, data.Minter)
+	genesisTime := ctx.BlockHeader().Time
+	deflationAnchor := data.DeflationAnchor
+	if deflationAnchor.IsZero() {
+		deflationAnchor = genesisTime
+	}
+	keeper.SetMinter(ctx, data.Minter.Anchor(data.Params, deflationAnchor, genesisTime))
 	keeper.SetParams(ctx, data.Params)
 }
 
@@ -51,5 +61,9 @@ func ValidateGenesis(data GenesisState) error {
 	if err != nil {
 		return err
 	}
+	if !data.DeflationAnchor.IsZero() && !data.Minter.DeflationTime.IsZero() {
+		return fmt.Errorf("mint genesis sets both a deflation anchor and the deflation time %s of the minter",
+			data.Minter.DeflationTime)
+	}
 	return nil
 }
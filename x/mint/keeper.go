@@ -27,10 +27,11 @@ type Keeper struct {
 	paramSpace params.Subspace
 	sk         StakingKeeper
 	fck        FeeCollectionKeeper
+	spk        SupplyKeeper
 }
 
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey,
-	paramSpace params.Subspace, sk StakingKeeper, fck FeeCollectionKeeper) Keeper {
+	paramSpace params.Subspace, sk StakingKeeper, fck FeeCollectionKeeper, spk SupplyKeeper) Keeper {
 
 	keeper := Keeper{
 		storeKey:   key,
@@ -38,6 +39,7 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey,
 		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
 		sk:         sk,
 		fck:        fck,
+		spk:        spk,
 	}
 	return keeper
 }
@@ -2,17 +2,12 @@ package mint
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
-const (
-	weeklyProvision int64 = 362880000000
-	mintingSpeed    int64 = 600000
-	deflationtime         = 60 * 60 * 24 * 7 * 52
-)
-
 // Minter represents the minting state.
 type Minter struct {
 	Deflation        sdk.Dec   `json:"deflation"`         // current annual inflation rate
@@ -35,23 +30,36 @@ func NewMinter(deflation, weeklyProvisions sdk.Dec, mintingspeed sdk.Dec,
 	}
 }
 
-// InitialMinter returns an initial Minter object with a given inflation value.
-func InitialMinter(deflation sdk.Dec) Minter {
+// InitialMinter returns an initial Minter object following the minting
+// schedule of the given params. Its timestamps are left empty so that every
+// node derives them from the genesis time, see Anchor.
+func InitialMinter(params Params) Minter {
 	return NewMinter(
-		deflation,
-		sdk.NewDec(weeklyProvision), //initial value of weekly provision
-		sdk.NewDec(mintingSpeed),    //initial minting speed
-		time.Now().UTC().Add(time.Second*deflationtime), // first deflation time stamp
-		time.Now().UTC(), //current time
+		params.DeflationRate,
+		params.InitialWeeklyProvisions,
+		params.InitialMintingSpeed,
+		time.Time{},
+		time.Time{},
 	)
 }
 
+// Anchor sets the timestamps the minter was created without. Minting starts at
+// blockTime and the first deflation happens one deflation interval after
+// deflationAnchor.
+func (m Minter) Anchor(params Params, deflationAnchor, blockTime time.Time) Minter {
+	if m.BlockTime.IsZero() {
+		m.BlockTime = blockTime
+	}
+	if m.DeflationTime.IsZero() {
+		m.DeflationTime = deflationAnchor.Add(params.DeflationInterval)
+	}
+	return m
+}
+
 // DefaultInitialMinter returns a default initial Minter object for a new chain
-// which uses an inflation rate of 13%.
+// which deflates by 3% every 52 weeks.
 func DefaultInitialMinter() Minter {
-	return InitialMinter(
-		sdk.NewDecWithPrec(3, 2),
-	)
+	return InitialMinter(DefaultParams())
 }
 
 func validateMinter(minter Minter) error {
@@ -59,22 +67,82 @@ func validateMinter(minter Minter) error {
 		return fmt.Errorf("mint parameter Deflation should be positive, is %s",
 			minter.Deflation.String())
 	}
+	if minter.WeeklyProvisions.IsNegative() {
+		return fmt.Errorf("minter WeeklyProvisions should be positive, is %s",
+			minter.WeeklyProvisions.String())
+	}
+	if minter.MintingSpeed.IsNegative() {
+		return fmt.Errorf("minter MintingSpeed should be positive, is %s",
+			minter.MintingSpeed.String())
+	}
 	return nil
 }
 
-// NewWeeklySupply reduces the amount of weekly supply by 5%
+// NewWeeklySupply reduces the weekly provisions and the minting speed by the
+// deflation rate of the params
 func (m Minter) NewWeeklySupply(params Params) (sdk.Dec, sdk.Dec) {
-	provisionAmt := m.WeeklyProvisions.QuoInt(sdk.NewInt(int64(params.BlocksPerWeek)))
+	remaining := sdk.OneDec().Sub(params.DeflationRate)
+	return m.WeeklyProvisions.Mul(remaining), m.MintingSpeed.Mul(remaining)
+}
 
-	return m.WeeklyProvisions.Sub(m.Deflation.Mul(m.WeeklyProvisions)),
-		provisionAmt
+// DeflationPeriod is the projected minting between two deflations
+type DeflationPeriod struct {
+	Period           uint64    `json:"period"`            // 0 for the running period
+	StartTime        time.Time `json:"start_time"`        // time of the deflation starting the period
+	EndTime          time.Time `json:"end_time"`          // time of the deflation ending the period
+	WeeklyProvisions sdk.Dec   `json:"weekly_provisions"` // weekly provisions during the period
+	MintingSpeed     sdk.Dec   `json:"minting_speed"`     // coins minted per second during the period
+	PeriodProvisions sdk.Dec   `json:"period_provisions"` // coins minted over the whole period
 }
 
-// BlockProvision returns the provisions for a block based on the annual
-// provisions rate.
+// Schedule is the projected provision curve of the next deflation periods
+type Schedule []DeflationPeriod
+
+// nolint
+func (s Schedule) String() string {
+	out := "Period - [StartTime] [EndTime] - WeeklyProvisions - MintingSpeed - PeriodProvisions\n"
+	for _, p := range s {
+		out += fmt.Sprintf("%d - [%s] [%s] - %s - %s - %s\n",
+			p.Period, p.StartTime, p.EndTime, p.WeeklyProvisions, p.MintingSpeed, p.PeriodProvisions)
+	}
+	return strings.TrimSpace(out)
+}
+
+// ProjectSchedule projects the running deflation period and the given number
+// of following periods, deflating the minter the same way BeginBlocker does
+func (m Minter) ProjectSchedule(params Params, periods uint64) Schedule {
+	schedule := make(Schedule, 0, periods+1)
+	endTime := m.DeflationTime
+	startTime := endTime.Add(-params.DeflationInterval)
+	for period := uint64(0); period <= periods; period++ {
+		seconds := sdk.NewDec(int64(endTime.Sub(startTime) / time.Second))
+		schedule = append(schedule, DeflationPeriod{
+			Period:           period,
+			StartTime:        startTime,
+			EndTime:          endTime,
+			WeeklyProvisions: m.WeeklyProvisions,
+			MintingSpeed:     m.MintingSpeed,
+			PeriodProvisions: m.MintingSpeed.Mul(seconds),
+		})
+		m.WeeklyProvisions, m.MintingSpeed = m.NewWeeklySupply(params)
+		startTime, endTime = endTime, endTime.Add(params.DeflationInterval)
+	}
+	return schedule
+}
+
+// BlockProvision returns the provisions minted at the minting speed since the
+// previous block. The elapsed time is capped by MaxElapsedTime so a chain
+// restarting after a halt does not mint for the whole halt.
 func (m Minter) BlockProvision(params Params, newtime time.Time) sdk.Coin {
+	elapsed := newtime.Sub(m.BlockTime)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if elapsed > params.MaxElapsedTime {
+		elapsed = params.MaxElapsedTime
+	}
 
-	blocktimediff := sdk.NewDec(int64((newtime.Sub(m.BlockTime).Nanoseconds())))
+	blocktimediff := sdk.NewDec(elapsed.Nanoseconds())
 	newCoins := (blocktimediff.Mul(m.MintingSpeed)).QuoInt(sdk.NewInt(1000000000))
 
 	return sdk.NewCoin(params.MintDenom, newCoins.TruncateInt())
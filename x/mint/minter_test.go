@@ -3,75 +3,61 @@ package mint
 import (
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
-func TestNextInflation(t *testing.T) {
+func TestNewWeeklySupply(t *testing.T) {
 	minter := DefaultInitialMinter()
 	params := DefaultParams()
-	blocksPerYr := sdk.NewDec(int64(params.BlocksPerYear))
-
-	// Governing Mechanism:
-	//    inflationRateChangePerYear = (1- BondedRatio/ GoalBonded) * MaxInflationRateChange
 
 	tests := []struct {
-		bondedRatio, setInflation, expChange sdk.Dec
+		deflationRate, weeklyProvisions, mintingSpeed sdk.Dec
+		expWeeklyProvisions, expMintingSpeed          sdk.Dec
 	}{
-		// with 0% bonded atom supply the inflation should increase by InflationRateChange
-		{sdk.ZeroDec(), sdk.NewDecWithPrec(7, 2), params.InflationRateChange.Quo(blocksPerYr)},
-
-		// 100% bonded, starting at 20% inflation and being reduced
-		// (1 - (1/0.67))*(0.13/8667)
-		{sdk.OneDec(), sdk.NewDecWithPrec(20, 2),
-			sdk.OneDec().Sub(sdk.OneDec().Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(blocksPerYr)},
-
-		// 50% bonded, starting at 10% inflation and being increased
-		{sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(10, 2),
-			sdk.OneDec().Sub(sdk.NewDecWithPrec(5, 1).Quo(params.GoalBonded)).Mul(params.InflationRateChange).Quo(blocksPerYr)},
-
-		// test 7% minimum stop (testing with 100% bonded)
-		{sdk.OneDec(), sdk.NewDecWithPrec(7, 2), sdk.ZeroDec()},
-		{sdk.OneDec(), sdk.NewDecWithPrec(700000001, 10), sdk.NewDecWithPrec(-1, 10)},
+		// no deflation keeps the supply
+		{sdk.ZeroDec(), sdk.NewDec(1000), sdk.NewDec(10), sdk.NewDec(1000), sdk.NewDec(10)},
 
-		// test 20% maximum stop (testing with 0% bonded)
-		{sdk.ZeroDec(), sdk.NewDecWithPrec(20, 2), sdk.ZeroDec()},
-		{sdk.ZeroDec(), sdk.NewDecWithPrec(1999999999, 10), sdk.NewDecWithPrec(1, 10)},
+		// 3% deflation
+		{sdk.NewDecWithPrec(3, 2), sdk.NewDec(1000), sdk.NewDec(10), sdk.NewDec(970), sdk.NewDecWithPrec(97, 1)},
 
-		// perfect balance shouldn't change inflation
-		{sdk.NewDecWithPrec(67, 2), sdk.NewDecWithPrec(15, 2), sdk.ZeroDec()},
+		// 100% deflation stops minting
+		{sdk.OneDec(), sdk.NewDec(1000), sdk.NewDec(10), sdk.ZeroDec(), sdk.ZeroDec()},
 	}
 	for i, tc := range tests {
-		minter.Inflation = tc.setInflation
-
-		inflation := minter.NextInflationRate(params, tc.bondedRatio)
-		diffInflation := inflation.Sub(tc.setInflation)
-
-		require.True(t, diffInflation.Equal(tc.expChange),
-			"Test Index: %v\nDiff:  %v\nExpected: %v\n", i, diffInflation, tc.expChange)
+		params.DeflationRate = tc.deflationRate
+		minter.WeeklyProvisions = tc.weeklyProvisions
+		minter.MintingSpeed = tc.mintingSpeed
+
+		weeklyProvisions, mintingSpeed := minter.NewWeeklySupply(params)
+		require.True(t, weeklyProvisions.Equal(tc.expWeeklyProvisions),
+			"Test Index: %v\nGot: %v\nExpected: %v\n", i, weeklyProvisions, tc.expWeeklyProvisions)
+		require.True(t, mintingSpeed.Equal(tc.expMintingSpeed),
+			"Test Index: %v\nGot: %v\nExpected: %v\n", i, mintingSpeed, tc.expMintingSpeed)
 	}
 }
 
 func TestBlockProvision(t *testing.T) {
-	minter := InitialMinter(sdk.NewDecWithPrec(1, 1))
 	params := DefaultParams()
-
-	secondsPerYear := int64(60 * 60 * 8766)
+	blockTime := time.Unix(1000, 0).UTC()
+	minter := NewMinter(params.DeflationRate, sdk.NewDec(1000), sdk.ZeroDec(), blockTime.Add(time.Hour), blockTime)
 
 	tests := []struct {
-		annualProvisions int64
-		expProvisions    int64
+		mintingSpeed  sdk.Dec
+		elapsed       time.Duration
+		expProvisions int64
 	}{
-		{secondsPerYear / 5, 1},
-		{secondsPerYear/5 + 1, 1},
-		{(secondsPerYear / 5) * 2, 2},
-		{(secondsPerYear / 5) / 2, 0},
+		{sdk.NewDec(10), time.Second, 10},
+		{sdk.NewDecWithPrec(1, 1), time.Second * 5, 0},
+		{sdk.NewDecWithPrec(1, 1), time.Second * 10, 1},
+		{sdk.NewDecWithPrec(2, 1), time.Millisecond * 7500, 1},
 	}
 	for i, tc := range tests {
-		minter.AnnualProvisions = sdk.NewDec(tc.annualProvisions)
-		provisions := minter.BlockProvision(params)
+		minter.MintingSpeed = tc.mintingSpeed
+		provisions := minter.BlockProvision(params, blockTime.Add(tc.elapsed))
 
 		expProvisions := sdk.NewCoin(params.MintDenom,
 			sdk.NewInt(tc.expProvisions))
@@ -83,49 +69,121 @@ func TestBlockProvision(t *testing.T) {
 }
 
 // Benchmarking :)
-// previously using sdk.Int operations:
-// BenchmarkBlockProvision-4 5000000 220 ns/op
-//
-// using sdk.Dec operations: (current implementation)
-// BenchmarkBlockProvision-4 3000000 429 ns/op
 func BenchmarkBlockProvision(b *testing.B) {
-	minter := InitialMinter(sdk.NewDecWithPrec(1, 1))
 	params := DefaultParams()
+	blockTime := time.Unix(1000, 0).UTC()
+	minter := DefaultInitialMinter().Anchor(params, blockTime, blockTime)
 
 	s1 := rand.NewSource(100)
 	r1 := rand.New(s1)
-	minter.AnnualProvisions = sdk.NewDec(r1.Int63n(1000000))
+	minter.MintingSpeed = sdk.NewDec(r1.Int63n(1000000))
 
 	// run the BlockProvision function b.N times
 	for n := 0; n < b.N; n++ {
-		minter.BlockProvision(params)
+		minter.BlockProvision(params, blockTime.Add(time.Second*5))
 	}
 }
 
-// Next inflation benchmarking
-// BenchmarkNextInflation-4 1000000 1828 ns/op
-func BenchmarkNextInflation(b *testing.B) {
-	minter := InitialMinter(sdk.NewDecWithPrec(1, 1))
+// Next weekly supply benchmarking
+func BenchmarkNewWeeklySupply(b *testing.B) {
+	minter := DefaultInitialMinter()
 	params := DefaultParams()
-	bondedRatio := sdk.NewDecWithPrec(1, 1)
 
-	// run the NextInflationRate function b.N times
+	// run the NewWeeklySupply function b.N times
 	for n := 0; n < b.N; n++ {
-		minter.NextInflationRate(params, bondedRatio)
+		minter.NewWeeklySupply(params)
 	}
+}
 
+func TestProjectSchedule(t *testing.T) {
+	params := DefaultParams()
+	params.DeflationRate = sdk.NewDecWithPrec(10, 2)
+	params.DeflationInterval = time.Hour
+	deflationTime := time.Unix(7200, 0).UTC()
+	minter := NewMinter(params.DeflationRate, sdk.NewDec(1000), sdk.NewDec(10), deflationTime, time.Unix(3600, 0).UTC())
+
+	schedule := minter.ProjectSchedule(params, 2)
+	require.Equal(t, 3, len(schedule))
+	require.Equal(t, time.Unix(3600, 0).UTC(), schedule[0].StartTime)
+	require.Equal(t, deflationTime, schedule[0].EndTime)
+	require.Equal(t, deflationTime, schedule[1].StartTime)
+	require.True(t, sdk.NewDec(36000).Equal(schedule[0].PeriodProvisions))
+	require.True(t, sdk.NewDec(900).Equal(schedule[1].WeeklyProvisions))
+	require.True(t, sdk.NewDec(9).Equal(schedule[1].MintingSpeed))
+	require.True(t, sdk.NewDecWithPrec(81, 1).Equal(schedule[2].MintingSpeed))
+
+	// the projection matches the deflations applied by BeginBlocker
+	for _, period := range schedule[1:] {
+		updateWeeklySupply(params, &minter, period.StartTime.Add(time.Second))
+		require.Equal(t, period.EndTime, minter.DeflationTime)
+		require.True(t, period.WeeklyProvisions.Equal(minter.WeeklyProvisions))
+		require.True(t, period.MintingSpeed.Equal(minter.MintingSpeed))
+	}
 }
 
-// Next annual provisions benchmarking
-// BenchmarkNextAnnualProvisions-4 5000000 251 ns/op
-func BenchmarkNextAnnualProvisions(b *testing.B) {
-	minter := InitialMinter(sdk.NewDecWithPrec(1, 1))
+func TestValidateParams(t *testing.T) {
+	require.NoError(t, validateParams(DefaultParams()))
+
 	params := DefaultParams()
-	totalSupply := sdk.NewInt(100000000000000)
+	params.DeflationRate = sdk.NewDecWithPrec(11, 1)
+	require.Error(t, validateParams(params))
 
-	// run the NextAnnualProvisions function b.N times
-	for n := 0; n < b.N; n++ {
-		minter.NextAnnualProvisions(params, totalSupply)
+	params = DefaultParams()
+	params.DeflationInterval = 0
+	require.Error(t, validateParams(params))
+
+	params = DefaultParams()
+	params.InitialMintingSpeed = sdk.NewDec(-1)
+	require.Error(t, validateParams(params))
+
+	params = DefaultParams()
+	params.BlocksPerWeek = 0
+	require.Error(t, params.Validate())
+}
+
+func TestBlockProvisionCap(t *testing.T) {
+	params := DefaultParams()
+	params.MaxElapsedTime = time.Minute
+	blockTime := time.Unix(1000, 0).UTC()
+	minter := NewMinter(params.DeflationRate, sdk.NewDec(1000), sdk.NewDec(10), blockTime.Add(time.Hour), blockTime)
+
+	tests := []struct {
+		elapsed       time.Duration
+		expProvisions int64
+	}{
+		{time.Second * 5, 50},
+		{time.Minute, 600},
+		{time.Hour * 24, 600},
+		{-time.Second, 0},
 	}
+	for i, tc := range tests {
+		provisions := minter.BlockProvision(params, blockTime.Add(tc.elapsed))
+		require.True(t, sdk.NewCoin(params.MintDenom, sdk.NewInt(tc.expProvisions)).IsEqual(provisions),
+			"test: %v\n\tExp: %v\n\tGot: %v\n", i, tc.expProvisions, provisions)
+	}
+}
 
+func TestMinterAnchor(t *testing.T) {
+	params := DefaultParams()
+	genesisTime := time.Unix(1000, 0).UTC()
+	anchor := time.Unix(500, 0).UTC()
+
+	// the initial minter does not depend on the time it is created at
+	minter := DefaultInitialMinter()
+	require.Equal(t, minter, DefaultInitialMinter())
+	require.True(t, minter.BlockTime.IsZero())
+
+	anchored := minter.Anchor(params, anchor, genesisTime)
+	require.Equal(t, genesisTime, anchored.BlockTime)
+	require.Equal(t, anchor.Add(params.DeflationInterval), anchored.DeflationTime)
+
+	// timestamps of a started minter are kept
+	require.Equal(t, anchored, anchored.Anchor(params, genesisTime, genesisTime.Add(time.Hour)))
+
+	genState := DefaultGenesisState()
+	require.NoError(t, ValidateGenesis(genState))
+	genState.DeflationAnchor = anchor
+	require.NoError(t, ValidateGenesis(genState))
+	genState.Minter = anchored
+	require.Error(t, ValidateGenesis(genState))
 }
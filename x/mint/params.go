@@ -2,29 +2,46 @@ package mint
 
 import (
 	"fmt"
+	"time"
 
 	sdk "github.com/ColorPlatform/color-sdk/types"
 )
 
 // mint parameters
 type Params struct {
-	MintDenom     string `json:"mint_denom"`      // type of coin to mint
-	BlocksPerWeek uint64 `json:"blocks_per_week"` // expected blocks per week
+	MintDenom               string        `json:"mint_denom"`                // type of coin to mint
+	BlocksPerWeek           uint64        `json:"blocks_per_week"`           // expected blocks per week
+	InitialWeeklyProvisions sdk.Dec       `json:"initial_weekly_provisions"` // weekly provisions of the first deflation period
+	InitialMintingSpeed     sdk.Dec       `json:"initial_minting_speed"`     // coins minted per second in the first deflation period
+	DeflationRate           sdk.Dec       `json:"deflation_rate"`            // share of the provisions removed at each deflation
+	DeflationInterval       time.Duration `json:"deflation_interval"`        // time between two deflations
+	MaxElapsedTime          time.Duration `json:"max_elapsed_time"`          // longest time a single block mints for, bounds the mint after a halt
 }
 
-func NewParams(mintDenom string,blocksPerWeek uint64) Params {
+func NewParams(mintDenom string, blocksPerWeek uint64, initialWeeklyProvisions, initialMintingSpeed,
+	deflationRate sdk.Dec, deflationInterval, maxElapsedTime time.Duration) Params {
 
 	return Params{
-		MintDenom:     mintDenom,
-		BlocksPerWeek: blocksPerWeek,
+		MintDenom:               mintDenom,
+		BlocksPerWeek:           blocksPerWeek,
+		InitialWeeklyProvisions: initialWeeklyProvisions,
+		InitialMintingSpeed:     initialMintingSpeed,
+		DeflationRate:           deflationRate,
+		DeflationInterval:       deflationInterval,
+		MaxElapsedTime:          maxElapsedTime,
 	}
 }
 
 // default minting module parameters
 func DefaultParams() Params {
 	return Params{
-		MintDenom:     sdk.DefaultBondDenom,
-		BlocksPerWeek: uint64(60 * 60 * 24 * 7), // assuming 1 second block time
+		MintDenom:               sdk.DefaultBondDenom,
+		BlocksPerWeek:           uint64(60 * 60 * 24 * 7), // assuming 1 second block time
+		InitialWeeklyProvisions: sdk.NewDec(362880000000),
+		InitialMintingSpeed:     sdk.NewDec(600000),
+		DeflationRate:           sdk.NewDecWithPrec(3, 2),
+		DeflationInterval:       time.Hour * 24 * 7 * 52,
+		MaxElapsedTime:          time.Minute * 5,
 	}
 }
 
@@ -33,14 +50,48 @@ func validateParams(params Params) error {
 	if params.MintDenom == "" {
 		return fmt.Errorf("mint parameter MintDenom can't be an empty string")
 	}
+	if params.BlocksPerWeek == 0 {
+		return fmt.Errorf("mint parameter BlocksPerWeek must be positive")
+	}
+	if params.InitialWeeklyProvisions.IsNil() || params.InitialWeeklyProvisions.IsNegative() {
+		return fmt.Errorf("mint parameter InitialWeeklyProvisions should be positive, is %s",
+			params.InitialWeeklyProvisions)
+	}
+	if params.InitialMintingSpeed.IsNil() || params.InitialMintingSpeed.IsNegative() {
+		return fmt.Errorf("mint parameter InitialMintingSpeed should be positive, is %s",
+			params.InitialMintingSpeed)
+	}
+	if params.DeflationRate.IsNil() || params.DeflationRate.IsNegative() || params.DeflationRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("mint parameter DeflationRate should be between 0 and 1, is %s",
+			params.DeflationRate)
+	}
+	if params.DeflationInterval <= 0 {
+		return fmt.Errorf("mint parameter DeflationInterval must be positive, is %s",
+			params.DeflationInterval)
+	}
+	if params.MaxElapsedTime <= 0 {
+		return fmt.Errorf("mint parameter MaxElapsedTime must be positive, is %s",
+			params.MaxElapsedTime)
+	}
 	return nil
 }
 
+// Validate checks the params, including those changed by governance proposals
+func (p Params) Validate() error {
+	return validateParams(p)
+}
+
 func (p Params) String() string {
 	return fmt.Sprintf(`Minting Params:
-  Mint Denom:             %s
-  Blocks Per Year:        %d
+  Mint Denom:                 %s
+  Blocks Per Week:            %d
+  Initial Weekly Provisions:  %s
+  Initial Minting Speed:      %s
+  Deflation Rate:             %s
+  Deflation Interval:         %s
+  Max Elapsed Time:           %s
   `,
-		p.MintDenom, p.BlocksPerWeek,
+		p.MintDenom, p.BlocksPerWeek, p.InitialWeeklyProvisions,
+		p.InitialMintingSpeed, p.DeflationRate, p.DeflationInterval, p.MaxElapsedTime,
 	)
 }
@@ -13,12 +13,30 @@ const (
 	QueryParameters       = "parameters"
 	QueryInflation        = "deflation"
 	QueryWeeklyProvisions = "weekly_provisions"
-	QueryMintingSpeed = "minting_speed"
+	QueryMintingSpeed     = "minting_speed"
+	QuerySchedule         = "schedule"
+
+	// DefaultSchedulePeriods is the number of deflation periods projected when none is requested
+	DefaultSchedulePeriods = 10
+	// MaxSchedulePeriods is the maximum number of deflation periods projected by a query
+	MaxSchedulePeriods = 100
 )
 
+// QueryScheduleParams are the params of the schedule query
+type QueryScheduleParams struct {
+	Periods uint64 // number of deflation periods to project after the running one
+}
+
+// NewQueryScheduleParams creates a new instance of QueryScheduleParams
+func NewQueryScheduleParams(periods uint64) QueryScheduleParams {
+	return QueryScheduleParams{
+		Periods: periods,
+	}
+}
+
 // NewQuerier returns a minting Querier handler.
 func NewQuerier(k Keeper) sdk.Querier {
-	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, sdk.Error) {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
 		switch path[0] {
 		case QueryParameters:
 			return queryParams(ctx, k)
@@ -30,7 +48,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryWeeklyProvisions(ctx, k)
 		
 		case QueryMintingSpeed:
-			return queryMintingSpeed(ctx,k)
+			return queryMintingSpeed(ctx, k)
+
+		case QuerySchedule:
+			return querySchedule(ctx, req, k)
 
 		default:
 			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown minting query endpoint: %s", path[0]))
@@ -71,7 +92,7 @@ func queryWeeklyProvisions(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
 	return res, nil
 }
 
-func queryMintingSpeed(ctx sdk.Context, k Keeper) ([]byte, sdk.Error){
+func queryMintingSpeed(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
 	minter := k.GetMinter(ctx)
 
 	res, err := codec.MarshalJSONIndent(k.cdc, minter.MintingSpeed)
@@ -81,3 +102,24 @@ func queryMintingSpeed(ctx sdk.Context, k Keeper) ([]byte, sdk.Error){
 
 	return res, nil
 }
+
+func querySchedule(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	params := NewQueryScheduleParams(DefaultSchedulePeriods)
+	if len(req.Data) != 0 {
+		if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+	}
+	if params.Periods > MaxSchedulePeriods {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("cannot project more than %d deflation periods", MaxSchedulePeriods))
+	}
+
+	schedule := k.GetMinter(ctx).ProjectSchedule(k.GetParams(ctx), params.Periods)
+
+	res, err := codec.MarshalJSONIndent(k.cdc, schedule)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
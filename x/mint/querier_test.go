@@ -24,7 +24,13 @@ func TestNewQuerier(t *testing.T) {
 	_, err = querier(input.ctx, []string{QueryInflation}, query)
 	require.NoError(t, err)
 
-	_, err = querier(input.ctx, []string{QueryAnnualProvisions}, query)
+	_, err = querier(input.ctx, []string{QueryWeeklyProvisions}, query)
+	require.NoError(t, err)
+
+	_, err = querier(input.ctx, []string{QueryMintingSpeed}, query)
+	require.NoError(t, err)
+
+	_, err = querier(input.ctx, []string{QuerySchedule}, query)
 	require.NoError(t, err)
 
 	_, err = querier(input.ctx, []string{"foo"}, query)
@@ -56,19 +62,33 @@ func TestQueryInflation(t *testing.T) {
 	err := input.cdc.UnmarshalJSON(res, &inflation)
 	require.NoError(t, err)
 
-	require.Equal(t, input.mintKeeper.GetMinter(input.ctx).Inflation, inflation)
+	require.Equal(t, input.mintKeeper.GetMinter(input.ctx).Deflation, inflation)
+}
+
+func TestQueryWeeklyProvisions(t *testing.T) {
+	input := newTestInput(t)
+
+	var weeklyProvisions sdk.Dec
+
+	res, sdkErr := queryWeeklyProvisions(input.ctx, input.mintKeeper)
+	require.NoError(t, sdkErr)
+
+	err := input.cdc.UnmarshalJSON(res, &weeklyProvisions)
+	require.NoError(t, err)
+
+	require.Equal(t, input.mintKeeper.GetMinter(input.ctx).WeeklyProvisions, weeklyProvisions)
 }
 
-func TestQueryAnnualProvisions(t *testing.T) {
+func TestQueryMintingSpeed(t *testing.T) {
 	input := newTestInput(t)
 
-	var annualProvisions sdk.Dec
+	var mintingSpeed sdk.Dec
 
-	res, sdkErr := queryAnnualProvisions(input.ctx, input.mintKeeper)
+	res, sdkErr := queryMintingSpeed(input.ctx, input.mintKeeper)
 	require.NoError(t, sdkErr)
 
-	err := input.cdc.UnmarshalJSON(res, &annualProvisions)
+	err := input.cdc.UnmarshalJSON(res, &mintingSpeed)
 	require.NoError(t, err)
 
-	require.Equal(t, input.mintKeeper.GetMinter(input.ctx).AnnualProvisions, annualProvisions)
+	require.Equal(t, input.mintKeeper.GetMinter(input.ctx).MintingSpeed, mintingSpeed)
 }
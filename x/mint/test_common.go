@@ -15,8 +15,10 @@ import (
 	sdk "github.com/ColorPlatform/color-sdk/types"
 	"github.com/ColorPlatform/color-sdk/x/auth"
 	"github.com/ColorPlatform/color-sdk/x/bank"
+	distr "github.com/ColorPlatform/color-sdk/x/distribution"
 	"github.com/ColorPlatform/color-sdk/x/params"
 	"github.com/ColorPlatform/color-sdk/x/staking"
+	"github.com/ColorPlatform/color-sdk/x/supply"
 	dbm "github.com/ColorPlatform/prism/libs/db"
 )
 
@@ -24,6 +26,7 @@ type testInput struct {
 	ctx        sdk.Context
 	cdc        *codec.Codec
 	mintKeeper Keeper
+	fck        auth.FeeCollectionKeeper
 }
 
 func createTestCodec() *codec.Codec {
@@ -43,6 +46,8 @@ func newTestInput(t *testing.T) testInput {
 	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
 	keyFeeCollection := sdk.NewKVStoreKey(auth.FeeStoreKey)
 	keyMint := sdk.NewKVStoreKey(StoreKey)
+	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
 
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
@@ -51,6 +56,8 @@ func newTestInput(t *testing.T) testInput {
 	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyMint, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
@@ -62,14 +69,20 @@ func newTestInput(t *testing.T) testInput {
 	stakingKeeper := staking.NewKeeper(
 		cdc, keyStaking, tkeyStaking, bankKeeper, paramsKeeper.Subspace(staking.DefaultParamspace), staking.DefaultCodespace,
 	)
+	distrKeeper := distr.NewKeeper(
+		cdc, keyDistr, paramsKeeper.Subspace(distr.DefaultParamspace), bankKeeper, &stakingKeeper,
+		feeCollectionKeeper, distr.DefaultCodespace,
+	)
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, &stakingKeeper, distrKeeper, feeCollectionKeeper)
 	mintKeeper := NewKeeper(
-		cdc, keyMint, paramsKeeper.Subspace(DefaultParamspace), &stakingKeeper, feeCollectionKeeper,
+		cdc, keyMint, paramsKeeper.Subspace(DefaultParamspace), &stakingKeeper, feeCollectionKeeper, supplyKeeper,
 	)
 
 	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(0, 0)}, false, log.NewTMLogger(os.Stdout))
 
+	stakingKeeper.SetPool(ctx, staking.InitialPool())
 	mintKeeper.SetParams(ctx, DefaultParams())
 	mintKeeper.SetMinter(ctx, DefaultInitialMinter())
 
-	return testInput{ctx, cdc, mintKeeper}
+	return testInput{ctx, cdc, mintKeeper, feeCollectionKeeper}
 }
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -216,3 +217,51 @@ func TestSubspace(t *testing.T) {
 		require.Equal(t, kv.param, indirect(kv.ptr), "stored param not equal, tc #%d", i)
 	}
 }
+
+type positiveParam int64
+
+func (p positiveParam) Validate() error {
+	if p <= 0 {
+		return fmt.Errorf("param must be positive, is %d", p)
+	}
+	return nil
+}
+
+func TestSubspaceUpdate(t *testing.T) {
+	cdc := createTestCodec()
+	key := sdk.NewKVStoreKey("test")
+	tkey := sdk.NewTransientStoreKey("transient_test")
+	ctx := defaultContext(key, tkey)
+	keeper := NewKeeper(cdc, key, tkey)
+
+	table := NewKeyTable(
+		[]byte("int64"), int64(0),
+		[]byte("dec"), sdk.Dec{},
+		[]byte("positive"), positiveParam(0),
+	)
+	space := keeper.Subspace("test").WithKeyTable(table)
+
+	require.Error(t, space.Validate([]byte("invalid"), []byte(`"1"`)))
+	require.Error(t, space.Validate([]byte("int64"), []byte(`"abc"`)))
+	require.NoError(t, space.Validate([]byte("int64"), []byte(`"10"`)))
+	require.Error(t, space.Validate([]byte("positive"), []byte(`"-1"`)))
+	require.NoError(t, space.Validate([]byte("positive"), []byte(`"1"`)))
+	require.False(t, space.Has(ctx, []byte("int64")))
+
+	require.Error(t, space.Update(ctx, []byte("dec"), []byte(`"not a dec"`)))
+	require.False(t, space.Has(ctx, []byte("dec")))
+
+	require.NoError(t, space.Update(ctx, []byte("dec"), []byte(`"0.500000000000000000"`)))
+	require.True(t, space.Modified(ctx, []byte("dec")))
+	var dec sdk.Dec
+	space.Get(ctx, []byte("dec"), &dec)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), dec)
+
+	// the subspace handed out by GetSubspace shares the KeyTable
+	gspace, ok := keeper.GetSubspace("test")
+	require.True(t, ok)
+	require.NoError(t, gspace.Update(ctx, []byte("int64"), []byte(`"3"`)))
+	var param int64
+	space.Get(ctx, []byte("int64"), &param)
+	require.Equal(t, int64(3), param)
+}
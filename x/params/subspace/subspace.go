@@ -1,6 +1,7 @@
 package subspace
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/ColorPlatform/color-sdk/codec"
@@ -195,6 +196,49 @@ func (s Subspace) SetWithSubkey(ctx sdk.Context, key []byte, subkey []byte, para
 	tstore.Set(newkey, []byte{})
 }
 
+// ValidatedParam is implemented by parameter types which check their own
+// value, such as a module params struct stored under a single key
+type ValidatedParam interface {
+	Validate() error
+}
+
+// Validate checks that the key is registered in the KeyTable and that the
+// JSON encoded value can be decoded into the registered type, and validates
+// the decoded value if its type implements ValidatedParam
+func (s Subspace) Validate(key []byte, value []byte) error {
+	_, err := s.decode(key, value)
+	return err
+}
+
+// Update decodes a JSON encoded value into the type registered for the key
+// and stores it. Nothing is written if the value cannot be decoded.
+func (s Subspace) Update(ctx sdk.Context, key []byte, value []byte) error {
+	param, err := s.decode(key, value)
+	if err != nil {
+		return err
+	}
+	s.Set(ctx, key, param)
+	return nil
+}
+
+func (s Subspace) decode(key []byte, value []byte) (interface{}, error) {
+	attr, ok := s.table.m[string(key)]
+	if !ok {
+		return nil, fmt.Errorf("parameter %s not registered in subspace %s", key, s.name)
+	}
+
+	ptr := reflect.New(attr.ty).Interface()
+	if err := s.cdc.UnmarshalJSON(value, ptr); err != nil {
+		return nil, fmt.Errorf("invalid value for parameter %s in subspace %s: %s", key, s.name, err)
+	}
+	if param, ok := ptr.(ValidatedParam); ok {
+		if err := param.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %s in subspace %s: %s", key, s.name, err)
+		}
+	}
+	return ptr, nil
+}
+
 // Get to ParamSet
 func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
 	for _, pair := range ps.ParamSetPairs() {
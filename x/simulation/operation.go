@@ -171,7 +171,7 @@ func newOperationQueue() OperationQueue {
 
 // adds all future operations into the operation queue.
 func queueOperations(queuedOps OperationQueue,
-	queuedTimeOps []FutureOperation, futureOps []FutureOperation) {
+	queuedTimeOps *[]FutureOperation, futureOps []FutureOperation) {
 
 	if futureOps == nil {
 		return
@@ -189,15 +189,17 @@ func queueOperations(queuedOps OperationQueue,
 
 		// TODO: Replace with proper sorted data structure, so don't have the
 		// copy entire slice
+		timeOps := *queuedTimeOps
 		index := sort.Search(
-			len(queuedTimeOps),
+			len(timeOps),
 			func(i int) bool {
-				return queuedTimeOps[i].BlockTime.After(futureOp.BlockTime)
+				return timeOps[i].BlockTime.After(futureOp.BlockTime)
 			},
 		)
-		queuedTimeOps = append(queuedTimeOps, FutureOperation{})
-		copy(queuedTimeOps[index+1:], queuedTimeOps[index:])
-		queuedTimeOps[index] = futureOp
+		timeOps = append(timeOps, FutureOperation{})
+		copy(timeOps[index+1:], timeOps[index:])
+		timeOps[index] = futureOp
+		*queuedTimeOps = timeOps
 	}
 }
 
@@ -207,10 +209,19 @@ func queueOperations(queuedOps OperationQueue,
 // provided BlockHeight. If both a BlockHeight and BlockTime are specified, it
 // will use the BlockHeight. In the (likely) event that multiple operations
 // are queued at the same block height, they will execute in a FIFO pattern.
+//
+// An operation queued at a BlockTime with AdvanceTime set always gets a block
+// at exactly that time: the simulation jumps to it once it is the next queued
+// operation, and never steps over it. As queued operations only run once the
+// block time is past their time, it runs at the beginning of the block after.
+// This lets operations cover periods far longer than the simulated blocks,
+// such as governance funding cycles, and check what the block at their time
+// did.
 type FutureOperation struct {
 	BlockHeight int
 	BlockTime   time.Time
 	Op          Operation
+	AdvanceTime bool
 }
 
 //________________________________________________________________________
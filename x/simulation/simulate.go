@@ -108,7 +108,7 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 
 	// These are operations which have been queued by previous operations
 	operationQueue := newOperationQueue()
-	timeOperationQueue := []FutureOperation{}
+	timeOperationQueue := &[]FutureOperation{}
 
 	logWriter := NewLogWriter(testingMode)
 	blockSimulator := createBlockSimulator(
@@ -172,10 +172,12 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 
 		res := app.EndBlock(abci.RequestEndBlock{})
 		header.Height++
+		lastTime := header.Time
 		header.Time = header.Time.Add(
 			time.Duration(minTimePerBlock) * time.Second)
 		header.Time = header.Time.Add(
 			time.Duration(int64(r.Intn(int(timeDiff)))) * time.Second)
+		header.Time = advanceTime(*timeOperationQueue, lastTime, header.Time)
 		header.ProposerAddress = validators.randomProposer(r)
 		logWriter.AddEntry(EndBlockEntry(int64(height)))
 
@@ -226,7 +228,7 @@ type blockSimFn func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
 // parameters being passed everytime, to minimize memory overhead.
 func createBlockSimulator(testingMode bool, tb testing.TB, t *testing.T, params Params,
 	event func(string), invariants sdk.Invariants, ops WeightedOperations,
-	operationQueue OperationQueue, timeOperationQueue []FutureOperation,
+	operationQueue OperationQueue, timeOperationQueue *[]FutureOperation,
 	totalNumBlocks, avgBlockSize int, logWriter LogWriter, lean bool) blockSimFn {
 
 	lastBlocksizeState := 0 // state for [4 * uniform distribution]
@@ -286,6 +288,23 @@ func createBlockSimulator(testingMode bool, tb testing.TB, t *testing.T, params
 	}
 }
 
+// advanceTime returns the time of the next block. The first queued operation
+// advancing the block time, which is still ahead of the last block, gets a
+// block at its time: the next block jumps to it if it is the next queued
+// operation, and stops at it if it would step over it.
+func advanceTime(queueOps []FutureOperation, lastTime, blockTime time.Time) time.Time {
+	for i, op := range queueOps {
+		if !op.AdvanceTime || !lastTime.Before(op.BlockTime) {
+			continue
+		}
+		if i == 0 || blockTime.After(op.BlockTime) {
+			return op.BlockTime
+		}
+		break
+	}
+	return blockTime
+}
+
 // nolint: errcheck
 func runQueuedOperations(queueOps map[int][]Operation,
 	height int, tb testing.TB, r *rand.Rand, app *baseapp.BaseApp,
@@ -316,18 +335,18 @@ func runQueuedOperations(queueOps map[int][]Operation,
 	return numOpsRan
 }
 
-func runQueuedTimeOperations(queueOps []FutureOperation,
+func runQueuedTimeOperations(queueOps *[]FutureOperation,
 	height int, currentTime time.Time, tb testing.TB, r *rand.Rand,
 	app *baseapp.BaseApp, ctx sdk.Context, accounts []Account,
 	logWriter LogWriter, tallyEvent func(string), lean bool) (numOpsRan int) {
 
 	numOpsRan = 0
-	for len(queueOps) > 0 && currentTime.After(queueOps[0].BlockTime) {
+	for len(*queueOps) > 0 && currentTime.After((*queueOps)[0].BlockTime) {
 
 		// For now, queued operations cannot queue more operations.
 		// If a need arises for us to support queued messages to queue more messages, this can
 		// be changed.
-		opMsg, _, err := queueOps[0].Op(r, app, ctx, accounts)
+		opMsg, _, err := (*queueOps)[0].Op(r, app, ctx, accounts)
 		opMsg.LogEvent(tallyEvent)
 		if !lean || opMsg.OK {
 			logWriter.AddEntry(QueuedMsgEntry(int64(height), opMsg))
@@ -337,7 +356,7 @@ func runQueuedTimeOperations(queueOps []FutureOperation,
 			tb.FailNow()
 		}
 
-		queueOps = queueOps[1:]
+		*queueOps = (*queueOps)[1:]
 		numOpsRan++
 	}
 	return numOpsRan
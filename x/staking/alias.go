@@ -15,7 +15,9 @@ type (
 	DistributionKeeper      = types.DistributionKeeper
 	Validator               = types.Validator
 	Validators              = types.Validators
+	CouncilMember           = types.CouncilMember
 	CouncilMembers          = types.CouncilMembers
+	HistoricalCouncil       = types.HistoricalCouncil
 	Description             = types.Description
 	Commission              = types.Commission
 	CommissionMsg           = types.CommissionMsg
@@ -38,12 +40,14 @@ type (
 	QueryBondsParams        = querier.QueryBondsParams
 	QueryRedelegationParams = querier.QueryRedelegationParams
 	QueryValidatorsParams   = querier.QueryValidatorsParams
+	QueryCouncilParams      = querier.QueryCouncilParams
 )
 
 var (
 	NewKeeper = keeper.NewKeeper
 
 	GetCouncilMemberKey          = keeper.GetCouncilMemberKey
+	GetCouncilHistoryKey         = keeper.GetCouncilHistoryKey
 	GetValidatorKey              = keeper.GetValidatorKey
 	GetValidatorByConsAddrKey    = keeper.GetValidatorByConsAddrKey
 	GetValidatorsByPowerIndexKey = keeper.GetValidatorsByPowerIndexKey
@@ -78,6 +82,7 @@ var (
 	NonNegativePowerInvariant    = keeper.NonNegativePowerInvariant
 	PositiveDelegationInvariant  = keeper.PositiveDelegationInvariant
 	DelegatorSharesInvariant     = keeper.DelegatorSharesInvariant
+	CouncilMembersInvariant      = keeper.CouncilMembersInvariant
 
 	DefaultParamspace = keeper.DefaultParamspace
 	KeyUnbondingTime  = types.KeyUnbondingTime
@@ -92,6 +97,8 @@ var (
 	NewCommissionMsg      = types.NewCommissionMsg
 	NewCommissionWithTime = types.NewCommissionWithTime
 	NewGenesisState       = types.NewGenesisState
+	NewCouncilMember      = types.NewCouncilMember
+	NewHistoricalCouncil  = types.NewHistoricalCouncil
 	DefaultGenesisState   = types.DefaultGenesisState
 	RegisterCodec         = types.RegisterCodec
 
@@ -108,12 +115,14 @@ var (
 	NewQueryValidatorsParams     = querier.NewQueryValidatorsParams
 	NewQueryCouncilMembersParams = querier.NewQueryCouncilMembersParams
 	NewQueryCouncilMemberParams  = querier.NewQueryCouncilMemberParams
+	NewQueryCouncilParams        = querier.NewQueryCouncilParams
 )
 
 const (
 	QueryValidators                    = querier.QueryValidators
 	QueryCouncilMembers                = querier.QueryCouncilMembers
 	QueryCouncilMember                 = querier.QueryCouncilMember
+	QueryCouncil                       = querier.QueryCouncil
 	QueryValidator                     = querier.QueryValidator
 	QueryValidatorDelegations          = querier.QueryValidatorDelegations
 	QueryValidatorRedelegations        = querier.QueryValidatorRedelegations
@@ -144,6 +153,10 @@ const (
 	CodeUnknownRequest    = types.CodeUnknownRequest
 )
 
+const (
+	CouncilHistoryEntries = keeper.CouncilHistoryEntries
+)
+
 var (
 	ErrNilValidatorAddr               = types.ErrNilValidatorAddr
 	ErrNoValidatorFound               = types.ErrNoValidatorFound
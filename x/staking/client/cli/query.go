@@ -133,6 +133,42 @@ $ colorcli query staking councilmembers
 	}
 }
 
+// GetCmdQueryCouncil implements the query of the council membership at a height.
+func GetCmdQueryCouncil(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "council",
+		Short: "Query the council membership at a height",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Query the council members and their shares at a height, defaults to the latest height:
+
+$ colorcli query staking council --height 1200
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// the council history is kept in the latest state, so the height is
+			// a query parameter instead of the height the store is queried at
+			params := staking.NewQueryCouncilParams(cliCtx.Height)
+			cliCtx.Height = 0
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", storeName, staking.QueryCouncil)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var council staking.HistoricalCouncil
+			cdc.MustUnmarshalJSON(res, &council)
+			return cliCtx.PrintOutput(council)
+		},
+	}
+}
+
 // GetCmdQueryValidatorUnbondingDelegations implements the query all unbonding delegatations from a validator command.
 func GetCmdQueryValidatorUnbondingDelegations(storeKey string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -37,6 +37,7 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 		cli.GetCmdQueryValidators(mc.storeKey, mc.cdc),
 		cli.GetCmdQueryCouncilMembers(mc.storeKey, mc.cdc),
 		cli.GetCmdQueryCouncilMember(mc.storeKey, mc.cdc),
+		cli.GetCmdQueryCouncil(mc.storeKey, mc.cdc),
 		cli.GetCmdQueryValidatorDelegations(mc.storeKey, mc.cdc),
 		cli.GetCmdQueryValidatorUnbondingDelegations(mc.storeKey, mc.cdc),
 		cli.GetCmdQueryValidatorRedelegations(mc.storeKey, mc.cdc),
@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/ColorPlatform/color-sdk/client/context"
@@ -76,6 +77,12 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		councilmembersHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// Get the council membership at a height
+	r.HandleFunc(
+		"/staking/council",
+		councilHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 	// Get a single council member info
 	r.HandleFunc(
 		"/staking/councilmembers/{councilmemberAddr}",
@@ -326,6 +333,36 @@ func councilmembersHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.H
 	}
 }
 
+// HTTP request handler to query the council membership at a height
+func councilHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var height int64
+		if heightStr := r.URL.Query().Get("height"); heightStr != "" {
+			var err error
+			height, err = strconv.ParseInt(heightStr, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "height must be an integer")
+				return
+			}
+		}
+
+		params := staking.NewQueryCouncilParams(height)
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", staking.QuerierRoute, staking.QueryCouncil)
+		res, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
 func councilmemberHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return queryCouncilMember(cliCtx, cdc, "custom/staking/councilmember")
 }
@@ -112,6 +112,13 @@ func (k Keeper) InflateSupply(ctx sdk.Context, newTokens sdk.Int) {
 	k.SetPool(ctx, pool)
 }
 
+// when burning not bonded tokens
+func (k Keeper) DeflateSupply(ctx sdk.Context, burnedTokens sdk.Int) {
+	pool := k.GetPool(ctx)
+	pool.NotBondedTokens = pool.NotBondedTokens.Sub(burnedTokens)
+	k.SetPool(ctx, pool)
+}
+
 // Implements DelegationSet
 
 var _ sdk.DelegationSet = Keeper{}
@@ -10,6 +10,7 @@ func (k Keeper) SetCouncilMember(ctx sdk.Context, member types.CouncilMember) {
 	store := ctx.KVStore(k.storeKey)
 	b := types.MustMarshalCouncilMember(k.cdc, member)
 	store.Set(GetCouncilMemberKey(member.MemberAddress), b)
+	k.markCouncilChanged(ctx)
 }
 
 // GetCouncilMember gets council member
@@ -45,6 +46,7 @@ func (k Keeper) DeleteCouncilMember(ctx sdk.Context, memAddr sdk.AccAddress) {
 	store := ctx.KVStore(k.storeKey)
 	key := GetCouncilMemberKey(memAddr)
 	store.Delete(key)
+	k.markCouncilChanged(ctx)
 }
 
 // GetCouncilMemberIterator :
@@ -74,3 +76,61 @@ func (k Keeper) GetAllCouncilMembers(ctx sdk.Context) (councilMembers []types.Co
 	}
 	return councilMembers
 }
+
+// CouncilHistoryEntries is the number of council records kept in the history.
+// Tallies use the council snapshots of gov, so older records are only lost to
+// queries.
+const CouncilHistoryEntries = 100
+
+// markCouncilChanged notes that the council changed during the block, for it
+// to be recorded once at the end of the block
+func (k Keeper) markCouncilChanged(ctx sdk.Context) {
+	tstore := ctx.TransientStore(k.storeTKey)
+	tstore.Set(CouncilChangedKey, []byte{1})
+}
+
+// RecordCouncilHistory stores the council membership of the current height if
+// it changed during the block, so the council can later be looked up at any
+// height where it changed. Only the CouncilHistoryEntries latest records are
+// kept.
+func (k Keeper) RecordCouncilHistory(ctx sdk.Context) {
+	tstore := ctx.TransientStore(k.storeTKey)
+	if !tstore.Has(CouncilChangedKey) {
+		return
+	}
+	tstore.Delete(CouncilChangedKey)
+
+	height := ctx.BlockHeight()
+	council := types.NewHistoricalCouncil(height, k.GetAllCouncilMembers(ctx))
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetCouncilHistoryKey(height), types.MustMarshalHistoricalCouncil(k.cdc, council))
+
+	iterator := sdk.KVStoreReversePrefixIterator(store, CouncilHistoryKey)
+	defer iterator.Close()
+	var pruned [][]byte
+	for kept := 0; iterator.Valid(); iterator.Next() {
+		if kept < CouncilHistoryEntries {
+			kept++
+			continue
+		}
+		pruned = append(pruned, iterator.Key())
+	}
+	for _, key := range pruned {
+		store.Delete(key)
+	}
+}
+
+// GetCouncilAtHeight returns the council membership as it was at the end of a
+// height. The council is only recorded at the heights where it changed, so the
+// latest record at or below the height is returned, with the height it was
+// recorded at.
+func (k Keeper) GetCouncilAtHeight(ctx sdk.Context, height int64) (council types.HistoricalCouncil, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(CouncilHistoryKey, sdk.PrefixEndBytes(GetCouncilHistoryKey(height)))
+	defer iterator.Close()
+
+	if !iterator.Valid() {
+		return council, false
+	}
+	return types.MustUnmarshalHistoricalCouncil(k.cdc, iterator.Value()), true
+}
@@ -565,9 +565,12 @@ func (k Keeper) unbond(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValA
 	totalDelegation := k.GetTotalDelegatorDelegations(ctx, delAddr)
 	params := k.GetParams(ctx)
 
-	//if delegations are less than min council coin value, remove the council member
+	//if delegations are less than min council coin value, remove the council member,
+	//otherwise update its shares
 	if totalDelegation.LT(params.CouncilMemberMinCoin) {
 		k.DeleteCouncilMember(ctx, delAddr)
+	} else {
+		k.SetCouncilMemberShares(ctx, delAddr, totalDelegation)
 	}
 
 	return amount, nil
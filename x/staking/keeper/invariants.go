@@ -21,6 +21,8 @@ func RegisterInvariants(c types.CrisisKeeper, k Keeper, f types.FeeCollectionKee
 		PositiveDelegationInvariant(k))
 	c.RegisterRoute(types.ModuleName, "delegator-shares",
 		DelegatorSharesInvariant(k))
+	c.RegisterRoute(types.ModuleName, "council-members",
+		CouncilMembersInvariant(k))
 }
 
 // AllInvariants runs all invariants of the staking module.
@@ -48,6 +50,11 @@ func AllInvariants(k Keeper, f types.FeeCollectionKeeper,
 			return err
 		}
 
+		err = CouncilMembersInvariant(k)(ctx)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	}
 }
@@ -182,3 +189,25 @@ func DelegatorSharesInvariant(k Keeper) sdk.Invariant {
 		return nil
 	}
 }
+
+// CouncilMembersInvariant checks that every council member still delegates at
+// least the minimum council member coins, and that its shares equal the sum of
+// its delegation shares
+func CouncilMembersInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		minCoin := k.CouncilMemberMinCoin(ctx)
+		for _, member := range k.GetAllCouncilMembers(ctx) {
+			totalDelegation := k.GetTotalDelegatorDelegations(ctx, member.MemberAddress)
+			if totalDelegation.LT(minCoin) {
+				return fmt.Errorf("council member %s delegates %v, below the minimum %v",
+					member.MemberAddress, totalDelegation, minCoin)
+			}
+			if !member.Shares.Equal(totalDelegation) {
+				return fmt.Errorf("broken council member shares invariance for %s:\n"+
+					"\tcouncilMember.Shares: %v\n"+
+					"\tsum of Delegation.Shares: %v", member.MemberAddress, member.Shares, totalDelegation)
+			}
+		}
+		return nil
+	}
+}
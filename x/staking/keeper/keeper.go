@@ -18,6 +18,7 @@ type Keeper struct {
 	storeTKey          sdk.StoreKey
 	cdc                *codec.Codec
 	bankKeeper         types.BankKeeper
+	supplyKeeper       types.SupplyKeeper
 	hooks              sdk.StakingHooks
 	paramstore         params.Subspace
 	validatorCache     map[string]cachedValidator
@@ -53,6 +54,15 @@ func (k *Keeper) SetHooks(sh sdk.StakingHooks) *Keeper {
 	return k
 }
 
+// Set the supply keeper recording the tokens burned by slashes
+func (k *Keeper) SetSupplyKeeper(sk types.SupplyKeeper) *Keeper {
+	if k.supplyKeeper != nil {
+		panic("cannot set supply keeper twice")
+	}
+	k.supplyKeeper = sk
+	return k
+}
+
 // return the codespace
 func (k Keeper) Codespace() sdk.CodespaceType {
 	return k.codespace
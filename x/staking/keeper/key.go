@@ -35,6 +35,9 @@ var (
 	ValidatorQueueKey    = []byte{0x43} // prefix for the timestamps in validator queue
 
 	CouncilMembersKey = []byte{0x51} //prefix for each key to a council member
+	CouncilHistoryKey = []byte{0x52} //prefix for each key to the council membership at a height
+
+	CouncilChangedKey = []byte{0x53} // transient key set when the council changed during the block
 )
 
 // gets the key for the validator with address
@@ -49,6 +52,12 @@ func GetCouncilMemberKey(memberAddr sdk.AccAddress) []byte {
 	return append(CouncilMembersKey, memberAddr.Bytes()...)
 }
 
+// gets the key for the council membership recorded at a height
+// VALUE: staking/types.HistoricalCouncil
+func GetCouncilHistoryKey(height int64) []byte {
+	return append(CouncilHistoryKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
 // gets the key for the validator with pubkey
 // VALUE: validator operator address ([]byte)
 func GetValidatorByConsAddrKey(addr sdk.ConsAddress) []byte {
@@ -18,6 +18,7 @@ const (
 	QueryValidator                     = "validator"
 	QueryCouncilMembers                = "councilmembers"
 	QueryCouncilMember                 = "councilmember"
+	QueryCouncil                       = "council"
 	QueryDelegatorDelegations          = "delegatorDelegations"
 	QueryDelegatorUnbondingDelegations = "delegatorUnbondingDelegations"
 	QueryRedelegations                 = "redelegations"
@@ -45,6 +46,8 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 			return queryCouncilMembers(ctx, cdc, req, k)
 		case QueryCouncilMember:
 			return queryCouncilMember(ctx, cdc, req, k)
+		case QueryCouncil:
+			return queryCouncil(ctx, cdc, req, k)
 		case QueryValidatorDelegations:
 			return queryValidatorDelegations(ctx, cdc, req, k)
 		case QueryValidatorUnbondingDelegations:
@@ -250,6 +253,35 @@ func queryCouncilMember(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery
 	return res, nil
 }
 
+func queryCouncil(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keep.Keeper) (res []byte, err sdk.Error) {
+	var params QueryCouncilParams
+
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrInternal(fmt.Sprintf("failed to parse params: %s", errRes))
+	}
+
+	height := params.Height
+	if height == 0 {
+		height = ctx.BlockHeight()
+	}
+	if height < 0 || height > ctx.BlockHeight() {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("invalid council height %d, latest height is %d", height, ctx.BlockHeight()))
+	}
+
+	council, found := k.GetCouncilAtHeight(ctx, height)
+	if !found {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("council history pruned or not recorded at height %d", height))
+	}
+	council.Height = height
+
+	res, errRes = codec.MarshalJSONIndent(cdc, council)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func queryValidatorDelegations(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keep.Keeper) (res []byte, err sdk.Error) {
 	var params QueryValidatorParams
 
@@ -466,3 +498,15 @@ type QueryCouncilMembersParams struct {
 func NewQueryCouncilMembersParams(page int) QueryCouncilMembersParams {
 	return QueryCouncilMembersParams{page}
 }
+
+// QueryCouncilParams defines the params for following Queries:
+// - 'custom/staking/council'
+//
+// A zero height queries the latest height.
+type QueryCouncilParams struct {
+	Height int64
+}
+
+func NewQueryCouncilParams(height int64) QueryCouncilParams {
+	return QueryCouncilParams{height}
+}
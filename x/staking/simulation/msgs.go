@@ -134,6 +134,55 @@ func SimulateMsgDelegate(m auth.AccountKeeper, k staking.Keeper) simulation.Oper
 	}
 }
 
+// SimulateMsgDelegateCouncilMember simulates a delegation large enough to make
+// the delegator a council member
+func SimulateMsgDelegateCouncilMember(m auth.AccountKeeper, k staking.Keeper) simulation.Operation {
+	handler := staking.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		denom := k.GetParams(ctx).BondDenom
+		if len(k.GetAllValidators(ctx)) == 0 {
+			return simulation.NoOpMsg(), nil, nil
+		}
+		val := keeper.RandomValidator(r, k, ctx)
+		delegatorAcc := simulation.RandomAcc(r, accs)
+		delegatorAddress := delegatorAcc.Address
+		if _, found := k.GetCouncilMember(ctx, delegatorAddress); found {
+			return simulation.NoOpMsg(), nil, nil
+		}
+
+		// delegate the missing amount, rounded up to tokens, and some more
+		missing := k.CouncilMemberMinCoin(ctx).Sub(k.GetTotalDelegatorDelegations(ctx, delegatorAddress))
+		minAmount := missing.Ceil().TruncateInt()
+		if !minAmount.IsPositive() {
+			minAmount = sdk.OneInt()
+		}
+		balance := m.GetAccount(ctx, delegatorAddress).GetCoins().AmountOf(denom)
+		if balance.LT(minAmount) {
+			return simulation.NoOpMsg(), nil, nil
+		}
+		amount := minAmount
+		if extra := balance.Sub(minAmount); extra.IsPositive() {
+			amount = amount.Add(simulation.RandomAmount(r, extra))
+		}
+
+		msg := staking.NewMsgDelegate(
+			delegatorAddress, val.GetOperator(), sdk.NewCoin(denom, amount))
+
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+		ctx, write := ctx.CacheContext()
+		ok := handler(ctx, msg).IsOK()
+		if ok {
+			write()
+		}
+		opMsg = simulation.NewOperationMsg(msg, ok, "")
+		return opMsg, nil, nil
+	}
+}
+
 // SimulateMsgUndelegate
 func SimulateMsgUndelegate(m auth.AccountKeeper, k staking.Keeper) simulation.Operation {
 	handler := staking.NewHandler(k)
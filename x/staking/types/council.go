@@ -57,3 +57,34 @@ func (cms CouncilMembers) String() (out string) {
 	}
 	return strings.TrimSpace(out)
 }
+
+// HistoricalCouncil is the council membership recorded at a block height
+type HistoricalCouncil struct {
+	Height  int64          `json:"height"`
+	Members CouncilMembers `json:"members"`
+}
+
+func NewHistoricalCouncil(height int64, members CouncilMembers) HistoricalCouncil {
+	return HistoricalCouncil{
+		Height:  height,
+		Members: members,
+	}
+}
+
+// MustMarshalHistoricalCouncil : return the historical council
+func MustMarshalHistoricalCouncil(cdc *codec.Codec, council HistoricalCouncil) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(council)
+}
+
+// MustUnmarshalHistoricalCouncil : return the historical council
+func MustUnmarshalHistoricalCouncil(cdc *codec.Codec, value []byte) HistoricalCouncil {
+	var council HistoricalCouncil
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &council)
+	return council
+}
+
+func (hc HistoricalCouncil) String() string {
+	out := fmt.Sprintf("Council at height %d:\n", hc.Height)
+	out += hc.Members.String()
+	return strings.TrimSpace(out)
+}
@@ -23,3 +23,8 @@ type BankKeeper interface {
 type CrisisKeeper interface {
 	RegisterRoute(moduleName, route string, invar sdk.Invariant)
 }
+
+// expected supply keeper
+type SupplyKeeper interface {
+	Burn(ctx sdk.Context, amount sdk.Coins)
+}
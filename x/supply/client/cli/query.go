@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ColorPlatform/color-sdk/client/context"
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+)
+
+// GetCmdQueryTotal implements the query total supply command.
+func GetCmdQueryTotal(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "total",
+		Short: "Query the supply of every denomination",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`
+Query the total, circulating, bonded, community pool, burned and locked supply of every denomination:
+
+$ colorcli query supply total
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, supply.QueryTotal), nil)
+			if err != nil {
+				return err
+			}
+
+			var supplies supply.DenomSupplies
+			cdc.MustUnmarshalJSON(res, &supplies)
+			return cliCtx.PrintOutput(supplies)
+		},
+	}
+}
+
+// GetCmdQueryDenom implements the query supply of a denomination command.
+func GetCmdQueryDenom(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "denom [denom]",
+		Short: "Query the supply of a denomination",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(`
+Query the total, circulating, bonded, community pool, burned and locked supply of a denomination:
+
+$ colorcli query supply denom uclr
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := supply.NewQueryDenomParams(args[0])
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, supply.QueryDenom), bz)
+			if err != nil {
+				return err
+			}
+
+			var denomSupply supply.DenomSupply
+			cdc.MustUnmarshalJSON(res, &denomSupply)
+			return cliCtx.PrintOutput(denomSupply)
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/ColorPlatform/color-sdk/client"
+	"github.com/ColorPlatform/color-sdk/x/supply"
+	"github.com/ColorPlatform/color-sdk/x/supply/client/cli"
+)
+
+// ModuleClient exports all client functionality from the supply module
+type ModuleClient struct {
+	storeKey string
+	cdc      *amino.Codec
+}
+
+func NewModuleClient(storeKey string, cdc *amino.Codec) ModuleClient {
+	return ModuleClient{storeKey, cdc}
+}
+
+// GetQueryCmd returns the cli query commands for the supply module
+func (mc ModuleClient) GetQueryCmd() *cobra.Command {
+	supplyQueryCmd := &cobra.Command{
+		Use:   supply.ModuleName,
+		Short: "Querying commands for the supply module",
+	}
+
+	supplyQueryCmd.AddCommand(
+		client.GetCommands(
+			cli.GetCmdQueryTotal(mc.storeKey, mc.cdc),
+			cli.GetCmdQueryDenom(mc.storeKey, mc.cdc),
+		)...,
+	)
+
+	return supplyQueryCmd
+}
+
+// GetTxCmd returns the transaction commands for the supply module.
+// The supply is only changed by the modules creating and burning coins.
+func (mc ModuleClient) GetTxCmd() *cobra.Command {
+	supplyTxCmd := &cobra.Command{
+		Use:   supply.ModuleName,
+		Short: "Supply transaction subcommands",
+	}
+
+	return supplyTxCmd
+}
@@ -0,0 +1,31 @@
+package supply
+
+import (
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/auth"
+	stakingtypes "github.com/ColorPlatform/color-sdk/x/staking/types"
+)
+
+// expected account keeper
+type AccountKeeper interface {
+	IterateAccounts(ctx sdk.Context, process func(auth.Account) (stop bool))
+}
+
+// expected staking keeper
+type StakingKeeper interface {
+	BondDenom(ctx sdk.Context) string
+	TotalBondedTokens(ctx sdk.Context) sdk.Int
+	IterateValidators(ctx sdk.Context, fn func(index int64, validator sdk.Validator) (stop bool))
+	IterateUnbondingDelegations(ctx sdk.Context, fn func(index int64, ubd stakingtypes.UnbondingDelegation) (stop bool))
+}
+
+// expected distribution keeper
+type DistributionKeeper interface {
+	GetFeePoolCommunityCoins(ctx sdk.Context) sdk.DecCoins
+	GetValidatorOutstandingRewardsCoins(ctx sdk.Context, val sdk.ValAddress) sdk.DecCoins
+}
+
+// expected fee collection keeper
+type FeeCollectionKeeper interface {
+	GetCollectedFees(ctx sdk.Context) sdk.Coins
+}
@@ -0,0 +1,46 @@
+package supply
+
+import (
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// GenesisState - supply state
+type GenesisState struct {
+	Supply Supply `json:"supply"` // tracked supply, computed from the genesis holdings if empty
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(supply Supply) GenesisState {
+	return GenesisState{
+		Supply: supply,
+	}
+}
+
+// DefaultGenesisState creates a default GenesisState object
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Supply: NewSupply(sdk.Coins{}, sdk.Coins{}, sdk.Coins{}),
+	}
+}
+
+// new supply genesis. It must run after the genesis of the modules holding
+// coins. A genesis without a total supply starts tracking the coins it holds.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	supply := data.Supply
+	if supply.Total.Empty() {
+		total, _ := keeper.holdings(ctx).TruncateDecimal()
+		supply.Total = total.Add(supply.Locked)
+	}
+	keeper.SetSupply(ctx, supply)
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	return NewGenesisState(keeper.GetSupply(ctx))
+}
+
+// ValidateGenesis validates the provided genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	return validateSupply(data.Supply)
+}
@@ -0,0 +1,35 @@
+package supply
+
+import (
+	"fmt"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// expected crisis keeper
+type CrisisKeeper interface {
+	RegisterRoute(moduleName, route string, invar sdk.Invariant)
+}
+
+// register supply invariants
+func RegisterInvariants(c CrisisKeeper, k Keeper) {
+	c.RegisterRoute(ModuleName, "total-supply",
+		TotalSupplyInvariant(k))
+}
+
+// TotalSupplyInvariant checks that the tracked supply, less the coins locked
+// on other chains, equals the coins held on this chain
+func TotalSupplyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		supply := k.GetSupply(ctx)
+		expected := sdk.NewDecCoins(supply.Total.Sub(supply.Locked))
+		holdings := k.holdings(ctx)
+
+		if diff, _ := expected.SafeSub(holdings); !diff.IsZero() {
+			return fmt.Errorf("total supply invariance:\n"+
+				"\ttracked supply held on chain: %v\n"+
+				"\tsum of holdings: %v", expected, holdings)
+		}
+		return nil
+	}
+}
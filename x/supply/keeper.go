@@ -0,0 +1,177 @@
+package supply
+
+import (
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/auth"
+	stakingtypes "github.com/ColorPlatform/color-sdk/x/staking/types"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "supply"
+
+	// StoreKey is the store key string for supply
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for supply
+	QuerierRoute = ModuleName
+)
+
+// Keys for supply store
+var (
+	supplyKey = []byte{0x00} // key for the tracked supply
+)
+
+// Keeper of the supply store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	ak       AccountKeeper
+	sk       StakingKeeper
+	dk       DistributionKeeper
+	fck      FeeCollectionKeeper
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ak AccountKeeper, sk StakingKeeper,
+	dk DistributionKeeper, fck FeeCollectionKeeper) Keeper {
+
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+		ak:       ak,
+		sk:       sk,
+		dk:       dk,
+		fck:      fck,
+	}
+}
+
+// GetSupply returns the tracked supply
+func (k Keeper) GetSupply(ctx sdk.Context) (supply Supply) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(supplyKey)
+	if bz == nil {
+		return NewSupply(sdk.Coins{}, sdk.Coins{}, sdk.Coins{})
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &supply)
+	return
+}
+
+// SetSupply sets the tracked supply
+func (k Keeper) SetSupply(ctx sdk.Context, supply Supply) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(supply)
+	store.Set(supplyKey, bz)
+}
+
+// Inflate records newly created coins
+func (k Keeper) Inflate(ctx sdk.Context, amount sdk.Coins) {
+	supply := k.GetSupply(ctx)
+	supply.Total = supply.Total.Add(amount)
+	k.SetSupply(ctx, supply)
+}
+
+// Burn records coins destroyed by the modules
+func (k Keeper) Burn(ctx sdk.Context, amount sdk.Coins) {
+	supply := k.GetSupply(ctx)
+	total, hasNeg := supply.Total.SafeSub(amount)
+	if hasNeg {
+		panic("burning more coins than the total supply")
+	}
+	supply.Total = total
+	supply.Burned = supply.Burned.Add(amount)
+	k.SetSupply(ctx, supply)
+}
+
+// Unburn records burned coins coming back into the supply, such as the
+// vouchers refunded to the sender of a failed IBC transfer
+func (k Keeper) Unburn(ctx sdk.Context, amount sdk.Coins) {
+	supply := k.GetSupply(ctx)
+	burned, hasNeg := supply.Burned.SafeSub(amount)
+	if hasNeg {
+		panic("unburning more coins than burned")
+	}
+	supply.Total = supply.Total.Add(amount)
+	supply.Burned = burned
+	k.SetSupply(ctx, supply)
+}
+
+// Lock records coins sent to another chain. They remain part of the total
+// supply but are no longer held on this chain.
+func (k Keeper) Lock(ctx sdk.Context, amount sdk.Coins) {
+	supply := k.GetSupply(ctx)
+	supply.Locked = supply.Locked.Add(amount)
+	k.SetSupply(ctx, supply)
+}
+
+// Unlock records coins received from another chain. Coins coming back release
+// the locked amount, anything above it is new to this chain and inflates the supply.
+func (k Keeper) Unlock(ctx sdk.Context, amount sdk.Coins) {
+	supply := k.GetSupply(ctx)
+	released := sdk.Coins{}
+	for _, coin := range amount {
+		locked := supply.Locked.AmountOf(coin.Denom)
+		if locked.IsPositive() {
+			released = released.Add(sdk.Coins{sdk.NewCoin(coin.Denom, sdk.MinInt(locked, coin.Amount))})
+		}
+	}
+	supply.Locked = supply.Locked.Sub(released)
+	supply.Total = supply.Total.Add(amount.Sub(released))
+	k.SetSupply(ctx, supply)
+}
+
+// GetDenomSupply returns the supply of a single denomination
+func (k Keeper) GetDenomSupply(ctx sdk.Context, denom string) DenomSupply {
+	supply := k.GetSupply(ctx)
+
+	bonded := sdk.ZeroInt()
+	if denom == k.sk.BondDenom(ctx) {
+		bonded = k.sk.TotalBondedTokens(ctx)
+	}
+	communityPool := k.dk.GetFeePoolCommunityCoins(ctx).AmountOf(denom).TruncateInt()
+
+	return NewDenomSupply(denom, supply.Total.AmountOf(denom), bonded, communityPool,
+		supply.Burned.AmountOf(denom), supply.Locked.AmountOf(denom))
+}
+
+// GetDenomSupplies returns the supply of every denomination the chain tracks
+func (k Keeper) GetDenomSupplies(ctx sdk.Context) DenomSupplies {
+	supply := k.GetSupply(ctx)
+	denoms := supply.Total.Add(supply.Burned)
+
+	supplies := DenomSupplies{}
+	for _, coin := range denoms {
+		supplies = append(supplies, k.GetDenomSupply(ctx, coin.Denom))
+	}
+	return supplies
+}
+
+// holdings sums all the coins held on this chain: by accounts, by validators
+// and unbonding delegations, by the distribution module and by the fee collector.
+func (k Keeper) holdings(ctx sdk.Context) sdk.DecCoins {
+	coins := sdk.Coins{}
+	k.ak.IterateAccounts(ctx, func(acc auth.Account) bool {
+		coins = coins.Add(acc.GetCoins())
+		return false
+	})
+
+	bondDenom := k.sk.BondDenom(ctx)
+	staked := sdk.ZeroInt()
+	k.sk.IterateUnbondingDelegations(ctx, func(_ int64, ubd stakingtypes.UnbondingDelegation) bool {
+		for _, entry := range ubd.Entries {
+			staked = staked.Add(entry.Balance)
+		}
+		return false
+	})
+
+	distributed := k.dk.GetFeePoolCommunityCoins(ctx)
+	k.sk.IterateValidators(ctx, func(_ int64, validator sdk.Validator) bool {
+		staked = staked.Add(validator.GetTokens())
+		distributed = distributed.Add(k.dk.GetValidatorOutstandingRewardsCoins(ctx, validator.GetOperator()))
+		return false
+	})
+	coins = coins.Add(sdk.Coins{sdk.NewCoin(bondDenom, staked)})
+	coins = coins.Add(k.fck.GetCollectedFees(ctx))
+
+	return sdk.NewDecCoins(coins).Add(distributed)
+}
@@ -0,0 +1,103 @@
+package supply
+
+import (
+	"testing"
+
+	"github.com/ColorPlatform/prism/crypto/ed25519"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+func TestSupplyRecords(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	coins := func(amt int64) sdk.Coins {
+		return sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, amt)}
+	}
+
+	keeper.Inflate(ctx, coins(100))
+	keeper.Burn(ctx, coins(10))
+	keeper.Lock(ctx, coins(30))
+	require.Equal(t, NewSupply(coins(90), coins(10), coins(30)), keeper.GetSupply(ctx))
+
+	// coins coming back release the locked coins, the rest is new to the chain
+	keeper.Unlock(ctx, coins(50))
+	supply := keeper.GetSupply(ctx)
+	require.Equal(t, coins(110), supply.Total)
+	require.True(t, supply.Locked.Empty())
+
+	require.Panics(t, func() { keeper.Burn(ctx, coins(111)) })
+
+	// refunded coins come back from the burned ones
+	keeper.Unburn(ctx, coins(4))
+	require.Equal(t, NewSupply(coins(114), coins(6), nil), keeper.GetSupply(ctx))
+	require.Panics(t, func() { keeper.Unburn(ctx, coins(7)) })
+}
+
+func TestTotalSupplyInvariant(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("other", 5), sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)}
+	_, _, err := input.bankKeeper.AddCoins(ctx, addr, coins)
+	require.Nil(t, err)
+
+	// the genesis supply is computed from the holdings
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+	supply := keeper.GetSupply(ctx)
+	require.Equal(t, coins, supply.Total)
+	require.True(t, supply.Burned.Empty())
+	require.Nil(t, TotalSupplyInvariant(keeper)(ctx))
+
+	// minted fees
+	minted := sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 20)}
+	input.fck.AddCollectedFees(ctx, minted)
+	require.NotNil(t, TotalSupplyInvariant(keeper)(ctx))
+	keeper.Inflate(ctx, minted)
+	require.Nil(t, TotalSupplyInvariant(keeper)(ctx))
+
+	// burned coins
+	burned := sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 40)}
+	_, _, err = input.bankKeeper.SubtractCoins(ctx, addr, burned)
+	require.Nil(t, err)
+	require.NotNil(t, TotalSupplyInvariant(keeper)(ctx))
+	keeper.Burn(ctx, burned)
+	require.Nil(t, TotalSupplyInvariant(keeper)(ctx))
+
+	// coins sent to another chain
+	locked := sdk.Coins{sdk.NewInt64Coin("other", 5)}
+	_, _, err = input.bankKeeper.SubtractCoins(ctx, addr, locked)
+	require.Nil(t, err)
+	keeper.Lock(ctx, locked)
+	require.Nil(t, TotalSupplyInvariant(keeper)(ctx))
+}
+
+func TestDenomSupply(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	feePool := input.distrKeeper.GetFeePool(ctx)
+	feePool.CommunityPool = sdk.DecCoins{sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDecWithPrec(255, 1))}
+	input.distrKeeper.SetFeePool(ctx, feePool)
+
+	keeper.SetSupply(ctx, NewSupply(
+		sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)},
+		sdk.Coins{sdk.NewInt64Coin("burnt", 7), sdk.NewInt64Coin(sdk.DefaultBondDenom, 3)},
+		sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)},
+	))
+
+	denomSupply := keeper.GetDenomSupply(ctx, sdk.DefaultBondDenom)
+	require.Equal(t, NewDenomSupply(sdk.DefaultBondDenom, sdk.NewInt(100), sdk.ZeroInt(), sdk.NewInt(25),
+		sdk.NewInt(3), sdk.NewInt(10)), denomSupply)
+	require.Equal(t, sdk.NewInt(65), denomSupply.Circulating)
+
+	// denominations burned entirely are still listed
+	supplies := keeper.GetDenomSupplies(ctx)
+	require.Len(t, supplies, 2)
+	require.Equal(t, "burnt", supplies[0].Denom)
+	require.True(t, supplies[0].Total.IsZero())
+	require.Equal(t, sdk.NewInt(7), supplies[0].Burned)
+}
@@ -0,0 +1,63 @@
+package supply
+
+import (
+	"fmt"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// query endpoints supported by the supply Querier
+const (
+	QueryTotal = "total"
+	QueryDenom = "denom"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryTotal:
+			return queryTotal(ctx, keeper)
+		case QueryDenom:
+			return queryDenom(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown supply query endpoint: %s", path[0]))
+		}
+	}
+}
+
+func queryTotal(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetDenomSupplies(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/supply/denom'
+type QueryDenomParams struct {
+	Denom string
+}
+
+// creates a new instance of QueryDenomParams
+func NewQueryDenomParams(denom string) QueryDenomParams {
+	return QueryDenomParams{
+		Denom: denom,
+	}
+}
+
+func queryDenom(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryDenomParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetDenomSupply(ctx, params.Denom))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
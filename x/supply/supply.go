@@ -0,0 +1,102 @@
+package supply
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// Supply is the supply tracked by the chain
+type Supply struct {
+	Total  sdk.Coins `json:"total"`  // coins in existence, including the locked ones
+	Burned sdk.Coins `json:"burned"` // coins taken out of the supply
+	Locked sdk.Coins `json:"locked"` // coins sent to other chains
+}
+
+func NewSupply(total, burned, locked sdk.Coins) Supply {
+	return Supply{
+		Total:  total,
+		Burned: burned,
+		Locked: locked,
+	}
+}
+
+// validate supply
+func validateSupply(supply Supply) error {
+	if !supply.Total.IsValid() {
+		return fmt.Errorf("supply total must be valid coins, is %s", supply.Total)
+	}
+	if !supply.Burned.IsValid() {
+		return fmt.Errorf("supply burned must be valid coins, is %s", supply.Burned)
+	}
+	if !supply.Locked.IsValid() {
+		return fmt.Errorf("supply locked must be valid coins, is %s", supply.Locked)
+	}
+	if !supply.Total.IsAllGTE(supply.Locked) {
+		return fmt.Errorf("supply locked %s exceeds the total %s", supply.Locked, supply.Total)
+	}
+	return nil
+}
+
+func (s Supply) String() string {
+	return fmt.Sprintf(`Supply:
+  Total:   %s
+  Burned:  %s
+  Locked:  %s`, s.Total, s.Burned, s.Locked)
+}
+
+// DenomSupply is the breakdown of the supply of a denomination
+type DenomSupply struct {
+	Denom         string  `json:"denom"`
+	Total         sdk.Int `json:"total"`
+	Circulating   sdk.Int `json:"circulating"`
+	Bonded        sdk.Int `json:"bonded"`
+	CommunityPool sdk.Int `json:"community_pool"`
+	Burned        sdk.Int `json:"burned"`
+	Locked        sdk.Int `json:"locked"`
+}
+
+// NewDenomSupply creates the breakdown of a denomination. Circulating coins
+// are the coins of the total that are not bonded, in the community pool or locked.
+func NewDenomSupply(denom string, total, bonded, communityPool, burned, locked sdk.Int) DenomSupply {
+	circulating := total.Sub(bonded).Sub(communityPool).Sub(locked)
+	if circulating.IsNegative() {
+		circulating = sdk.ZeroInt()
+	}
+
+	return DenomSupply{
+		Denom:         denom,
+		Total:         total,
+		Circulating:   circulating,
+		Bonded:        bonded,
+		CommunityPool: communityPool,
+		Burned:        burned,
+		Locked:        locked,
+	}
+}
+
+func (ds DenomSupply) String() string {
+	return fmt.Sprintf(`Supply of %s:
+  Total:           %s
+  Circulating:     %s
+  Bonded:          %s
+  Community Pool:  %s
+  Burned:          %s
+  Locked:          %s`,
+		ds.Denom, ds.Total, ds.Circulating, ds.Bonded, ds.CommunityPool, ds.Burned, ds.Locked)
+}
+
+// DenomSupplies is a list of denomination supplies
+type DenomSupplies []DenomSupply
+
+func (dss DenomSupplies) String() string {
+	if len(dss) == 0 {
+		return "[]"
+	}
+	out := make([]string, len(dss))
+	for i, ds := range dss {
+		out[i] = ds.String()
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,76 @@
+package supply
+
+import (
+	"testing"
+	"time"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/ColorPlatform/prism/libs/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/store"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+	"github.com/ColorPlatform/color-sdk/x/auth"
+	"github.com/ColorPlatform/color-sdk/x/bank"
+	distr "github.com/ColorPlatform/color-sdk/x/distribution"
+	distrtypes "github.com/ColorPlatform/color-sdk/x/distribution/types"
+	"github.com/ColorPlatform/color-sdk/x/params"
+	"github.com/ColorPlatform/color-sdk/x/staking"
+)
+
+type testInput struct {
+	ctx         sdk.Context
+	cdc         *codec.Codec
+	keeper      Keeper
+	bankKeeper  bank.Keeper
+	distrKeeper distr.Keeper
+	fck         auth.FeeCollectionKeeper
+}
+
+func newTestInput(t *testing.T) testInput {
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	db := dbm.NewMemDB()
+
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyFeeCollection := sdk.NewKVStoreKey(auth.FeeStoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keySupply := sdk.NewKVStoreKey(StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, nil)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
+	fck := auth.NewFeeCollectionKeeper(cdc, keyFeeCollection)
+	stakingKeeper := staking.NewKeeper(cdc, keyStaking, tkeyStaking, bankKeeper,
+		pk.Subspace(staking.DefaultParamspace), staking.DefaultCodespace)
+	distrKeeper := distr.NewKeeper(cdc, keyDistr, pk.Subspace(distr.DefaultParamspace), bankKeeper,
+		&stakingKeeper, fck, distr.DefaultCodespace)
+
+	keeper := NewKeeper(cdc, keySupply, accountKeeper, &stakingKeeper, distrKeeper, fck)
+	ctx := sdk.NewContext(ms, abci.Header{Height: 10, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	stakingKeeper.SetParams(ctx, staking.DefaultParams())
+	stakingKeeper.SetPool(ctx, staking.InitialPool())
+	distrKeeper.SetFeePool(ctx, distrtypes.FeePool{CommunityPool: sdk.DecCoins{}})
+
+	return testInput{ctx, cdc, keeper, bankKeeper, distrKeeper, fck}
+}
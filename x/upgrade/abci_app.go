@@ -0,0 +1,26 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// BeginBlocker applies the pending upgrade plan once it is due. If the running
+// binary has no handler for the plan, it raises sdk.UpgradeNeededError so that
+// BaseApp halts the node before the block is executed.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found || !plan.ShouldExecute(ctx) {
+		return
+	}
+
+	logger := ctx.Logger().With("module", "x/upgrade")
+	if !k.HasUpgradeHandler(plan.Name) {
+		logger.Error(fmt.Sprintf("upgrade %s is due but not supported by this binary, halting", plan.Name))
+		panic(sdk.UpgradeNeededError{Name: plan.Name, Height: ctx.BlockHeight(), Info: plan.Info})
+	}
+
+	logger.Info(fmt.Sprintf("applying upgrade %s at height %d", plan.Name, ctx.BlockHeight()))
+	k.ApplyUpgrade(ctx, plan)
+}
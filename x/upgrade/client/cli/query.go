@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ColorPlatform/color-sdk/client/context"
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
+)
+
+// GetCmdQueryPlan implements the query pending upgrade plan command.
+func GetCmdQueryPlan(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan",
+		Short: "Query the upgrade plan scheduled by governance, if any",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`
+Query the software upgrade plan scheduled by a passed software upgrade proposal:
+
+$ colorcli query upgrade plan
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryCurrent), nil)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return fmt.Errorf("no upgrade scheduled")
+			}
+
+			var plan upgrade.Plan
+			cdc.MustUnmarshalJSON(res, &plan)
+			return cliCtx.PrintOutput(plan)
+		},
+	}
+}
+
+// GetCmdQueryApplied implements the query applied upgrade plan command.
+func GetCmdQueryApplied(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "applied [upgrade-name]",
+		Short: "Query an applied upgrade plan and the height at which it was applied",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(`
+Query an upgrade plan applied by the chain, by name:
+
+$ colorcli query upgrade applied v2
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := upgrade.NewQueryAppliedParams(args[0])
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryApplied), bz)
+			if err != nil {
+				return err
+			}
+
+			var appliedPlan upgrade.AppliedPlan
+			cdc.MustUnmarshalJSON(res, &appliedPlan)
+			return cliCtx.PrintOutput(appliedPlan)
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/ColorPlatform/color-sdk/client"
+	"github.com/ColorPlatform/color-sdk/x/upgrade"
+	"github.com/ColorPlatform/color-sdk/x/upgrade/client/cli"
+)
+
+// ModuleClient exports all client functionality from the upgrade module
+type ModuleClient struct {
+	storeKey string
+	cdc      *amino.Codec
+}
+
+func NewModuleClient(storeKey string, cdc *amino.Codec) ModuleClient {
+	return ModuleClient{storeKey, cdc}
+}
+
+// GetQueryCmd returns the cli query commands for the upgrade module
+func (mc ModuleClient) GetQueryCmd() *cobra.Command {
+	upgradeQueryCmd := &cobra.Command{
+		Use:   upgrade.ModuleName,
+		Short: "Querying commands for the upgrade module",
+	}
+
+	upgradeQueryCmd.AddCommand(
+		client.GetCommands(
+			cli.GetCmdQueryPlan(mc.storeKey, mc.cdc),
+			cli.GetCmdQueryApplied(mc.storeKey, mc.cdc),
+		)...,
+	)
+
+	return upgradeQueryCmd
+}
+
+// GetTxCmd returns the transaction commands for the upgrade module.
+// Upgrades are scheduled through software upgrade proposals of the gov module.
+func (mc ModuleClient) GetTxCmd() *cobra.Command {
+	upgradeTxCmd := &cobra.Command{
+		Use:   upgrade.ModuleName,
+		Short: "Upgrade transaction subcommands",
+	}
+
+	return upgradeTxCmd
+}
@@ -0,0 +1,25 @@
+//nolint
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = ModuleName
+
+	CodeInvalidPlan        sdk.CodeType = 1
+	CodePlanAlreadyApplied sdk.CodeType = 2
+)
+
+// Error constructors
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, msg)
+}
+
+func ErrPlanAlreadyApplied(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodePlanAlreadyApplied, fmt.Sprintf("Upgrade plan %s has already been applied", name))
+}
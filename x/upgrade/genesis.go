@@ -0,0 +1,71 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// GenesisState - upgrade state
+type GenesisState struct {
+	Plan         *Plan         `json:"plan"`          // pending upgrade plan, if any
+	AppliedPlans []AppliedPlan `json:"applied_plans"` // upgrades applied by the chain so far
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(plan *Plan, appliedPlans []AppliedPlan) GenesisState {
+	return GenesisState{
+		Plan:         plan,
+		AppliedPlans: appliedPlans,
+	}
+}
+
+// DefaultGenesisState creates a default GenesisState object
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState(nil, nil)
+}
+
+// InitGenesis sets the pending plan and the applied plans. The pending plan is
+// not checked against the genesis block, an exported plan keeps its height.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, appliedPlan := range data.AppliedPlans {
+		keeper.setAppliedPlan(ctx, appliedPlan)
+	}
+	if data.Plan != nil {
+		keeper.setUpgradePlan(ctx, *data.Plan)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var plan *Plan
+	if pending, found := keeper.GetUpgradePlan(ctx); found {
+		plan = &pending
+	}
+	return NewGenesisState(plan, keeper.GetAppliedPlans(ctx))
+}
+
+// ValidateGenesis validates the provided genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	applied := make(map[string]bool, len(data.AppliedPlans))
+	for _, appliedPlan := range data.AppliedPlans {
+		if err := appliedPlan.Plan.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid applied upgrade plan: %s", err.Result().Log)
+		}
+		if applied[appliedPlan.Plan.Name] {
+			return fmt.Errorf("upgrade %s is applied more than once", appliedPlan.Plan.Name)
+		}
+		applied[appliedPlan.Plan.Name] = true
+	}
+
+	if data.Plan != nil {
+		if err := data.Plan.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid pending upgrade plan: %s", err.Result().Log)
+		}
+		if applied[data.Plan.Name] {
+			return fmt.Errorf("pending upgrade %s has already been applied", data.Plan.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "upgrade"
+
+	// StoreKey is the store key string for upgrade
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for upgrade
+	QuerierRoute = ModuleName
+)
+
+// Keys for upgrade store
+var (
+	planKey           = []byte{0x00} // key for the pending upgrade plan
+	AppliedPlanPrefix = []byte{0x01} // prefix for the applied plans, keyed by name
+)
+
+// AppliedPlanKey gets the key of an applied plan
+func AppliedPlanKey(name string) []byte {
+	return append(AppliedPlanPrefix, []byte(name)...)
+}
+
+// Handler migrates the state of the chain when the upgrade named by the plan is applied.
+// Binaries register a handler for every upgrade they know how to perform.
+type Handler func(ctx sdk.Context, plan Plan)
+
+// Keeper of the upgrade store
+type Keeper struct {
+	storeKey        sdk.StoreKey
+	cdc             *codec.Codec
+	upgradeHandlers map[string]Handler
+	codespace       sdk.CodespaceType
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:        key,
+		cdc:             cdc,
+		upgradeHandlers: map[string]Handler{},
+		codespace:       codespace,
+	}
+}
+
+// SetUpgradeHandler registers the handler applying the upgrade with the given name.
+// It must be called when the app is constructed, before any block is processed.
+func (k Keeper) SetUpgradeHandler(name string, handler Handler) {
+	k.upgradeHandlers[name] = handler
+}
+
+// HasUpgradeHandler returns true if the running binary can apply the upgrade with the given name
+func (k Keeper) HasUpgradeHandler(name string) bool {
+	_, ok := k.upgradeHandlers[name]
+	return ok
+}
+
+// ValidatePlan checks that a plan can be scheduled at the current block
+func (k Keeper) ValidatePlan(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if plan.Height > 0 && plan.Height <= ctx.BlockHeight() {
+		return ErrInvalidPlan(k.codespace, fmt.Sprintf("Upgrade height %d must be after the current height %d", plan.Height, ctx.BlockHeight()))
+	}
+	if !plan.Time.IsZero() && !plan.Time.After(ctx.BlockHeader().Time) {
+		return ErrInvalidPlan(k.codespace, fmt.Sprintf("Upgrade time %s must be after the current block time", plan.Time))
+	}
+	if _, ok := k.GetAppliedPlan(ctx, plan.Name); ok {
+		return ErrPlanAlreadyApplied(k.codespace, plan.Name)
+	}
+	return nil
+}
+
+// ScheduleUpgrade stores the plan as the pending upgrade, replacing any previously scheduled plan
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := k.ValidatePlan(ctx, plan); err != nil {
+		return err
+	}
+	k.setUpgradePlan(ctx, plan)
+	return nil
+}
+
+func (k Keeper) setUpgradePlan(ctx sdk.Context, plan Plan) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(planKey, k.cdc.MustMarshalBinaryLengthPrefixed(plan))
+}
+
+// GetUpgradePlan returns the pending upgrade plan, if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(planKey)
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan removes the pending upgrade plan
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(planKey)
+}
+
+// GetAppliedPlan returns the plan applied under the given name, if any
+func (k Keeper) GetAppliedPlan(ctx sdk.Context, name string) (appliedPlan AppliedPlan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(AppliedPlanKey(name))
+	if bz == nil {
+		return appliedPlan, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &appliedPlan)
+	return appliedPlan, true
+}
+
+// GetAppliedPlans returns all the applied plans, ordered by name
+func (k Keeper) GetAppliedPlans(ctx sdk.Context) (appliedPlans []AppliedPlan) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, AppliedPlanPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var appliedPlan AppliedPlan
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &appliedPlan)
+		appliedPlans = append(appliedPlans, appliedPlan)
+	}
+	return appliedPlans
+}
+
+func (k Keeper) setAppliedPlan(ctx sdk.Context, appliedPlan AppliedPlan) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(AppliedPlanKey(appliedPlan.Plan.Name), k.cdc.MustMarshalBinaryLengthPrefixed(appliedPlan))
+}
+
+// ApplyUpgrade runs the registered handler of the plan, records it as applied and
+// clears the pending plan. It panics if no handler is registered for the plan.
+func (k Keeper) ApplyUpgrade(ctx sdk.Context, plan Plan) {
+	handler, ok := k.upgradeHandlers[plan.Name]
+	if !ok {
+		panic(fmt.Sprintf("no upgrade handler registered for %s", plan.Name))
+	}
+	handler(ctx, plan)
+
+	k.setAppliedPlan(ctx, AppliedPlan{Plan: plan, Height: ctx.BlockHeight()})
+	k.ClearUpgradePlan(ctx)
+}
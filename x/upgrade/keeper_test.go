@@ -0,0 +1,144 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+func TestPlanValidateBasic(t *testing.T) {
+	tests := []struct {
+		plan       Plan
+		expectPass bool
+	}{
+		{NewPlan("v2", 100, time.Time{}, "info"), true},
+		{NewPlan("v2", 0, time.Unix(2000, 0), "info"), true},
+		{NewPlan("", 100, time.Time{}, "info"), false},
+		{NewPlan("v2", -1, time.Time{}, "info"), false},
+		{NewPlan("v2", 0, time.Time{}, "info"), false},
+		{NewPlan("v2", 100, time.Unix(2000, 0), "info"), false},
+	}
+
+	for i, tc := range tests {
+		err := tc.plan.ValidateBasic()
+		if tc.expectPass {
+			require.Nil(t, err, "test: %v", i)
+		} else {
+			require.NotNil(t, err, "test: %v", i)
+		}
+	}
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// plans must be in the future
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 10, time.Time{}, "")))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 0, time.Unix(1000, 0), "")))
+
+	plan := NewPlan("v2", 20, time.Time{}, "https://example.com/v2")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	stored, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	// a new plan replaces the pending one
+	plan = NewPlan("v3", 0, time.Unix(2000, 0).UTC(), "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	stored, _ = keeper.GetUpgradePlan(ctx)
+	require.Equal(t, plan, stored)
+
+	keeper.ClearUpgradePlan(ctx)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestBeginBlockerAppliesUpgrade(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	plan := NewPlan("v2", 12, time.Time{}, "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+
+	var applied []string
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan Plan) { applied = append(applied, plan.Name) })
+
+	// not due yet
+	BeginBlocker(ctx.WithBlockHeight(11), keeper)
+	require.Empty(t, applied)
+
+	BeginBlocker(ctx.WithBlockHeight(12), keeper)
+	require.Equal(t, []string{"v2"}, applied)
+
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	appliedPlan, found := keeper.GetAppliedPlan(ctx, "v2")
+	require.True(t, found)
+	require.Equal(t, AppliedPlan{Plan: plan, Height: 12}, appliedPlan)
+
+	// an applied plan cannot be scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("v2", 30, time.Time{}, "")))
+}
+
+func TestBeginBlockerUpgradeNeeded(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	plan := NewPlan("v2", 0, time.Unix(1500, 0).UTC(), "new binary")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+
+	require.NotPanics(t, func() { BeginBlocker(ctx, keeper) })
+
+	header := ctx.BlockHeader()
+	header.Time = time.Unix(1500, 0)
+	ctx = ctx.WithBlockHeader(header).WithBlockHeight(15)
+
+	defer func() {
+		r := recover()
+		require.Equal(t, sdk.UpgradeNeededError{Name: "v2", Height: 15, Info: "new binary"}, r)
+
+		// the plan is kept for the upgraded binary
+		_, found := keeper.GetUpgradePlan(ctx)
+		require.True(t, found)
+	}()
+	BeginBlocker(ctx, keeper)
+}
+
+func TestGenesis(t *testing.T) {
+	input := newTestInput(t)
+	ctx, keeper := input.ctx, input.keeper
+
+	require.Equal(t, DefaultGenesisState(), ExportGenesis(ctx, keeper))
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	keeper.SetUpgradeHandler("v2", func(ctx sdk.Context, plan Plan) {})
+	applied := NewPlan("v2", 12, time.Time{}, "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, applied))
+	keeper.ApplyUpgrade(ctx.WithBlockHeight(12), applied)
+	pending := NewPlan("v3", 30, time.Time{}, "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, pending))
+
+	genState := ExportGenesis(ctx, keeper)
+	require.Equal(t, NewGenesisState(&pending, []AppliedPlan{{Plan: applied, Height: 12}}), genState)
+	require.Nil(t, ValidateGenesis(genState))
+
+	// the plans are restored as exported, even when the pending one is due already
+	input = newTestInput(t)
+	InitGenesis(input.ctx.WithBlockHeight(40), input.keeper, genState)
+	require.Equal(t, genState, ExportGenesis(input.ctx, input.keeper))
+
+	// a pending plan cannot be applied already, nor a plan applied twice
+	genState.Plan = &applied
+	require.NotNil(t, ValidateGenesis(genState))
+	genState = NewGenesisState(nil, []AppliedPlan{{Plan: applied, Height: 12}, {Plan: applied, Height: 13}})
+	require.NotNil(t, ValidateGenesis(genState))
+	genState = NewGenesisState(&Plan{Name: "v4"}, nil)
+	require.NotNil(t, ValidateGenesis(genState))
+}
@@ -0,0 +1,77 @@
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// Plan specifies a software upgrade and the height or time at which it must happen.
+// Exactly one of Height and Time is set.
+type Plan struct {
+	Name   string    `json:"name"`   //  Name of the upgrade, binaries register their handler under this name
+	Time   time.Time `json:"time"`   //  Block time at which the upgrade happens
+	Height int64     `json:"height"` //  Block height at which the upgrade happens
+	Info   string    `json:"info"`   //  Additional information, e.g. where to find the new binary
+}
+
+func NewPlan(name string, height int64, upgradeTime time.Time, info string) Plan {
+	return Plan{
+		Name:   name,
+		Time:   upgradeTime,
+		Height: height,
+		Info:   info,
+	}
+}
+
+func (plan Plan) String() string {
+	return fmt.Sprintf(`Upgrade Plan
+  Name:   %s
+  %s
+  Info:   %s`, plan.Name, plan.DueAt(), plan.Info)
+}
+
+// DueAt returns a human readable description of when the upgrade happens
+func (plan Plan) DueAt() string {
+	if plan.Height > 0 {
+		return fmt.Sprintf("Height: %d", plan.Height)
+	}
+	return fmt.Sprintf("Time:   %s", plan.Time.UTC().Format(time.RFC3339))
+}
+
+// ValidateBasic performs the stateless checks on the plan
+func (plan Plan) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(plan.Name)) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "Upgrade plan name cannot be empty")
+	}
+	if plan.Height < 0 {
+		return ErrInvalidPlan(DefaultCodespace, "Upgrade plan height cannot be negative")
+	}
+	if plan.Height > 0 && !plan.Time.IsZero() {
+		return ErrInvalidPlan(DefaultCodespace, "Upgrade plan cannot set both height and time")
+	}
+	if plan.Height == 0 && plan.Time.IsZero() {
+		return ErrInvalidPlan(DefaultCodespace, "Upgrade plan must set either height or time")
+	}
+	return nil
+}
+
+// ShouldExecute returns true if the plan is due at the block of the context
+func (plan Plan) ShouldExecute(ctx sdk.Context) bool {
+	if plan.Height > 0 {
+		return ctx.BlockHeight() >= plan.Height
+	}
+	return !ctx.BlockHeader().Time.Before(plan.Time)
+}
+
+// AppliedPlan is an upgrade plan that has been applied by a running binary
+type AppliedPlan struct {
+	Plan   Plan  `json:"plan"`   //  The applied plan
+	Height int64 `json:"height"` //  Block height at which the plan was applied
+}
+
+func (ap AppliedPlan) String() string {
+	return fmt.Sprintf("%s\n  Applied At Height: %d", ap.Plan, ap.Height)
+}
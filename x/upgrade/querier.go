@@ -0,0 +1,74 @@
+package upgrade
+
+import (
+	"fmt"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+// query endpoints supported by the upgrade Querier
+const (
+	QueryCurrent = "current"
+	QueryApplied = "applied"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryCurrent:
+			return queryCurrent(ctx, keeper)
+		case QueryApplied:
+			return queryApplied(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown upgrade query endpoint: %s", path[0]))
+		}
+	}
+}
+
+// queryCurrent returns the pending plan, or no data if no upgrade is scheduled
+func queryCurrent(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	plan, found := keeper.GetUpgradePlan(ctx)
+	if !found {
+		return nil, nil
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, plan)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/upgrade/applied'
+type QueryAppliedParams struct {
+	Name string
+}
+
+// creates a new instance of QueryAppliedParams
+func NewQueryAppliedParams(name string) QueryAppliedParams {
+	return QueryAppliedParams{
+		Name: name,
+	}
+}
+
+func queryApplied(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryAppliedParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	appliedPlan, found := keeper.GetAppliedPlan(ctx, params.Name)
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("upgrade %s has not been applied", params.Name))
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, appliedPlan)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,38 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	abci "github.com/ColorPlatform/prism/abci/types"
+	dbm "github.com/ColorPlatform/prism/libs/db"
+	"github.com/ColorPlatform/prism/libs/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ColorPlatform/color-sdk/codec"
+	"github.com/ColorPlatform/color-sdk/store"
+	sdk "github.com/ColorPlatform/color-sdk/types"
+)
+
+type testInput struct {
+	ctx    sdk.Context
+	cdc    *codec.Codec
+	keeper Keeper
+}
+
+func newTestInput(t *testing.T) testInput {
+	cdc := codec.New()
+	db := dbm.NewMemDB()
+
+	keyUpgrade := sdk.NewKVStoreKey(StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyUpgrade, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	keeper := NewKeeper(cdc, keyUpgrade, DefaultCodespace)
+	ctx := sdk.NewContext(ms, abci.Header{Height: 10, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	return testInput{ctx, cdc, keeper}
+}